@@ -0,0 +1,253 @@
+package bytes
+
+import (
+	"bytes"
+
+	luc "github.com/PlayerR9/lib_units/common"
+)
+
+// ForwardSearch searches for the first occurrence of a separator in a byte slice.
+//
+// Parameters:
+//   - data: The byte slice to search in.
+//   - from: The index to start the search from. If negative, it is treated as 0.
+//   - sep: The separator to search for.
+//
+// Returns:
+//   - int: The index of the first occurrence of the separator at or after from, or -1
+//     if not found.
+func ForwardSearch(data []byte, from int, sep []byte) int {
+	sep_len := len(sep)
+
+	if sep_len == 0 || len(data) == 0 {
+		return -1
+	}
+
+	if from < 0 {
+		from = 0
+	}
+
+	if from+sep_len > len(data) {
+		return -1
+	}
+
+	idx := bytes.Index(data[from:], sep)
+	if idx == -1 {
+		return -1
+	}
+
+	return from + idx
+}
+
+// ReverseSearch searches for the last occurrence of a separator in a byte slice.
+//
+// Parameters:
+//   - data: The byte slice to search in.
+//   - from: The index to start the search from. If the separator does not fit at
+//     this index, it is treated as the last index at which the separator fits.
+//   - sep: The separator to search for.
+//
+// Returns:
+//   - int: The index of the last occurrence of the separator at or before from, or -1
+//     if not found.
+func ReverseSearch(data []byte, from int, sep []byte) int {
+	sep_len := len(sep)
+
+	if from < 0 || sep_len == 0 || sep_len > len(data) {
+		return -1
+	}
+
+	if from+sep_len > len(data) {
+		from = len(data) - sep_len
+	}
+
+	idx := bytes.LastIndex(data[:from+sep_len], sep)
+
+	return idx
+}
+
+// AllOccurrences returns the indices of every occurrence of the separator in the data.
+//
+// Parameters:
+//   - data: The byte slice to search in.
+//   - sep: The separator to search for.
+//   - allow_overlap: Whether overlapping occurrences are reported. For example, "aa"
+//     occurs at [0, 1, 2] in "aaaa" with overlaps and at [0, 2] without.
+//
+// Returns:
+//   - []int: The indices of the occurrences in ascending order. Nil if there are none.
+func AllOccurrences(data, sep []byte, allow_overlap bool) []int {
+	iter := NewOccurrenceIterator(data, sep, allow_overlap)
+
+	var indices []int
+
+	for {
+		idx, err := iter.Consume()
+		if err != nil {
+			break
+		}
+
+		indices = append(indices, idx)
+	}
+
+	return indices
+}
+
+// OccurrenceIterator is an iterator that lazily walks over the occurrences of a
+// separator in a byte slice.
+type OccurrenceIterator struct {
+	// data is the byte slice to search in.
+	data []byte
+
+	// sep is the separator to search for.
+	sep []byte
+
+	// allow_overlap is whether overlapping occurrences are reported.
+	allow_overlap bool
+
+	// pos is the position from which the next search starts.
+	pos int
+}
+
+// Consume returns the index of the next occurrence.
+//
+// Returns:
+//   - int: The index of the next occurrence.
+//   - error: An error if there are no more occurrences.
+//
+// Errors:
+//   - *common.ErrExhaustedIter: If there are no more occurrences.
+func (oi *OccurrenceIterator) Consume() (int, error) {
+	idx := ForwardSearch(oi.data, oi.pos, oi.sep)
+	if idx == -1 {
+		oi.pos = len(oi.data)
+
+		return -1, luc.NewErrExhaustedIter()
+	}
+
+	if oi.allow_overlap {
+		oi.pos = idx + 1
+	} else {
+		oi.pos = idx + len(oi.sep)
+	}
+
+	return idx, nil
+}
+
+// Restart restarts the iterator from the beginning of the data.
+func (oi *OccurrenceIterator) Restart() {
+	oi.pos = 0
+}
+
+// NewOccurrenceIterator creates a new OccurrenceIterator.
+//
+// Parameters:
+//   - data: The byte slice to search in.
+//   - sep: The separator to search for.
+//   - allow_overlap: Whether overlapping occurrences are reported.
+//
+// Returns:
+//   - *OccurrenceIterator: The new iterator. Never nil.
+//
+// If either data or sep is empty, the iterator is exhausted from the start.
+func NewOccurrenceIterator(data, sep []byte, allow_overlap bool) *OccurrenceIterator {
+	oi := &OccurrenceIterator{
+		data:          data,
+		sep:           sep,
+		allow_overlap: allow_overlap,
+		pos:           0,
+	}
+
+	return oi
+}
+
+// SearchWithMask searches for the first occurrence of a pattern in which some
+// bits are wildcards.
+//
+// Parameters:
+//   - data: The byte slice to search in.
+//   - pattern: The pattern to search for.
+//   - mask: The wildcard bits of each byte of the pattern. A 0xFF byte makes the
+//     whole position a wildcard and a 0x00 byte requires an exact match. If it is
+//     shorter than the pattern, the remaining positions require an exact match.
+//   - from: The index to start the search from. If negative, it is treated as 0.
+//
+// Returns:
+//   - int: The index of the first occurrence at or after from, or -1 if not found.
+//
+// Example:
+//
+//	// Match a "TLV" header with a type of 0x01 whatever its 2-byte length.
+//	SearchWithMask(data, []byte{0x01, 0, 0}, []byte{0x00, 0xFF, 0xFF}, 0)
+func SearchWithMask(data, pattern, mask []byte, from int) int {
+	if len(mask) == 0 {
+		return ForwardSearch(data, from, pattern)
+	}
+
+	pattern_len := len(pattern)
+
+	if pattern_len == 0 {
+		return -1
+	}
+
+	if from < 0 {
+		from = 0
+	}
+
+	// anchor is the first position of the pattern that requires an exact match;
+	// it lets bytes.IndexByte skip over the data.
+	anchor := -1
+
+	for i := range pattern {
+		if i >= len(mask) || mask[i] == 0 {
+			anchor = i
+			break
+		}
+	}
+
+	for start := from; start+pattern_len <= len(data); start++ {
+		if anchor != -1 {
+			idx := bytes.IndexByte(data[start+anchor:len(data)-pattern_len+anchor+1], pattern[anchor])
+			if idx == -1 {
+				return -1
+			}
+
+			start += idx
+		}
+
+		if masked_equal(data[start:start+pattern_len], pattern, mask) {
+			return start
+		}
+	}
+
+	return -1
+}
+
+// masked_equal checks whether a window of data matches a pattern, ignoring the
+// bits set in the mask.
+//
+// Parameters:
+//   - window: The data to compare.
+//   - pattern: The pattern.
+//   - mask: The wildcard bits.
+//
+// Returns:
+//   - bool: True if the window matches, false otherwise.
+//
+// Assertions:
+//   - len(window) == len(pattern)
+func masked_equal(window, pattern, mask []byte) bool {
+	for i, b := range pattern {
+		var m byte
+
+		if i < len(mask) {
+			m = mask[i]
+		}
+
+		if window[i]&^m != b&^m {
+			return false
+		}
+	}
+
+	return true
+}
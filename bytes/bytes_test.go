@@ -0,0 +1,364 @@
+package bytes
+
+import (
+	stdbytes "bytes"
+	"errors"
+	"math/rand"
+	"slices"
+	"testing"
+	"testing/iotest"
+
+	gcby "github.com/PlayerR9/go-commons/bytes"
+	gcint "github.com/PlayerR9/go-commons/ints"
+)
+
+func TestForwardSearch(t *testing.T) {
+	data := []byte("abcabc")
+
+	idx := ForwardSearch(data, 1, []byte("abc"))
+	if idx != 3 {
+		t.Errorf("expected index 3, got %d", idx)
+	}
+
+	idx = ForwardSearch(data, 0, []byte("abcabc"))
+	if idx != 0 {
+		t.Errorf("expected index 0, got %d", idx)
+	}
+
+	idx = ForwardSearch(data, 0, []byte("ax"))
+	if idx != -1 {
+		t.Errorf("expected index -1, got %d", idx)
+	}
+}
+
+func TestReverseSearch(t *testing.T) {
+	data := []byte("abcabc")
+
+	idx := ReverseSearch(data, 10, []byte("abc"))
+	if idx != 3 {
+		t.Errorf("expected index 3, got %d", idx)
+	}
+
+	idx = ReverseSearch(data, 2, []byte("abc"))
+	if idx != 0 {
+		t.Errorf("expected index 0, got %d", idx)
+	}
+
+	idx = ReverseSearch(data, 5, []byte("ax"))
+	if idx != -1 {
+		t.Errorf("expected index -1, got %d", idx)
+	}
+}
+
+func TestAllOccurrences(t *testing.T) {
+	data := []byte("aaaa")
+
+	indices := AllOccurrences(data, []byte("aa"), true)
+	if !slices.Equal(indices, []int{0, 1, 2}) {
+		t.Errorf("expected [0 1 2], got %v", indices)
+	}
+
+	indices = AllOccurrences(data, []byte("aa"), false)
+	if !slices.Equal(indices, []int{0, 2}) {
+		t.Errorf("expected [0 2], got %v", indices)
+	}
+}
+
+func TestCDCSplit(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+
+	data := make([]byte, 1<<16)
+	r.Read(data)
+
+	chunks := CDCSplit(data, 256, 1024, 4096)
+
+	var joined []byte
+
+	for i, chunk := range chunks {
+		if i < len(chunks)-1 && (len(chunk) < 256 || len(chunk) > 4096) {
+			t.Errorf("chunk %d has invalid size %d", i, len(chunk))
+		}
+
+		joined = append(joined, chunk...)
+	}
+
+	if !slices.Equal(joined, data) {
+		t.Fatalf("chunks do not rebuild the data")
+	}
+
+	iter := NewCDCIterator(iotest.OneByteReader(stdbytes.NewReader(data)), 256, 1024, 4096)
+
+	for i := 0; ; i++ {
+		chunk, err := iter.Consume()
+		if err != nil {
+			if i != len(chunks) {
+				t.Errorf("expected %d chunks, got %d", len(chunks), i)
+			}
+
+			break
+		}
+
+		if i >= len(chunks) || !slices.Equal(chunk, chunks[i]) {
+			t.Fatalf("chunk %d differs from CDCSplit", i)
+		}
+	}
+
+	// Inserting bytes at the front only changes the first chunks.
+	shifted := CDCSplit(append([]byte("prefix"), data...), 256, 1024, 4096)
+
+	last := shifted[len(shifted)-1]
+	if !slices.Equal(last, chunks[len(chunks)-1]) {
+		t.Errorf("expected the last chunk to be unaffected by the insertion")
+	}
+}
+
+func TestSearchWithMask(t *testing.T) {
+	data := []byte{0x00, 0x01, 0x10, 0x20, 0x02, 0x01, 0x33, 0x44, 0x02}
+
+	tests := []struct {
+		pattern, mask []byte
+		from, want    int
+	}{
+		{[]byte{0x01, 0, 0, 0x02}, []byte{0, 0xFF, 0xFF, 0}, 0, 1},
+		{[]byte{0x01, 0, 0, 0x02}, []byte{0, 0xFF, 0xFF, 0}, 2, 5},
+		{[]byte{0x01, 0, 0, 0x03}, []byte{0, 0xFF, 0xFF, 0}, 0, -1},
+		{[]byte{0, 0x30}, []byte{0xFF, 0x0F}, 0, 5},
+		{[]byte{0x20, 0x02}, nil, 0, 3},
+	}
+
+	for _, test := range tests {
+		got := SearchWithMask(data, test.pattern, test.mask, test.from)
+		if got != test.want {
+			t.Errorf("SearchWithMask(%x, %x, %d) = %d, want %d", test.pattern, test.mask, test.from, got, test.want)
+		}
+	}
+}
+
+func TestChecksum(t *testing.T) {
+	data := []byte("hello, world")
+
+	for _, algo := range []ChecksumAlgo{ChecksumCRC32, ChecksumFNV, ChecksumSHA256} {
+		summer := NewSummer(algo)
+
+		_, _ = summer.Write(data[:5])
+		_, _ = summer.Write(data[5:])
+
+		if summer.Sum() != Checksum(data, algo) {
+			t.Errorf("%s: expected the incremental checksum to be %s, got %s", algo, Checksum(data, algo), summer.Sum())
+		}
+	}
+
+	if got := Checksum([]byte("hello"), ChecksumCRC32); got != "3610a686" {
+		t.Errorf("expected crc32 3610a686, got %s", got)
+	}
+
+	if Checksum(data, ChecksumAlgo(-1)) != "" || NewSummer(ChecksumAlgo(-1)) != nil {
+		t.Errorf("expected unknown algorithms to be rejected")
+	}
+}
+
+func TestFindSubsliceFrom(t *testing.T) {
+	data := []byte("abababcab")
+
+	tests := []struct {
+		sep  string
+		at   int
+		want int
+	}{
+		{"ababc", 0, 2},
+		{"ab", 1, 2},
+		{"ab", -3, 0},
+		{"cab", 0, 6},
+		{"abd", 0, -1},
+		{"", 0, -1},
+	}
+
+	for _, test := range tests {
+		got := FindSubsliceFrom(data, []byte(test.sep), test.at)
+		if got != test.want {
+			t.Errorf("FindSubsliceFrom(%q, %d) = %d, want %d", test.sep, test.at, got, test.want)
+		}
+	}
+}
+
+func TestFindAllSubslices(t *testing.T) {
+	r := rand.New(rand.NewSource(7))
+
+	// A small alphabet makes partial matches and overlaps frequent.
+	data := make([]byte, 4096)
+	for i := range data {
+		data[i] = "ab"[r.Intn(2)]
+	}
+
+	for _, sep_len := range []int{1, 2, 3, 5, 8, 13} {
+		start := r.Intn(len(data) - sep_len)
+		sep := data[start : start+sep_len]
+
+		for _, allow_overlap := range []bool{true, false} {
+			want := AllOccurrences(data, sep, allow_overlap)
+
+			for _, algo := range []SearchAlgo{SearchAuto, SearchKMP, SearchBoyerMoore} {
+				got := FindAllSubslices(data, sep, allow_overlap, algo)
+				if !slices.Equal(got, want) {
+					t.Errorf("%s: %q (overlap %t): expected %d occurrences, got %d", algo, sep, allow_overlap, len(want), len(got))
+				}
+			}
+		}
+	}
+
+	if FindAllSubslices(data, nil, true, SearchAuto) != nil {
+		t.Errorf("expected no occurrences of an empty pattern")
+	}
+}
+
+// search_input returns a large input in which the pattern only occurs at the
+// very end, after many partial matches.
+func search_input(sep_len int) ([]byte, []byte) {
+	data := stdbytes.Repeat([]byte("abcdefgh"), 1<<17)
+
+	sep := stdbytes.Repeat([]byte("abcdefgh"), sep_len/8)
+	sep = append(sep, 'z')
+
+	data = append(data, sep...)
+
+	return data, sep
+}
+
+func BenchmarkStdIndex(b *testing.B) {
+	data, sep := search_input(64)
+
+	b.SetBytes(int64(len(data)))
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		stdbytes.Index(data, sep)
+	}
+}
+
+func BenchmarkFindSubsliceFrom(b *testing.B) {
+	data, sep := search_input(64)
+
+	b.SetBytes(int64(len(data)))
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		FindSubsliceFrom(data, sep, 0)
+	}
+}
+
+func BenchmarkFindAllSubslicesKMP(b *testing.B) {
+	data, sep := search_input(64)
+
+	b.SetBytes(int64(len(data)))
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		FindAllSubslices(data, sep, false, SearchKMP)
+	}
+}
+
+func BenchmarkFindAllSubslicesBoyerMoore(b *testing.B) {
+	data, sep := search_input(64)
+
+	b.SetBytes(int64(len(data)))
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		FindAllSubslices(data, sep, false, SearchBoyerMoore)
+	}
+}
+
+func TestFindAllContentIndexesNewline(t *testing.T) {
+	tokens := [][]byte{[]byte("a"), []byte("#"), []byte("b"), []byte("\n"), []byte("#"), []byte("c")}
+
+	regions, err := FindAllContentIndexes([]byte("#"), []byte("\n"), tokens)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := [][2]int{{2, 4}, {5, 6}}
+	if !slices.Equal(regions, expected) {
+		t.Errorf("expected %v, got %v", expected, regions)
+	}
+
+	_, err = FindAllContentIndexes([]byte("("), []byte(")"), stdbytes.Fields([]byte("a ( b")))
+
+	var not_found *gcby.ErrTokenNotFound
+
+	if !errors.As(err, &not_found) {
+		t.Errorf("expected *ErrTokenNotFound, got %v", err)
+	}
+}
+
+func TestFindAllContentIndexesQuotes(t *testing.T) {
+	tokens := stdbytes.Fields([]byte(`a " b c " d " e "`))
+
+	regions, err := FindAllContentIndexes([]byte(`"`), []byte(`"`), tokens)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := [][2]int{{2, 5}, {7, 9}}
+	if !slices.Equal(regions, expected) {
+		t.Errorf("expected %v, got %v", expected, regions)
+	}
+
+	_, err = FindAllContentIndexes(nil, []byte(")"), tokens)
+	if err == nil {
+		t.Errorf("expected an error for an empty opening token")
+	}
+}
+
+func TestTokenizer(t *testing.T) {
+	tk, err := NewTokenizer(
+		Delimiters{Open: []byte("{"), Close: []byte("}")},
+		Delimiters{Open: []byte("("), Close: []byte(")")},
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	tokens := stdbytes.Fields([]byte("a ( b { c } ) d { e }"))
+
+	regions, err := tk.Regions(tokens)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := []Region{{Pair: 1, Start: 2, End: 7}, {Pair: 0, Start: 9, End: 11}}
+	if !slices.Equal(regions, expected) {
+		t.Errorf("expected %v, got %v", expected, regions)
+	}
+
+	tests := []struct {
+		input string
+		idx   int
+		never bool
+	}{
+		{"a ) b", 2, true},
+		{"( a { b ) c", 3, false},
+		{"( a { b }", 1, false},
+	}
+
+	for _, test := range tests {
+		_, err := tk.Regions(stdbytes.Fields([]byte(test.input)))
+
+		var at *gcint.ErrAt
+
+		if !errors.As(err, &at) || at.Idx != test.idx {
+			t.Errorf("%q: expected an error at token %d, got %v", test.input, test.idx, err)
+			continue
+		}
+
+		var never *gcby.ErrNeverOpened
+
+		if errors.As(err, &never) != test.never {
+			t.Errorf("%q: unexpected reason %v", test.input, at.Reason)
+		}
+	}
+
+	_, err = NewTokenizer(Delimiters{Open: []byte("|"), Close: []byte("|")})
+	if err == nil {
+		t.Errorf("expected an error for identical delimiters")
+	}
+}
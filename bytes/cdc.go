@@ -0,0 +1,261 @@
+package bytes
+
+import (
+	"errors"
+	"io"
+	"math/bits"
+
+	luc "github.com/PlayerR9/lib_units/common"
+)
+
+var (
+	// gear_table is the table of random values used by the gear rolling hash.
+	gear_table [256]uint64
+)
+
+func init() {
+	// The table is generated with SplitMix64 from a fixed seed so that chunk
+	// boundaries are stable across runs and builds.
+	state := uint64(0x9E3779B97F4A7C15)
+
+	for i := range gear_table {
+		state += 0x9E3779B97F4A7C15
+
+		z := state
+		z = (z ^ (z >> 30)) * 0xBF58476D1CE4E5B9
+		z = (z ^ (z >> 27)) * 0x94D049BB133111EB
+
+		gear_table[i] = z ^ (z >> 31)
+	}
+}
+
+// cdc_params is the normalized set of parameters of content-defined chunking.
+type cdc_params struct {
+	// min_size is the minimum size of a chunk.
+	min_size int
+
+	// max_size is the maximum size of a chunk.
+	max_size int
+
+	// mask is the mask that selects the hash bits tested for a boundary.
+	mask uint64
+}
+
+// new_cdc_params is a helper function that normalizes the sizes of
+// content-defined chunking.
+//
+// Parameters:
+//   - min_size: The minimum size of a chunk. At least 1.
+//   - avg_size: The target average size of a chunk. At least min_size.
+//   - max_size: The maximum size of a chunk. At least avg_size.
+//
+// Returns:
+//   - cdc_params: The normalized parameters.
+func new_cdc_params(min_size, avg_size, max_size int) cdc_params {
+	min_size = max(min_size, 1)
+	avg_size = max(avg_size, min_size)
+	max_size = max(max_size, avg_size)
+
+	// A boundary is found, on average, every 2^n bytes past the minimum size
+	// when n bits of the hash are tested.
+	n := bits.Len(uint(max(avg_size-min_size, 1))) - 1
+
+	var mask uint64
+
+	if n > 0 {
+		// The top bits are used because, with the gear hash, they depend on
+		// the last 64 bytes while the low bits only depend on the last few.
+		mask = ^uint64(0) << (64 - n)
+	}
+
+	p := cdc_params{
+		min_size: min_size,
+		max_size: max_size,
+		mask:     mask,
+	}
+
+	return p
+}
+
+// cut_point returns the size of the first chunk of the data.
+//
+// Parameters:
+//   - data: The data to cut.
+//
+// Returns:
+//   - int: The size of the first chunk.
+//
+// Assertions:
+//   - len(data) > 0
+func (p cdc_params) cut_point(data []byte) int {
+	limit := min(len(data), p.max_size)
+	if limit <= p.min_size {
+		return limit
+	}
+
+	var hash uint64
+
+	for i := 0; i < limit; i++ {
+		hash = (hash << 1) + gear_table[data[i]]
+
+		if i+1 >= p.min_size && hash&p.mask == 0 {
+			return i + 1
+		}
+	}
+
+	return limit
+}
+
+// CDCSplit splits the data into content-defined chunks: boundaries are chosen
+// from the content itself using a rolling hash, so that inserting or removing
+// bytes only changes the chunks around the edit.
+//
+// Parameters:
+//   - data: The data to split.
+//   - minSize: The minimum size of a chunk. Values below 1 are treated as 1.
+//   - avgSize: The target average size of a chunk. Values below minSize are
+//     treated as minSize.
+//   - maxSize: The maximum size of a chunk. Values below avgSize are treated
+//     as avgSize.
+//
+// Returns:
+//   - [][]byte: The chunks. Nil if the data is empty.
+//
+// Behaviors:
+//   - Every chunk but the last is between minSize and maxSize bytes long.
+//   - The chunks share the memory of the data.
+//   - The same data and sizes always yield the same chunks.
+func CDCSplit(data []byte, minSize, avgSize, maxSize int) [][]byte {
+	if len(data) == 0 {
+		return nil
+	}
+
+	p := new_cdc_params(minSize, avgSize, maxSize)
+
+	var chunks [][]byte
+
+	for len(data) > 0 {
+		size := p.cut_point(data)
+
+		chunks = append(chunks, data[:size:size])
+		data = data[size:]
+	}
+
+	return chunks
+}
+
+// CDCIterator is an iterator that reads content-defined chunks from a reader,
+// holding at most one maximum-sized chunk in memory.
+type CDCIterator struct {
+	// r is the reader to read from.
+	r io.Reader
+
+	// p is the chunking parameters.
+	p cdc_params
+
+	// buf is the data read but not yet returned.
+	buf []byte
+
+	// eof is true if the reader has no more data.
+	eof bool
+}
+
+// fill is a helper method that reads until the buffer holds a maximum-sized
+// chunk or the reader is exhausted.
+//
+// Returns:
+//   - error: Any error returned by the reader other than io.EOF.
+func (ci *CDCIterator) fill() error {
+	for !ci.eof && len(ci.buf) < ci.p.max_size {
+		if cap(ci.buf) < ci.p.max_size {
+			buf := make([]byte, len(ci.buf), ci.p.max_size)
+			copy(buf, ci.buf)
+
+			ci.buf = buf
+		}
+
+		n, err := ci.r.Read(ci.buf[len(ci.buf):ci.p.max_size])
+		ci.buf = ci.buf[:len(ci.buf)+n]
+
+		if errors.Is(err, io.EOF) {
+			ci.eof = true
+		} else if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Consume implements the common.Iterater interface.
+//
+// Errors:
+//   - *common.ErrExhaustedIter: If the reader has no more data.
+//   - any error returned by the reader other than io.EOF.
+//
+// The returned chunk is a new slice that the caller may keep.
+func (ci *CDCIterator) Consume() ([]byte, error) {
+	err := ci.fill()
+	if err != nil {
+		return nil, err
+	}
+
+	if len(ci.buf) == 0 {
+		return nil, luc.NewErrExhaustedIter()
+	}
+
+	size := ci.p.cut_point(ci.buf)
+
+	chunk := make([]byte, size)
+	copy(chunk, ci.buf)
+
+	n := copy(ci.buf, ci.buf[size:])
+	ci.buf = ci.buf[:n]
+
+	return chunk, nil
+}
+
+// Restart implements the common.Iterater interface.
+//
+// If the reader implements io.Seeker, it is moved back to its start; otherwise,
+// Restart has no effect.
+func (ci *CDCIterator) Restart() {
+	seeker, ok := ci.r.(io.Seeker)
+	if !ok {
+		return
+	}
+
+	_, err := seeker.Seek(0, io.SeekStart)
+	if err != nil {
+		return
+	}
+
+	ci.buf = ci.buf[:0]
+	ci.eof = false
+}
+
+// NewCDCIterator creates a new CDCIterator.
+//
+// Parameters:
+//   - r: The reader to read from.
+//   - minSize: The minimum size of a chunk.
+//   - avgSize: The target average size of a chunk.
+//   - maxSize: The maximum size of a chunk.
+//
+// Returns:
+//   - *CDCIterator: The new iterator. Nil if r is nil.
+//
+// The sizes are normalized as in CDCSplit, and the chunks are the same as the
+// ones CDCSplit returns for the whole content of the reader.
+func NewCDCIterator(r io.Reader, minSize, avgSize, maxSize int) *CDCIterator {
+	if r == nil {
+		return nil
+	}
+
+	ci := &CDCIterator{
+		r: r,
+		p: new_cdc_params(minSize, avgSize, maxSize),
+	}
+
+	return ci
+}
@@ -0,0 +1,158 @@
+package bytes
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"hash"
+	"hash/crc32"
+	"hash/fnv"
+	"strconv"
+)
+
+// ChecksumAlgo is an algorithm used to compute checksums.
+type ChecksumAlgo int
+
+const (
+	// ChecksumCRC32 is CRC-32 with the IEEE polynomial. It is fast but only
+	// detects accidental changes.
+	ChecksumCRC32 ChecksumAlgo = iota
+
+	// ChecksumFNV is the 64-bit FNV-1a hash. It is fast but only detects
+	// accidental changes.
+	ChecksumFNV
+
+	// ChecksumSHA256 is SHA-256. It is slower but resists deliberate
+	// tampering.
+	ChecksumSHA256
+)
+
+// String implements the fmt.Stringer interface.
+func (algo ChecksumAlgo) String() string {
+	switch algo {
+	case ChecksumCRC32:
+		return "crc32"
+	case ChecksumFNV:
+		return "fnv"
+	case ChecksumSHA256:
+		return "sha256"
+	default:
+		return "ChecksumAlgo(" + strconv.Itoa(int(algo)) + ")"
+	}
+}
+
+// new_hash creates the hash of an algorithm.
+//
+// Returns:
+//   - hash.Hash: The hash. Nil if the algorithm is unknown.
+func (algo ChecksumAlgo) new_hash() hash.Hash {
+	switch algo {
+	case ChecksumCRC32:
+		return crc32.NewIEEE()
+	case ChecksumFNV:
+		return fnv.New64a()
+	case ChecksumSHA256:
+		return sha256.New()
+	default:
+		return nil
+	}
+}
+
+// Checksum computes the checksum of data.
+//
+// Parameters:
+//   - data: The data.
+//   - algo: The algorithm to use.
+//
+// Returns:
+//   - string: The checksum, in lower-case hexadecimal. Empty if the algorithm
+//     is unknown.
+//
+// Example:
+//
+//	Checksum([]byte("hello"), ChecksumCRC32) // "3610a686"
+func Checksum(data []byte, algo ChecksumAlgo) string {
+	h := algo.new_hash()
+	if h == nil {
+		return ""
+	}
+
+	_, _ = h.Write(data)
+
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// Summer computes a checksum incrementally, as data is written to it. It
+// implements io.Writer so that it can be used with io.Copy or io.MultiWriter.
+type Summer struct {
+	// algo is the algorithm in use.
+	algo ChecksumAlgo
+
+	// h is the running hash.
+	h hash.Hash
+
+	// size is the number of bytes written since the last Reset.
+	size int64
+}
+
+// NewSummer creates a new Summer.
+//
+// Parameters:
+//   - algo: The algorithm to use.
+//
+// Returns:
+//   - *Summer: The new Summer. Nil if the algorithm is unknown.
+func NewSummer(algo ChecksumAlgo) *Summer {
+	h := algo.new_hash()
+	if h == nil {
+		return nil
+	}
+
+	s := &Summer{
+		algo: algo,
+		h:    h,
+	}
+
+	return s
+}
+
+// Write implements the io.Writer interface.
+//
+// It never returns an error.
+func (s *Summer) Write(p []byte) (int, error) {
+	n, _ := s.h.Write(p)
+	s.size += int64(n)
+
+	return n, nil
+}
+
+// Sum returns the checksum of the data written so far. More data can be
+// written afterwards.
+//
+// Returns:
+//   - string: The checksum, in lower-case hexadecimal; the same as Checksum
+//     would return for the concatenation of the data written.
+func (s *Summer) Sum() string {
+	return hex.EncodeToString(s.h.Sum(nil))
+}
+
+// Size returns the number of bytes written since the last Reset.
+//
+// Returns:
+//   - int64: The number of bytes.
+func (s *Summer) Size() int64 {
+	return s.size
+}
+
+// Algo returns the algorithm in use.
+//
+// Returns:
+//   - ChecksumAlgo: The algorithm.
+func (s *Summer) Algo() ChecksumAlgo {
+	return s.algo
+}
+
+// Reset forgets the data written so far.
+func (s *Summer) Reset() {
+	s.h.Reset()
+	s.size = 0
+}
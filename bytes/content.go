@@ -0,0 +1,89 @@
+package bytes
+
+import (
+	"bytes"
+
+	gcby "github.com/PlayerR9/go-commons/bytes"
+	gcers "github.com/PlayerR9/go-commons/errors"
+	gcint "github.com/PlayerR9/go-commons/ints"
+)
+
+// FindAllContentIndexes is like FindContentIndexes of go-commons but returns every
+// balanced top-level region instead of only the first one.
+//
+// Parameters:
+//   - op_token: The token that marks the beginning of a region.
+//   - cl_token: The token that marks the end of a region.
+//   - tokens: The slice of tokens in which to search for the regions.
+//
+// Returns:
+//   - [][2]int: The start and end indexes of each region, in order. Nil if there
+//     are no regions.
+//   - error: Any error that occurred while searching for the regions.
+//
+// Errors:
+//   - *errors.ErrInvalidParameter: If the op_token or the cl_token is empty.
+//   - *ints.ErrAt: If a region is malformed. Its index is the 1-based number of the
+//     region and its reason is one of the following:
+//   - *bytes.ErrNeverOpened: If a closing token is found without any corresponding
+//     opening token.
+//   - *bytes.ErrTokenNotFound: If the last region is never closed.
+//
+// Behaviors:
+//   - As in FindContentIndexes, the first index of a region is inclusive, while the
+//     second index is exclusive and points right after the closing token.
+//   - Unlike in FindContentIndexes, a last region closed by "\n" may end at the
+//     end of the tokens, so that the last line needs no newline; any other
+//     region that is never closed is an error.
+//   - If op_token and cl_token are the same, as with quotes, regions cannot
+//     nest: the token opens a region outside of one and closes it inside.
+//   - When an error occurs, the regions found before the error are returned.
+func FindAllContentIndexes(op_token, cl_token []byte, tokens [][]byte) ([][2]int, error) {
+	if len(op_token) == 0 {
+		return nil, gcers.NewErrInvalidParameter("op_token", gcers.NewErrEmpty(op_token))
+	} else if len(cl_token) == 0 {
+		return nil, gcers.NewErrInvalidParameter("cl_token", gcers.NewErrEmpty(cl_token))
+	}
+
+	var regions [][2]int
+
+	balance := 0
+	start := -1
+
+	for i, curr_tok := range tokens {
+		// Outside of a region, opening takes precedence so that a token that
+		// both opens and closes starts a region.
+		if balance == 0 && bytes.Equal(curr_tok, op_token) {
+			start = i + 1
+			balance++
+		} else if bytes.Equal(curr_tok, cl_token) {
+			if balance == 0 {
+				reason := gcby.NewErrNeverOpened(op_token, cl_token)
+
+				return regions, gcint.NewErrAt(len(regions)+1, "region", reason)
+			}
+
+			balance--
+
+			if balance == 0 {
+				regions = append(regions, [2]int{start, i + 1})
+			}
+		} else if bytes.Equal(curr_tok, op_token) {
+			balance++
+		}
+	}
+
+	if balance == 0 {
+		return regions, nil
+	}
+
+	if balance == 1 && bytes.Equal(cl_token, []byte("\n")) {
+		regions = append(regions, [2]int{start, len(tokens)})
+
+		return regions, nil
+	}
+
+	reason := gcby.NewErrTokenNotFound(cl_token, false)
+
+	return regions, gcint.NewErrAt(len(regions)+1, "region", reason)
+}
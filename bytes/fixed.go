@@ -0,0 +1,104 @@
+package bytes
+
+// PadTo pads the data with the fill byte until it reaches the given size.
+//
+// Parameters:
+//   - data: The data to pad.
+//   - size: The size to reach.
+//   - fill: The byte to pad with.
+//
+// Returns:
+//   - []byte: The padded data. If the data is already at least size bytes long,
+//     it is returned as is (it is never truncated).
+//
+// The data is never modified; a new slice is returned when padding is needed.
+func PadTo(data []byte, size int, fill byte) []byte {
+	if len(data) >= size {
+		return data
+	}
+
+	padded := make([]byte, size)
+	copy(padded, data)
+
+	for i := len(data); i < size; i++ {
+		padded[i] = fill
+	}
+
+	return padded
+}
+
+// ChunkFixed splits the data into chunks of the given size.
+//
+// Parameters:
+//   - data: The data to split.
+//   - size: The size of each chunk.
+//
+// Returns:
+//   - [][]byte: The chunks. Nil if the data is empty or size is not positive.
+//   - bool: True if the last chunk is shorter than size, false otherwise.
+//
+// The chunks share the memory of the data.
+func ChunkFixed(data []byte, size int) ([][]byte, bool) {
+	if len(data) == 0 || size <= 0 {
+		return nil, false
+	}
+
+	chunks := make([][]byte, 0, (len(data)+size-1)/size)
+
+	for i := 0; i < len(data); i += size {
+		end := min(i+size, len(data))
+
+		chunks = append(chunks, data[i:end:end])
+	}
+
+	is_short := len(data)%size != 0
+
+	return chunks, is_short
+}
+
+// ChunkFixedPadded is like ChunkFixed but pads the last chunk with the fill
+// byte so that every chunk is exactly size bytes long.
+//
+// Parameters:
+//   - data: The data to split.
+//   - size: The size of each chunk.
+//   - fill: The byte to pad the last chunk with.
+//
+// Returns:
+//   - [][]byte: The chunks. Nil if the data is empty or size is not positive.
+func ChunkFixedPadded(data []byte, size int, fill byte) [][]byte {
+	chunks, is_short := ChunkFixed(data, size)
+
+	if is_short {
+		last := len(chunks) - 1
+		chunks[last] = PadTo(chunks[last], size, fill)
+	}
+
+	return chunks
+}
+
+// AlignOffset rounds the offset up to the next multiple of the alignment.
+//
+// Parameters:
+//   - offset: The offset to align.
+//   - alignment: The alignment.
+//
+// Returns:
+//   - int: The aligned offset. If alignment is not positive, offset is returned
+//     as is.
+func AlignOffset(offset, alignment int) int {
+	if alignment <= 0 {
+		return offset
+	}
+
+	rem := offset % alignment
+	if rem == 0 {
+		return offset
+	}
+
+	if rem < 0 {
+		return offset - rem
+	}
+
+	return offset + alignment - rem
+}
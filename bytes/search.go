@@ -0,0 +1,277 @@
+package bytes
+
+import (
+	"strconv"
+)
+
+// SearchAlgo is an algorithm used to search for a subslice.
+type SearchAlgo int
+
+const (
+	// SearchAuto uses SearchBoyerMoore for patterns of at least
+	// BoyerMooreThreshold bytes and SearchKMP for shorter ones.
+	SearchAuto SearchAlgo = iota
+
+	// SearchKMP is the Knuth-Morris-Pratt algorithm. It never reads a byte of
+	// the data twice, whatever the pattern.
+	SearchKMP
+
+	// SearchBoyerMoore is the Boyer-Moore-Horspool algorithm. It skips over
+	// the data by up to the length of the pattern, which makes it faster for
+	// long patterns.
+	SearchBoyerMoore
+)
+
+// BoyerMooreThreshold is the length from which SearchAuto uses
+// SearchBoyerMoore.
+const BoyerMooreThreshold int = 8
+
+// String implements the fmt.Stringer interface.
+func (algo SearchAlgo) String() string {
+	switch algo {
+	case SearchAuto:
+		return "auto"
+	case SearchKMP:
+		return "kmp"
+	case SearchBoyerMoore:
+		return "boyer-moore"
+	default:
+		return "SearchAlgo(" + strconv.Itoa(int(algo)) + ")"
+	}
+}
+
+// searcher is a pattern prepared for an algorithm.
+type searcher interface {
+	// find_all returns the indices of the occurrences of the pattern.
+	//
+	// Parameters:
+	//   - data: The byte slice to search in.
+	//   - at: The index to start the search from. Never negative.
+	//   - allow_overlap: Whether overlapping occurrences are reported.
+	//   - limit: The maximum number of indices to return. Negative if there is
+	//     no limit.
+	//
+	// Returns:
+	//   - []int: The indices, in ascending order. Nil if there are none.
+	find_all(data []byte, at int, allow_overlap bool, limit int) []int
+}
+
+// new_searcher prepares a pattern for an algorithm.
+//
+// Parameters:
+//   - sep: The pattern. Must not be empty.
+//   - algo: The algorithm to use. Unknown algorithms are treated as SearchAuto.
+//
+// Returns:
+//   - searcher: The searcher. Never nil.
+func new_searcher(sep []byte, algo SearchAlgo) searcher {
+	if algo != SearchKMP && algo != SearchBoyerMoore {
+		if len(sep) >= BoyerMooreThreshold {
+			algo = SearchBoyerMoore
+		} else {
+			algo = SearchKMP
+		}
+	}
+
+	if algo == SearchBoyerMoore {
+		return new_horspool_searcher(sep)
+	}
+
+	return new_kmp_searcher(sep)
+}
+
+// kmp_searcher is a pattern prepared for the Knuth-Morris-Pratt algorithm.
+type kmp_searcher struct {
+	// sep is the pattern.
+	sep []byte
+
+	// lps are, for each prefix of sep, the length of its longest proper prefix
+	// that is also a suffix of it.
+	lps []int
+}
+
+// new_kmp_searcher prepares a pattern for the Knuth-Morris-Pratt algorithm.
+//
+// Parameters:
+//   - sep: The pattern. Must not be empty.
+//
+// Returns:
+//   - *kmp_searcher: The searcher. Never nil.
+func new_kmp_searcher(sep []byte) *kmp_searcher {
+	lps := make([]int, len(sep))
+
+	var length int
+
+	for i := 1; i < len(sep); {
+		if sep[i] == sep[length] {
+			length++
+			lps[i] = length
+			i++
+		} else if length != 0 {
+			length = lps[length-1]
+		} else {
+			i++
+		}
+	}
+
+	ks := &kmp_searcher{
+		sep: sep,
+		lps: lps,
+	}
+
+	return ks
+}
+
+// find_all implements the searcher interface.
+func (ks *kmp_searcher) find_all(data []byte, at int, allow_overlap bool, limit int) []int {
+	var indices []int
+
+	var j int
+
+	for i := at; i < len(data) && limit != 0; {
+		if data[i] == ks.sep[j] {
+			i++
+			j++
+
+			if j < len(ks.sep) {
+				continue
+			}
+
+			indices = append(indices, i-j)
+			limit--
+
+			if allow_overlap {
+				j = ks.lps[j-1]
+			} else {
+				j = 0
+			}
+		} else if j != 0 {
+			j = ks.lps[j-1]
+		} else {
+			i++
+		}
+	}
+
+	return indices
+}
+
+// horspool_searcher is a pattern prepared for the Boyer-Moore-Horspool
+// algorithm.
+type horspool_searcher struct {
+	// sep is the pattern.
+	sep []byte
+
+	// shifts are, for each byte, how far the pattern can move when that byte
+	// is under its last position and the pattern does not match.
+	shifts [256]int
+}
+
+// new_horspool_searcher prepares a pattern for the Boyer-Moore-Horspool
+// algorithm.
+//
+// Parameters:
+//   - sep: The pattern. Must not be empty.
+//
+// Returns:
+//   - *horspool_searcher: The searcher. Never nil.
+func new_horspool_searcher(sep []byte) *horspool_searcher {
+	hs := &horspool_searcher{
+		sep: sep,
+	}
+
+	last := len(sep) - 1
+
+	for i := range hs.shifts {
+		hs.shifts[i] = len(sep)
+	}
+
+	for i, b := range sep[:last] {
+		hs.shifts[b] = last - i
+	}
+
+	return hs
+}
+
+// find_all implements the searcher interface.
+func (hs *horspool_searcher) find_all(data []byte, at int, allow_overlap bool, limit int) []int {
+	var indices []int
+
+	last := len(hs.sep) - 1
+
+	for i := at; i+last < len(data) && limit != 0; {
+		b := data[i+last]
+
+		if b == hs.sep[last] && string(data[i:i+last]) == string(hs.sep[:last]) {
+			indices = append(indices, i)
+			limit--
+
+			if allow_overlap {
+				i++
+			} else {
+				i += len(hs.sep)
+			}
+
+			continue
+		}
+
+		i += hs.shifts[b]
+	}
+
+	return indices
+}
+
+// FindSubsliceFrom finds the first occurrence of a subslice in a byte slice
+// starting from a given index, with the Knuth-Morris-Pratt algorithm.
+//
+// Parameters:
+//   - data: The byte slice to search in.
+//   - sep: The subslice to search for.
+//   - at: The index to start the search from. If negative, it is treated as 0.
+//
+// Returns:
+//   - int: The index of the first occurrence of the subslice at or after at,
+//     or -1 if not found or if either data or sep is empty.
+//
+// This mirrors slices.FindSubsliceFrom of go-commons. For repeated searches of
+// the same pattern, or long patterns, FindAllSubslices avoids preparing the
+// pattern more than once and can use SearchBoyerMoore.
+func FindSubsliceFrom(data, sep []byte, at int) int {
+	if len(sep) == 0 || len(data) == 0 {
+		return -1
+	}
+
+	if at < 0 {
+		at = 0
+	}
+
+	indices := new_kmp_searcher(sep).find_all(data, at, false, 1)
+	if len(indices) == 0 {
+		return -1
+	}
+
+	return indices[0]
+}
+
+// FindAllSubslices returns the indices of every occurrence of a subslice in a
+// byte slice.
+//
+// Parameters:
+//   - data: The byte slice to search in.
+//   - sep: The subslice to search for.
+//   - allow_overlap: Whether overlapping occurrences are reported. For example,
+//     "aa" occurs at [0, 1, 2] in "aaaa" with overlaps and at [0, 2] without.
+//   - algo: The algorithm to use. Unknown algorithms are treated as SearchAuto.
+//
+// Returns:
+//   - []int: The indices of the occurrences in ascending order. Nil if there are
+//     none or if either data or sep is empty.
+//
+// Unlike AllOccurrences, the pattern is prepared once and the data is scanned
+// in a single pass; every algorithm yields the same indices.
+func FindAllSubslices(data, sep []byte, allow_overlap bool, algo SearchAlgo) []int {
+	if len(sep) == 0 || len(data) == 0 {
+		return nil
+	}
+
+	return new_searcher(sep, algo).find_all(data, 0, allow_overlap, -1)
+}
@@ -0,0 +1,138 @@
+package bytes
+
+import (
+	"bytes"
+
+	gcby "github.com/PlayerR9/go-commons/bytes"
+	gcers "github.com/PlayerR9/go-commons/errors"
+	gcint "github.com/PlayerR9/go-commons/ints"
+	"github.com/PlayerR9/lib_units/internal/tokenizer"
+)
+
+// Delimiters is a pair of tokens that open and close a region.
+type Delimiters struct {
+	// Open is the token that marks the beginning of a region.
+	Open []byte
+
+	// Close is the token that marks the end of a region.
+	Close []byte
+}
+
+// Region is a balanced top-level region found by a Tokenizer.
+type Region struct {
+	// Pair is the index of the delimiters of the region.
+	Pair int
+
+	// Start is the index of the first token after the opening token.
+	Start int
+
+	// End is the index right after the closing token.
+	End int
+}
+
+// Tokenizer finds the balanced regions of a slice of tokens, for several kinds
+// of delimiters at once.
+type Tokenizer struct {
+	// pairs are the delimiters.
+	pairs []tokenizer.Pair[[]byte]
+}
+
+// is_empty_token checks whether a token is empty.
+//
+// Parameters:
+//   - tok: The token.
+//
+// Returns:
+//   - bool: True if the token is empty, false otherwise.
+func is_empty_token(tok []byte) bool {
+	return len(tok) == 0
+}
+
+// NewTokenizer creates a new Tokenizer.
+//
+// Parameters:
+//   - pairs: The delimiters, such as "{" and "}", "[" and "]", and "(" and ")".
+//
+// Returns:
+//   - *Tokenizer: The new Tokenizer. Nil if an error occurred.
+//   - error: An error if the delimiters are invalid.
+//
+// Errors:
+//   - *errors.ErrInvalidParameter: If there are no delimiters, if a token is
+//     empty, or if a pair opens and closes with the same token.
+func NewTokenizer(pairs ...Delimiters) (*Tokenizer, error) {
+	if len(pairs) == 0 {
+		return nil, gcers.NewErrInvalidParameter("pairs", gcers.NewErrEmpty(pairs))
+	}
+
+	t := &Tokenizer{
+		pairs: make([]tokenizer.Pair[[]byte], 0, len(pairs)),
+	}
+
+	for _, pair := range pairs {
+		t.pairs = append(t.pairs, tokenizer.Pair[[]byte]{Open: pair.Open, Close: pair.Close})
+	}
+
+	err := tokenizer.Check(t.pairs, is_empty_token, bytes.Equal)
+	if err != nil {
+		return nil, err
+	}
+
+	return t, nil
+}
+
+// Regions walks a slice of tokens and returns its balanced top-level regions.
+// Regions of any kind may be nested in each other.
+//
+// Parameters:
+//   - tokens: The slice of tokens in which to search for the regions.
+//
+// Returns:
+//   - []Region: The regions, in order. Nil if there are none.
+//   - error: An error if the regions are not balanced.
+//
+// Errors:
+//   - *ints.ErrAt: If a region is malformed. Its index is the 1-based
+//     position of the offending token and its reason is one of the following:
+//   - *bytes.ErrNeverOpened: If a closing token is found without any
+//     corresponding opening token.
+//   - *bytes.ErrTokenNotFound: If a region is never closed, or if it is still
+//     open when an outer region is closed. The offending token is the one that
+//     opened the region.
+//
+// Example:
+//
+//	t, _ := NewTokenizer(Delimiters{Open: []byte("("), Close: []byte(")")})
+//	regions, _ := t.Regions(tokens) // for "a ( b ( c ) ) d ( e )"
+//	// [{0 2 7} {0 9 11}]
+//
+// Behaviors:
+//   - As in FindAllContentIndexes, and unlike in FindContentIndexes of
+//     go-commons, a last region closed by "\n" may end at the end of the
+//     tokens.
+//   - When an error occurs, the regions found before the error are returned.
+func (t *Tokenizer) Regions(tokens [][]byte) ([]Region, error) {
+	found, failure := tokenizer.Regions(t.pairs, tokens, []byte("\n"), bytes.Equal)
+
+	var regions []Region
+
+	for _, region := range found {
+		regions = append(regions, Region(region))
+	}
+
+	if failure == nil {
+		return regions, nil
+	}
+
+	pair := t.pairs[failure.Pair]
+
+	var reason error
+
+	if failure.NeverOpened {
+		reason = gcby.NewErrNeverOpened(pair.Open, pair.Close)
+	} else {
+		reason = gcby.NewErrTokenNotFound(pair.Close, false)
+	}
+
+	return regions, gcint.NewErrAt(failure.Index+1, "token", reason)
+}
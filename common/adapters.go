@@ -0,0 +1,277 @@
+package common
+
+import (
+	"github.com/PlayerR9/lib_units/pair"
+)
+
+// MapIterator is an iterator that transforms the elements of another
+// iterator.
+type MapIterator[T, U any] struct {
+	// src is the wrapped iterator.
+	src Iterater[T]
+
+	// f is the transformation function.
+	f func(T) (U, error)
+}
+
+// Consume implements the Iterater interface.
+//
+// Errors of the wrapped iterator and of the transformation function are
+// returned as is.
+func (mi *MapIterator[T, U]) Consume() (U, error) {
+	value, err := mi.src.Consume()
+	if err != nil {
+		return *new(U), err
+	}
+
+	return mi.f(value)
+}
+
+// Restart implements the Iterater interface.
+func (mi *MapIterator[T, U]) Restart() {
+	mi.src.Restart()
+}
+
+// NewMapIterator creates a new MapIterator.
+//
+// Parameters:
+//   - src: The iterator to wrap.
+//   - f: The transformation function.
+//
+// Returns:
+//   - *MapIterator[T, U]: The new iterator. Nil if src or f is nil.
+func NewMapIterator[T, U any](src Iterater[T], f func(T) (U, error)) *MapIterator[T, U] {
+	if src == nil || f == nil {
+		return nil
+	}
+
+	mi := &MapIterator[T, U]{
+		src: src,
+		f:   f,
+	}
+
+	return mi
+}
+
+// FilterIterator is an iterator that skips the elements of another iterator
+// that do not satisfy a predicate.
+type FilterIterator[T any] struct {
+	// src is the wrapped iterator.
+	src Iterater[T]
+
+	// pred is the predicate.
+	pred func(T) bool
+}
+
+// Consume implements the Iterater interface.
+func (fi *FilterIterator[T]) Consume() (T, error) {
+	for {
+		value, err := fi.src.Consume()
+		if err != nil {
+			return value, err
+		}
+
+		if fi.pred(value) {
+			return value, nil
+		}
+	}
+}
+
+// Restart implements the Iterater interface.
+func (fi *FilterIterator[T]) Restart() {
+	fi.src.Restart()
+}
+
+// NewFilterIterator creates a new FilterIterator.
+//
+// Parameters:
+//   - src: The iterator to wrap.
+//   - pred: The predicate that elements must satisfy.
+//
+// Returns:
+//   - *FilterIterator[T]: The new iterator. Nil if src or pred is nil.
+func NewFilterIterator[T any](src Iterater[T], pred func(T) bool) *FilterIterator[T] {
+	if src == nil || pred == nil {
+		return nil
+	}
+
+	fi := &FilterIterator[T]{
+		src:  src,
+		pred: pred,
+	}
+
+	return fi
+}
+
+// TakeIterator is an iterator that yields at most a given number of elements
+// of another iterator.
+type TakeIterator[T any] struct {
+	// src is the wrapped iterator.
+	src Iterater[T]
+
+	// limit is the maximum number of elements to yield.
+	limit int
+
+	// count is the number of elements yielded so far.
+	count int
+}
+
+// Consume implements the Iterater interface.
+//
+// Once the limit is reached, the wrapped iterator is no longer consumed.
+func (ti *TakeIterator[T]) Consume() (T, error) {
+	if ti.count >= ti.limit {
+		return *new(T), NewErrExhaustedIter()
+	}
+
+	value, err := ti.src.Consume()
+	if err != nil {
+		return value, err
+	}
+
+	ti.count++
+
+	return value, nil
+}
+
+// Restart implements the Iterater interface.
+func (ti *TakeIterator[T]) Restart() {
+	ti.src.Restart()
+
+	ti.count = 0
+}
+
+// NewTakeIterator creates a new TakeIterator.
+//
+// Parameters:
+//   - src: The iterator to wrap.
+//   - n: The maximum number of elements. Negative values are treated as 0.
+//
+// Returns:
+//   - *TakeIterator[T]: The new iterator. Nil if src is nil.
+func NewTakeIterator[T any](src Iterater[T], n int) *TakeIterator[T] {
+	if src == nil {
+		return nil
+	}
+
+	ti := &TakeIterator[T]{
+		src:   src,
+		limit: max(n, 0),
+	}
+
+	return ti
+}
+
+// SkipIterator is an iterator that discards the first elements of another
+// iterator.
+type SkipIterator[T any] struct {
+	// src is the wrapped iterator.
+	src Iterater[T]
+
+	// n is the number of elements to discard.
+	n int
+
+	// skipped is true once the elements have been discarded.
+	skipped bool
+}
+
+// Consume implements the Iterater interface.
+//
+// The elements are discarded on the first call; if the wrapped iterator fails
+// while doing so, the error is returned.
+func (si *SkipIterator[T]) Consume() (T, error) {
+	if !si.skipped {
+		for i := 0; i < si.n; i++ {
+			value, err := si.src.Consume()
+			if err != nil {
+				return value, err
+			}
+		}
+
+		si.skipped = true
+	}
+
+	return si.src.Consume()
+}
+
+// Restart implements the Iterater interface.
+func (si *SkipIterator[T]) Restart() {
+	si.src.Restart()
+
+	si.skipped = false
+}
+
+// NewSkipIterator creates a new SkipIterator.
+//
+// Parameters:
+//   - src: The iterator to wrap.
+//   - n: The number of elements to discard. Negative values are treated as 0.
+//
+// Returns:
+//   - *SkipIterator[T]: The new iterator. Nil if src is nil.
+func NewSkipIterator[T any](src Iterater[T], n int) *SkipIterator[T] {
+	if src == nil {
+		return nil
+	}
+
+	si := &SkipIterator[T]{
+		src: src,
+		n:   max(n, 0),
+	}
+
+	return si
+}
+
+// ZipIterator is an iterator that pairs the elements of two iterators.
+type ZipIterator[A, B any] struct {
+	// first is the iterator of the first elements.
+	first Iterater[A]
+
+	// second is the iterator of the second elements.
+	second Iterater[B]
+}
+
+// Consume implements the Iterater interface.
+//
+// The iterator is exhausted as soon as either of the two iterators is; the
+// remaining elements of the other one are ignored.
+func (zi *ZipIterator[A, B]) Consume() (pair.Pair[A, B], error) {
+	a, err := zi.first.Consume()
+	if err != nil {
+		return pair.Pair[A, B]{}, err
+	}
+
+	b, err := zi.second.Consume()
+	if err != nil {
+		return pair.Pair[A, B]{}, err
+	}
+
+	return pair.NewPair(a, b), nil
+}
+
+// Restart implements the Iterater interface.
+func (zi *ZipIterator[A, B]) Restart() {
+	zi.first.Restart()
+	zi.second.Restart()
+}
+
+// NewZipIterator creates a new ZipIterator.
+//
+// Parameters:
+//   - first: The iterator of the first elements.
+//   - second: The iterator of the second elements.
+//
+// Returns:
+//   - *ZipIterator[A, B]: The new iterator. Nil if either iterator is nil.
+func NewZipIterator[A, B any](first Iterater[A], second Iterater[B]) *ZipIterator[A, B] {
+	if first == nil || second == nil {
+		return nil
+	}
+
+	zi := &ZipIterator[A, B]{
+		first:  first,
+		second: second,
+	}
+
+	return zi
+}
@@ -0,0 +1,190 @@
+package common
+
+import (
+	"strconv"
+	"sync"
+
+	gcers "github.com/PlayerR9/go-commons/errors"
+)
+
+// Severity is the severity of an assertion.
+type Severity int
+
+const (
+	// SeverityDebug is for expensive checks that are only worth running
+	// while debugging.
+	SeverityDebug Severity = iota
+
+	// SeverityNormal is the severity of Assert.
+	SeverityNormal
+
+	// SeverityCritical is for checks whose failure would corrupt state.
+	SeverityCritical
+)
+
+// String implements the fmt.Stringer interface.
+func (s Severity) String() string {
+	switch s {
+	case SeverityDebug:
+		return "debug"
+	case SeverityNormal:
+		return "normal"
+	case SeverityCritical:
+		return "critical"
+	default:
+		return "Severity(" + strconv.Itoa(int(s)) + ")"
+	}
+}
+
+var (
+	// assert_mu protects assert_handler and assert_level.
+	assert_mu sync.RWMutex
+
+	// assert_handler is called when an assertion fails. Nil means panic.
+	assert_handler func(msg string)
+
+	// assert_level is the minimum severity of the assertions that are checked.
+	assert_level Severity
+)
+
+// AssertsEnabled checks whether assertions are checked at all. They are not
+// when the module is built with the "production" build tag.
+//
+// Returns:
+//   - bool: True if assertions are enabled, false otherwise.
+//
+// Debug code can use it to skip computing the inputs of expensive checks.
+func AssertsEnabled() bool {
+	return asserts_enabled
+}
+
+// SetAssertHandler sets the function called when an assertion fails.
+//
+// Parameters:
+//   - handler: The handler, which receives the failure message. If nil, failed
+//     assertions panic with the message, which is the default.
+//
+// Returns:
+//   - func(msg string): The previous handler. Nil if it was the default.
+func SetAssertHandler(handler func(msg string)) func(msg string) {
+	assert_mu.Lock()
+	defer assert_mu.Unlock()
+
+	prev := assert_handler
+	assert_handler = handler
+
+	return prev
+}
+
+// SetAssertLevel sets the minimum severity of the assertions that are checked.
+// The default is SeverityDebug; that is, every assertion is checked.
+//
+// Parameters:
+//   - level: The minimum severity.
+//
+// Returns:
+//   - Severity: The previous minimum severity.
+func SetAssertLevel(level Severity) Severity {
+	assert_mu.Lock()
+	defer assert_mu.Unlock()
+
+	prev := assert_level
+	assert_level = level
+
+	return prev
+}
+
+// assert_active checks whether assertions of the given severity are checked.
+//
+// Parameters:
+//   - sev: The severity.
+//
+// Returns:
+//   - bool: True if they are checked, false otherwise.
+func assert_active(sev Severity) bool {
+	if !asserts_enabled {
+		return false
+	}
+
+	assert_mu.RLock()
+	defer assert_mu.RUnlock()
+
+	return sev >= assert_level
+}
+
+// assert_fail reports a failed assertion to the handler.
+//
+// Parameters:
+//   - msg: The failure message.
+func assert_fail(msg string) {
+	assert_mu.RLock()
+	handler := assert_handler
+	assert_mu.RUnlock()
+
+	if handler == nil {
+		panic(msg)
+	}
+
+	handler(msg)
+}
+
+// Assert checks a condition with SeverityNormal.
+//
+// Parameters:
+//   - cond: The condition.
+//   - msg: The message reported if the condition is false.
+//
+// Behaviors:
+//   - Does nothing when built with the "production" build tag.
+func Assert(cond bool, msg string) {
+	AssertWith(SeverityNormal, cond, msg)
+}
+
+// AssertWith checks a condition with the given severity.
+//
+// Parameters:
+//   - sev: The severity of the assertion.
+//   - cond: The condition.
+//   - msg: The message reported if the condition is false.
+//
+// Behaviors:
+//   - Does nothing if sev is below the level set by SetAssertLevel, or when
+//     built with the "production" build tag.
+func AssertWith(sev Severity, cond bool, msg string) {
+	if cond || !assert_active(sev) {
+		return
+	}
+
+	assert_fail(msg)
+}
+
+// AssertFunc checks a condition that is only computed if the assertion is
+// active. Does nothing if check is nil.
+//
+// Parameters:
+//   - sev: The severity of the assertion.
+//   - check: The function computing the condition.
+//   - msg: The message reported if the condition is false.
+func AssertFunc(sev Severity, check func() bool, msg string) {
+	if check == nil || !assert_active(sev) || check() {
+		return
+	}
+
+	assert_fail(msg)
+}
+
+// AssertParam checks a condition on a parameter with SeverityNormal.
+//
+// Parameters:
+//   - name: The name of the parameter.
+//   - cond: The condition.
+//   - reason: Why the parameter is invalid when the condition is false.
+//
+// The message reported is the one of errors.ErrInvalidParameter.
+func AssertParam(name string, cond bool, reason error) {
+	if cond || !assert_active(SeverityNormal) {
+		return
+	}
+
+	assert_fail(gcers.NewErrInvalidParameter(name, reason).Error())
+}
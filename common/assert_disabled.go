@@ -0,0 +1,7 @@
+//go:build production
+
+package common
+
+// asserts_enabled is true unless the module is built with the "production"
+// build tag.
+const asserts_enabled bool = false
@@ -0,0 +1,38 @@
+package common
+
+import (
+	"testing"
+)
+
+func TestAssertHandler(t *testing.T) {
+	var got []string
+
+	prev := SetAssertHandler(func(msg string) {
+		got = append(got, msg)
+	})
+	defer SetAssertHandler(prev)
+
+	prev_level := SetAssertLevel(SeverityNormal)
+	defer SetAssertLevel(prev_level)
+
+	Assert(true, "unused")
+	Assert(false, "failed")
+	AssertWith(SeverityDebug, false, "skipped")
+	AssertFunc(SeverityDebug, func() bool {
+		t.Error("expected the debug check not to run")
+		return false
+	}, "skipped")
+	AssertWith(SeverityCritical, false, "critical")
+
+	if !AssertsEnabled() {
+		if len(got) != 0 {
+			t.Errorf("expected no failures, got %q", got)
+		}
+
+		return
+	}
+
+	if len(got) != 2 || got[0] != "failed" || got[1] != "critical" {
+		t.Errorf("expected [failed critical], got %q", got)
+	}
+}
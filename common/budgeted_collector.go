@@ -0,0 +1,265 @@
+package common
+
+import (
+	"time"
+)
+
+// BudgetedCollector is an ErrOrSol that charges a cost for every candidate it
+// collects and stops collecting once a total budget is spent or a deadline is
+// passed, so that searches can be cut short while keeping the solutions found
+// so far. The zero value is a collector with an unlimited budget and no
+// deadline.
+type BudgetedCollector[T any] struct {
+	// eos holds the errors and solutions collected.
+	eos ErrOrSol[T]
+
+	// budget is the total cost allowed. Zero if unlimited.
+	budget float64
+
+	// spent is the total cost of the candidates collected.
+	spent float64
+
+	// costs are the costs of the candidates, in the order they were collected.
+	costs []float64
+
+	// deadline is the time by which collecting must be done. Zero if none.
+	deadline time.Time
+
+	// now returns the current time. Nil means time.Now.
+	now func() time.Time
+
+	// exceeded is the error returned once the budget or the deadline is
+	// exceeded. Nil until then.
+	exceeded *ErrBudgetExceeded
+}
+
+// NewBudgetedCollector creates a new BudgetedCollector.
+//
+// Parameters:
+//   - budget: The total cost allowed. If not positive, the budget is unlimited.
+//
+// Returns:
+//   - *BudgetedCollector[T]: The new collector. Never nil.
+//
+// Costs are in abstract units chosen by the caller; Measure uses seconds.
+func NewBudgetedCollector[T any](budget float64) *BudgetedCollector[T] {
+	if budget < 0 {
+		budget = 0
+	}
+
+	bc := &BudgetedCollector[T]{
+		budget: budget,
+		now:    time.Now,
+	}
+
+	return bc
+}
+
+// SetDeadline sets the time by which collecting must be done.
+//
+// Parameters:
+//   - deadline: The deadline. The zero time removes the deadline.
+func (bc *BudgetedCollector[T]) SetDeadline(deadline time.Time) {
+	bc.deadline = deadline
+}
+
+// clock returns the current time.
+//
+// Returns:
+//   - time.Time: The current time.
+func (bc *BudgetedCollector[T]) clock() time.Time {
+	if bc.now == nil {
+		return time.Now()
+	}
+
+	return bc.now()
+}
+
+// charge records the cost of a candidate and checks the budget and the
+// deadline.
+//
+// Parameters:
+//   - cost: The cost of the candidate. Negative costs count as zero.
+//
+// Returns:
+//   - error: An *ErrBudgetExceeded if the budget or the deadline is now
+//     exceeded, nil otherwise.
+func (bc *BudgetedCollector[T]) charge(cost float64) error {
+	if cost < 0 {
+		cost = 0
+	}
+
+	bc.costs = append(bc.costs, cost)
+	bc.spent += cost
+
+	over_budget := bc.budget > 0 && bc.spent > bc.budget
+	past_deadline := !bc.deadline.IsZero() && !bc.clock().Before(bc.deadline)
+
+	if !over_budget && !past_deadline {
+		return nil
+	}
+
+	bc.exceeded = &ErrBudgetExceeded{
+		Budget:   bc.budget,
+		Spent:    bc.spent,
+		Deadline: bc.deadline,
+	}
+
+	return bc.Err()
+}
+
+// AddErr charges the cost of a failed candidate and adds its error as
+// ErrOrSol.AddErr does.
+//
+// Parameters:
+//   - err: The error of the candidate.
+//   - level: The level of the error.
+//   - cost: The cost of the candidate.
+//
+// Returns:
+//   - error: An *ErrBudgetExceeded if the budget or the deadline is exceeded,
+//     nil otherwise.
+//
+// Behaviors:
+//   - Once the budget is exceeded, candidates are no longer collected.
+//   - The candidate that exceeds the budget is still collected, since its
+//     cost was already paid.
+func (bc *BudgetedCollector[T]) AddErr(err error, level int, cost float64) error {
+	if bc.exceeded != nil {
+		return bc.Err()
+	}
+
+	bc.eos.AddErr(err, level)
+
+	return bc.charge(cost)
+}
+
+// AddSol charges the cost of a successful candidate and adds its solution as
+// ErrOrSol.AddSol does.
+//
+// Parameters:
+//   - sol: The solution of the candidate.
+//   - level: The level of the solution.
+//   - cost: The cost of the candidate.
+//
+// Returns:
+//   - error: An *ErrBudgetExceeded if the budget or the deadline is exceeded,
+//     nil otherwise.
+//
+// Behaviors:
+//   - Once the budget is exceeded, candidates are no longer collected.
+//   - The candidate that exceeds the budget is still collected, since its
+//     cost was already paid.
+func (bc *BudgetedCollector[T]) AddSol(sol T, level int, cost float64) error {
+	if bc.exceeded != nil {
+		return bc.Err()
+	}
+
+	bc.eos.AddSol(sol, level)
+
+	return bc.charge(cost)
+}
+
+// Measure evaluates a candidate and charges the time it took, in seconds.
+//
+// Parameters:
+//   - level: The level of the result of the candidate.
+//   - f: The candidate. It is not called once the budget is exceeded.
+//
+// Returns:
+//   - error: An *ErrBudgetExceeded if the budget or the deadline is exceeded,
+//     nil otherwise.
+//
+// If f returns an error, it is added with AddErr; otherwise, its solution is
+// added with AddSol. A nil f is ignored.
+func (bc *BudgetedCollector[T]) Measure(level int, f func() (T, error)) error {
+	if bc.exceeded != nil {
+		return bc.Err()
+	} else if f == nil {
+		return nil
+	}
+
+	start := bc.clock()
+
+	sol, err := f()
+
+	cost := bc.clock().Sub(start).Seconds()
+
+	if err != nil {
+		return bc.AddErr(err, level, cost)
+	}
+
+	return bc.AddSol(sol, level, cost)
+}
+
+// Spent returns the total cost of the candidates collected.
+//
+// Returns:
+//   - float64: The total cost.
+func (bc *BudgetedCollector[T]) Spent() float64 {
+	return bc.spent
+}
+
+// Remaining returns the cost that can still be spent.
+//
+// Returns:
+//   - float64: The remaining cost. Zero if the budget is spent.
+//   - bool: False if the budget is unlimited, true otherwise.
+func (bc *BudgetedCollector[T]) Remaining() (float64, bool) {
+	if bc.budget == 0 {
+		return 0, false
+	}
+
+	return max(bc.budget-bc.spent, 0), true
+}
+
+// Costs returns the costs of the candidates collected.
+//
+// Returns:
+//   - []float64: The costs, in the order the candidates were collected.
+func (bc *BudgetedCollector[T]) Costs() []float64 {
+	return bc.costs
+}
+
+// Err returns the error of the collector.
+//
+// Returns:
+//   - error: An *ErrBudgetExceeded if the budget or the deadline was exceeded,
+//     nil otherwise.
+//
+// The returned error counts the solutions collected so far, which Solutions
+// returns.
+func (bc *BudgetedCollector[T]) Err() error {
+	if bc.exceeded == nil {
+		return nil
+	}
+
+	bc.exceeded.Partial = len(bc.eos.Solutions())
+
+	return bc.exceeded
+}
+
+// HasError checks if errors are not ignored and if the error list is not empty.
+//
+// Returns:
+//   - bool: True if errors are not ignored and the error list is not empty, otherwise false.
+func (bc *BudgetedCollector[T]) HasError() bool {
+	return bc.eos.HasError()
+}
+
+// Errors returns the list of errors.
+//
+// Returns:
+//   - []error: The list of errors.
+func (bc *BudgetedCollector[T]) Errors() []error {
+	return bc.eos.Errors()
+}
+
+// Solutions returns the list of solutions, which are partial if the budget was
+// exceeded.
+//
+// Returns:
+//   - []T: The list of solutions.
+func (bc *BudgetedCollector[T]) Solutions() []T {
+	return bc.eos.Solutions()
+}
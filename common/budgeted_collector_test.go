@@ -0,0 +1,77 @@
+package common
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestBudgetedCollector(t *testing.T) {
+	bc := NewBudgetedCollector[string](5)
+
+	if err := bc.AddSol("a", 0, 2); err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	if err := bc.AddErr(errors.New("bad"), 0, 2); err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	err := bc.AddSol("b", 0, 2)
+
+	var budget_err *ErrBudgetExceeded
+
+	if !errors.As(err, &budget_err) {
+		t.Fatalf("expected an *ErrBudgetExceeded, got %v", err)
+	}
+
+	const expected = "budget of 5 exceeded (spent 6); 2 partial solutions"
+	if err.Error() != expected {
+		t.Errorf("expected %q, got %q", expected, err.Error())
+	}
+
+	_ = bc.AddSol("c", 0, 1)
+
+	if len(bc.Solutions()) != 2 || len(bc.Costs()) != 3 {
+		t.Errorf("expected 2 solutions and 3 costs, got %v and %v", bc.Solutions(), bc.Costs())
+	}
+}
+
+func TestBudgetedCollectorDeadline(t *testing.T) {
+	now := time.Unix(0, 0)
+
+	bc := NewBudgetedCollector[int](0)
+	bc.now = func() time.Time { return now }
+	bc.SetDeadline(now.Add(time.Second))
+
+	err := bc.Measure(0, func() (int, error) {
+		now = now.Add(2 * time.Second)
+		return 1, nil
+	})
+
+	if err == nil || err.Error() != "deadline exceeded; 1 partial solution" {
+		t.Fatalf("expected the deadline to be exceeded, got %v", err)
+	}
+
+	if bc.Spent() != 2 {
+		t.Errorf("expected 2 seconds spent, got %g", bc.Spent())
+	}
+}
+
+func TestBudgetedCollectorZeroValue(t *testing.T) {
+	var bc BudgetedCollector[int]
+
+	bc.SetDeadline(time.Now().Add(-time.Second))
+
+	err := bc.AddSol(1, 0, 1)
+	if err == nil || err.Error() != "deadline exceeded; 1 partial solution" {
+		t.Fatalf("expected the deadline to be exceeded, got %v", err)
+	}
+
+	var measured BudgetedCollector[int]
+
+	err = measured.Measure(0, func() (int, error) { return 1, nil })
+	if err != nil {
+		t.Errorf("unexpected error: %s", err.Error())
+	}
+}
@@ -0,0 +1,66 @@
+package common
+
+import (
+	"context"
+)
+
+// ChanIterator is an iterator over the values received from a channel.
+type ChanIterator[T any] struct {
+	// ctx is the context that cancels the iteration.
+	ctx context.Context
+
+	// ch is the channel to receive from.
+	ch <-chan T
+}
+
+// Consume implements the Iterater interface.
+//
+// Consume blocks until a value is received, the channel is closed or the
+// context is done.
+//
+// Errors:
+//   - *ErrExhaustedIter: If the channel is closed and drained.
+//   - the error of the context: If the context is done.
+func (ci *ChanIterator[T]) Consume() (T, error) {
+	select {
+	case <-ci.ctx.Done():
+		return *new(T), ci.ctx.Err()
+	case value, ok := <-ci.ch:
+		if !ok {
+			return *new(T), NewErrExhaustedIter()
+		}
+
+		return value, nil
+	}
+}
+
+// Restart implements the Iterater interface.
+//
+// Received values cannot be received again; thus, Restart has no effect.
+func (ci *ChanIterator[T]) Restart() {}
+
+// NewChanIterator creates a new ChanIterator.
+//
+// Parameters:
+//   - ctx: The context that cancels the iteration. If nil,
+//     context.Background() is used.
+//   - ch: The channel to receive from.
+//
+// Returns:
+//   - *ChanIterator[T]: The new iterator. Nil if ch is nil.
+func NewChanIterator[T any](ctx context.Context, ch <-chan T) *ChanIterator[T] {
+	if ch == nil {
+		return nil
+	}
+
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	ci := &ChanIterator[T]{
+		ctx: ctx,
+		ch:  ch,
+	}
+
+	return ci
+}
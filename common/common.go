@@ -21,6 +21,16 @@ type Unwrapper interface {
 	ChangeReason(reason error)
 }
 
+// Copier is an interface for values that can make a deep copy of themselves.
+type Copier[T any] interface {
+	// Copy returns a deep copy of the value; modifying the copy must not
+	// affect the original.
+	//
+	// Returns:
+	//   - T: The copy.
+	Copy() T
+}
+
 // Is is function that checks if an error is of type T.
 //
 // Parameters:
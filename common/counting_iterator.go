@@ -0,0 +1,88 @@
+package common
+
+// CountingIterator is an iterator that wraps another iterator and records
+// statistics about how it is consumed.
+type CountingIterator[T any] struct {
+	// src is the wrapped iterator.
+	src Iterater[T]
+
+	// consumed is the number of elements successfully consumed.
+	consumed int
+
+	// restarts is the number of times the iterator was restarted.
+	restarts int
+
+	// last_err is the last error returned by the wrapped iterator.
+	last_err error
+}
+
+// Consume implements the Iterater interface.
+//
+// Errors are forwarded as-is from the wrapped iterator and recorded.
+func (ci *CountingIterator[T]) Consume() (T, error) {
+	value, err := ci.src.Consume()
+	if err != nil {
+		ci.last_err = err
+
+		return value, err
+	}
+
+	ci.consumed++
+
+	return value, nil
+}
+
+// Restart implements the Iterater interface.
+//
+// The consumption count is kept across restarts.
+func (ci *CountingIterator[T]) Restart() {
+	ci.src.Restart()
+
+	ci.restarts++
+	ci.last_err = nil
+}
+
+// NewCountingIterator creates a new CountingIterator.
+//
+// Parameters:
+//   - src: The iterator to wrap.
+//
+// Returns:
+//   - *CountingIterator[T]: The new iterator. Nil if src is nil.
+func NewCountingIterator[T any](src Iterater[T]) *CountingIterator[T] {
+	if src == nil {
+		return nil
+	}
+
+	ci := &CountingIterator[T]{
+		src: src,
+	}
+
+	return ci
+}
+
+// Consumed returns the number of elements successfully consumed since the
+// creation of the iterator.
+//
+// Returns:
+//   - int: The number of consumed elements.
+func (ci *CountingIterator[T]) Consumed() int {
+	return ci.consumed
+}
+
+// Restarts returns the number of times the iterator was restarted.
+//
+// Returns:
+//   - int: The number of restarts.
+func (ci *CountingIterator[T]) Restarts() int {
+	return ci.restarts
+}
+
+// LastErr returns the last error returned by the wrapped iterator since the
+// last restart.
+//
+// Returns:
+//   - error: The last error. Nil if no error occurred.
+func (ci *CountingIterator[T]) LastErr() error {
+	return ci.last_err
+}
@@ -0,0 +1,249 @@
+package common
+
+import (
+	"errors"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+var (
+	// error_string_type is the type of the errors created by errors.New.
+	error_string_type reflect.Type
+
+	// reflect_type_type is the reflect.Type interface type.
+	reflect_type_type reflect.Type
+)
+
+func init() {
+	error_string_type = reflect.TypeOf(errors.New(""))
+	reflect_type_type = reflect.TypeFor[reflect.Type]()
+}
+
+// value_dumper is the state of a DumpError computation.
+type value_dumper struct {
+	// builder is where the representation is written.
+	builder strings.Builder
+
+	// visiting is the set of pointers, maps and slices on the current path,
+	// used to detect cycles.
+	visiting map[visit_key]bool
+}
+
+// write_value writes the Go representation of a value.
+//
+// Parameters:
+//   - v: The value to write.
+func (vd *value_dumper) write_value(v reflect.Value) {
+	if !v.IsValid() {
+		vd.builder.WriteString("nil")
+		return
+	}
+
+	if v.Type() == reflect_type_type || v.Type().Implements(reflect_type_type) && v.Kind() == reflect.Pointer {
+		vd.write_type(v)
+		return
+	}
+
+	key, ok := new_visit_key(v)
+	if ok {
+		if vd.visiting[key] {
+			vd.builder.WriteString("<cycle>")
+			return
+		}
+
+		vd.visiting[key] = true
+		defer delete(vd.visiting, key)
+	}
+
+	switch v.Kind() {
+	case reflect.Bool:
+		vd.builder.WriteString(strconv.FormatBool(v.Bool()))
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		vd.builder.WriteString(strconv.FormatInt(v.Int(), 10))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		vd.builder.WriteString(strconv.FormatUint(v.Uint(), 10))
+	case reflect.Float32, reflect.Float64:
+		vd.builder.WriteString(strconv.FormatFloat(v.Float(), 'g', -1, v.Type().Bits()))
+	case reflect.Complex64, reflect.Complex128:
+		vd.builder.WriteString(strconv.FormatComplex(v.Complex(), 'g', -1, v.Type().Bits()))
+	case reflect.String:
+		vd.builder.WriteString(strconv.Quote(v.String()))
+	case reflect.Slice, reflect.Array:
+		if v.Kind() == reflect.Slice && v.IsNil() {
+			vd.write_nil(v.Type())
+			return
+		}
+
+		if v.Kind() == reflect.Slice && v.Type().Elem().Kind() == reflect.Uint8 {
+			vd.builder.WriteString(v.Type().String())
+			vd.builder.WriteRune('(')
+			vd.builder.WriteString(strconv.Quote(string(v.Bytes())))
+			vd.builder.WriteRune(')')
+
+			return
+		}
+
+		vd.builder.WriteString(v.Type().String())
+		vd.builder.WriteRune('{')
+
+		for i := 0; i < v.Len(); i++ {
+			if i > 0 {
+				vd.builder.WriteString(", ")
+			}
+
+			vd.write_value(v.Index(i))
+		}
+
+		vd.builder.WriteRune('}')
+	case reflect.Map:
+		if v.IsNil() {
+			vd.write_nil(v.Type())
+			return
+		}
+
+		// The entries are sorted by their representation so that the result
+		// does not depend on the iteration order of the map.
+		var entries []string
+
+		iter := v.MapRange()
+		for iter.Next() {
+			sub := &value_dumper{visiting: vd.visiting}
+
+			sub.write_value(iter.Key())
+			sub.builder.WriteString(": ")
+			sub.write_value(iter.Value())
+
+			entries = append(entries, sub.builder.String())
+		}
+
+		slices.Sort(entries)
+
+		vd.builder.WriteString(v.Type().String())
+		vd.builder.WriteRune('{')
+		vd.builder.WriteString(strings.Join(entries, ", "))
+		vd.builder.WriteRune('}')
+	case reflect.Struct:
+		t := v.Type()
+
+		vd.builder.WriteString(t.String())
+		vd.builder.WriteRune('{')
+
+		for i := 0; i < v.NumField(); i++ {
+			if i > 0 {
+				vd.builder.WriteString(", ")
+			}
+
+			vd.builder.WriteString(t.Field(i).Name)
+			vd.builder.WriteRune(':')
+			vd.write_value(v.Field(i))
+		}
+
+		vd.builder.WriteRune('}')
+	case reflect.Pointer:
+		if v.IsNil() {
+			vd.write_nil(v.Type())
+			return
+		}
+
+		if v.Type() == error_string_type {
+			vd.builder.WriteString("errors.New(")
+			vd.builder.WriteString(strconv.Quote(v.Elem().Field(0).String()))
+			vd.builder.WriteRune(')')
+
+			return
+		}
+
+		vd.builder.WriteRune('&')
+		vd.write_value(v.Elem())
+	case reflect.Interface:
+		if v.IsNil() {
+			vd.builder.WriteString("nil")
+			return
+		}
+
+		vd.write_value(v.Elem())
+	default:
+		// Functions, channels and unsafe pointers.
+		if v.IsNil() {
+			vd.write_nil(v.Type())
+			return
+		}
+
+		vd.builder.WriteRune('(')
+		vd.builder.WriteString(v.Type().String())
+		vd.builder.WriteString(")(0x")
+		vd.builder.WriteString(strconv.FormatUint(uint64(v.Pointer()), 16))
+		vd.builder.WriteRune(')')
+	}
+}
+
+// write_nil writes a typed nil value.
+//
+// Parameters:
+//   - t: The type of the value.
+func (vd *value_dumper) write_nil(t reflect.Type) {
+	vd.builder.WriteRune('(')
+	vd.builder.WriteString(t.String())
+	vd.builder.WriteString(")(nil)")
+}
+
+// write_type writes a reflect.Type value.
+//
+// Parameters:
+//   - v: The value, which holds a reflect.Type.
+func (vd *value_dumper) write_type(v reflect.Value) {
+	if v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			vd.builder.WriteString("nil")
+			return
+		}
+
+		v = v.Elem()
+	}
+
+	if v.IsNil() {
+		vd.builder.WriteString("nil")
+		return
+	}
+
+	if !v.CanInterface() {
+		// Unexported fields cannot be converted back to a reflect.Type.
+		vd.builder.WriteString(v.Type().String())
+		return
+	}
+
+	vd.builder.WriteString("reflect.TypeFor[")
+	vd.builder.WriteString(v.Interface().(reflect.Type).String())
+	vd.builder.WriteString("]()")
+}
+
+// DumpError returns a Go-syntax representation of an error that shows its
+// type and fields, recursively; unlike the %#v verb, nested errors are shown
+// as values instead of pointer addresses.
+//
+// Parameters:
+//   - err: The error to dump.
+//
+// Returns:
+//   - string: The representation. "nil" if err is nil.
+//
+// Example:
+//
+//	DumpError(NewErrWhile("parsing", errors.New("bad digit")))
+//	// &common.ErrWhile{Operation:"parsing", Reason:errors.New("bad digit")}
+//
+// Unexported fields are shown as well, errors created by errors.New are shown
+// as calls to it and byte slices are shown as quoted strings. Cycles are shown
+// as "<cycle>". The error types of this package implement fmt.GoStringer with
+// this function, so that the %#v verb shows the same representation.
+func DumpError(err error) string {
+	vd := &value_dumper{
+		visiting: make(map[visit_key]bool),
+	}
+
+	vd.write_value(reflect.ValueOf(err))
+
+	return vd.builder.String()
+}
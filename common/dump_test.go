@@ -0,0 +1,67 @@
+package common
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestDumpError(t *testing.T) {
+	err := NewErrWhile("parsing", errors.New("bad digit"))
+
+	want := `&common.ErrWhile{Operation:"parsing", Reason:errors.New("bad digit")}`
+
+	got := DumpError(err)
+	if got != want {
+		t.Errorf("DumpError() = %s, want %s", got, want)
+	}
+
+	got = fmt.Sprintf("%#v", err)
+	if got != want {
+		t.Errorf("%%#v = %s, want %s", got, want)
+	}
+
+	if DumpError(nil) != "nil" {
+		t.Errorf("DumpError(nil) = %s, want nil", DumpError(nil))
+	}
+}
+
+// holder_error is an error that holds a value.
+type holder_error struct {
+	value any
+}
+
+func (e *holder_error) Error() string {
+	return "holder"
+}
+
+func TestDumpErrorCycle(t *testing.T) {
+	s := []any{nil}
+	s[0] = s
+
+	m := map[string]any{}
+	m["self"] = m
+
+	tests := map[string]struct {
+		err  error
+		want string
+	}{
+		"slice": {
+			err:  NewErrWhile("parsing", &holder_error{value: s}),
+			want: `&common.ErrWhile{Operation:"parsing", Reason:&common.holder_error{value:[]interface {}{<cycle>}}}`,
+		},
+		"map": {
+			err:  NewErrWhile("parsing", &holder_error{value: m}),
+			want: `&common.ErrWhile{Operation:"parsing", Reason:&common.holder_error{value:map[string]interface {}{"self": <cycle>}}}`,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			got := DumpError(tt.err)
+			if got != tt.want {
+				t.Errorf("DumpError() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
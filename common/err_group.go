@@ -0,0 +1,251 @@
+package common
+
+import (
+	"strconv"
+	"strings"
+)
+
+// group_entry is an error of an ErrGroup with its optional key.
+type group_entry struct {
+	// key identifies the error within the group. Empty if none.
+	key string
+
+	// err is the error.
+	err error
+}
+
+// String returns the message of the entry, prefixed by its key if any.
+//
+// Returns:
+//   - string: The message.
+func (e group_entry) String() string {
+	if e.key == "" {
+		return e.err.Error()
+	}
+
+	var builder strings.Builder
+
+	builder.WriteString(e.key)
+	builder.WriteString(": ")
+	builder.WriteString(e.err.Error())
+
+	return builder.String()
+}
+
+// ErrGroup is an error that aggregates many errors, each one optionally
+// identified by a key or an index.
+type ErrGroup struct {
+	// entries are the errors in the order they were added.
+	entries []group_entry
+}
+
+// Error implements the error interface.
+//
+// Message:
+//   - "no errors" if the group is empty.
+//   - "{key}: {error}" if the group has one error.
+//   - "{n} errors occurred: {key}: {error}; {key}: {error}; ..." otherwise.
+//
+// The "{key}: " prefix is omitted for errors without a key.
+func (e *ErrGroup) Error() string {
+	switch len(e.entries) {
+	case 0:
+		return "no errors"
+	case 1:
+		return e.entries[0].String()
+	}
+
+	var builder strings.Builder
+
+	builder.WriteString(strconv.Itoa(len(e.entries)))
+	builder.WriteString(" errors occurred: ")
+
+	for i, entry := range e.entries {
+		if i > 0 {
+			builder.WriteString("; ")
+		}
+
+		builder.WriteString(entry.String())
+	}
+
+	return builder.String()
+}
+
+// GoString implements the fmt.GoStringer interface.
+//
+// See DumpError.
+func (e *ErrGroup) GoString() string {
+	return DumpError(e)
+}
+
+// Unwrap returns the errors of the group, so that errors.Is and errors.As
+// search all of them.
+//
+// Returns:
+//   - []error: The errors. Nil if the group is empty.
+func (e *ErrGroup) Unwrap() []error {
+	if len(e.entries) == 0 {
+		return nil
+	}
+
+	errs := make([]error, 0, len(e.entries))
+
+	for _, entry := range e.entries {
+		errs = append(errs, entry.err)
+	}
+
+	return errs
+}
+
+// NewErrGroup creates a new, empty ErrGroup.
+//
+// Returns:
+//   - *ErrGroup: A pointer to the new ErrGroup. Never nil.
+func NewErrGroup() *ErrGroup {
+	return &ErrGroup{}
+}
+
+// Add adds an error without a key. Does nothing if err is nil.
+//
+// Parameters:
+//   - err: The error to add.
+func (e *ErrGroup) Add(err error) {
+	e.AddKeyed("", err)
+}
+
+// AddKeyed adds an error identified by a key. Does nothing if err is nil.
+//
+// Parameters:
+//   - key: The key of the error, such as the name of a flag.
+//   - err: The error to add.
+func (e *ErrGroup) AddKeyed(key string, err error) {
+	if err == nil {
+		return
+	}
+
+	e.entries = append(e.entries, group_entry{
+		key: key,
+		err: err,
+	})
+}
+
+// AddAt adds an error identified by an index, such as the position of an
+// element in a batch. Does nothing if err is nil.
+//
+// Parameters:
+//   - idx: The index of the error.
+//   - err: The error to add.
+//
+// The key of the error is "[idx]".
+func (e *ErrGroup) AddAt(idx int, err error) {
+	e.AddKeyed("["+strconv.Itoa(idx)+"]", err)
+}
+
+// Len returns the number of errors in the group.
+//
+// Returns:
+//   - int: The number of errors.
+func (e *ErrGroup) Len() int {
+	return len(e.entries)
+}
+
+// Key returns the key of the error at the given position.
+//
+// Parameters:
+//   - pos: The position of the error, in the order errors were added.
+//
+// Returns:
+//   - string: The key. Empty if the error has no key or pos is out of range.
+func (e *ErrGroup) Key(pos int) string {
+	if pos < 0 || pos >= len(e.entries) {
+		return ""
+	}
+
+	return e.entries[pos].key
+}
+
+// ErrOrNil returns the group as an error if it has at least one error.
+//
+// Returns:
+//   - error: The group, or nil if it is empty.
+//
+// This avoids returning a non-nil error interface holding an empty group.
+func (e *ErrGroup) ErrOrNil() error {
+	if e == nil || len(e.entries) == 0 {
+		return nil
+	}
+
+	return e
+}
+
+// Tree returns a multi-line rendering of the group where each error is
+// followed by the errors it wraps, recursively.
+//
+// Format:
+//
+//	2 errors occurred
+//	├── [0]: error while parsing: bad digit
+//	│   └── bad digit
+//	└── name: missing value
+//
+// Returns:
+//   - string: The rendering.
+func (e *ErrGroup) Tree() string {
+	var builder strings.Builder
+
+	switch len(e.entries) {
+	case 0:
+		builder.WriteString("no errors")
+	case 1:
+		builder.WriteString("1 error occurred")
+	default:
+		builder.WriteString(strconv.Itoa(len(e.entries)))
+		builder.WriteString(" errors occurred")
+	}
+
+	for i, entry := range e.entries {
+		write_error_node(&builder, "", entry.String(), entry.err, i == len(e.entries)-1)
+	}
+
+	return builder.String()
+}
+
+// write_error_node writes an error and, recursively, the errors it wraps as
+// a branch of a tree.
+//
+// Parameters:
+//   - builder: The builder to write to.
+//   - indent: The indentation of the branch.
+//   - label: The text of the node.
+//   - err: The error of the node.
+//   - is_last: Whether the node is the last child of its parent.
+//
+// Assertions:
+//   - builder != nil
+//   - err != nil
+func write_error_node(builder *strings.Builder, indent, label string, err error, is_last bool) {
+	builder.WriteRune('\n')
+	builder.WriteString(indent)
+
+	if is_last {
+		builder.WriteString("└── ")
+		indent += "    "
+	} else {
+		builder.WriteString("├── ")
+		indent += "│   "
+	}
+
+	builder.WriteString(label)
+
+	var children []error
+
+	for _, child := range unwrap_all(err) {
+		if child != nil {
+			children = append(children, child)
+		}
+	}
+
+	for i, child := range children {
+		write_error_node(builder, indent, child.Error(), child, i == len(children)-1)
+	}
+}
@@ -0,0 +1,183 @@
+package common
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+// ErrGroupLite runs functions in goroutines and collects their errors, in the
+// spirit of golang.org/x/sync/errgroup.
+//
+// The first failure cancels the context of the group so that the remaining
+// functions can stop early. An ErrGroupLite must not be reused after Wait.
+type ErrGroupLite struct {
+	// ctx is the context passed to the functions.
+	ctx context.Context
+
+	// cancel cancels ctx.
+	cancel context.CancelFunc
+
+	// wg waits for the running functions.
+	wg sync.WaitGroup
+
+	// sem limits the number of running functions. Nil if there is no limit.
+	sem chan struct{}
+
+	// mu protects the fields below.
+	mu sync.Mutex
+
+	// errs are the errors of the functions.
+	errs *ErrGroup
+
+	// count is the number of functions started so far.
+	count int
+
+	// failed is true once a function failed.
+	failed bool
+}
+
+// NewErrGroupLite creates a new ErrGroupLite.
+//
+// Parameters:
+//   - ctx: The parent context. If nil, context.Background() is used.
+//   - limit: The maximum number of functions running at the same time. Values
+//     below 1 mean no limit.
+//
+// Returns:
+//   - *ErrGroupLite: A pointer to the new group. Never nil.
+func NewErrGroupLite(ctx context.Context, limit int) *ErrGroupLite {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+
+	g := &ErrGroupLite{
+		ctx:    ctx,
+		cancel: cancel,
+		errs:   NewErrGroup(),
+	}
+
+	if limit > 0 {
+		g.sem = make(chan struct{}, limit)
+	}
+
+	return g
+}
+
+// Context returns the context passed to the functions of the group. It is
+// canceled when a function fails or when Wait returns.
+//
+// Returns:
+//   - context.Context: The context. Never nil.
+func (g *ErrGroupLite) Context() context.Context {
+	return g.ctx
+}
+
+// Go runs a function in a new goroutine. If the group has a limit, Go blocks
+// until the function can start. Does nothing if f is nil.
+//
+// Parameters:
+//   - f: The function to run.
+func (g *ErrGroupLite) Go(f func(ctx context.Context) error) {
+	if f == nil {
+		return
+	}
+
+	if g.sem != nil {
+		g.sem <- struct{}{}
+	}
+
+	g.start(f)
+}
+
+// TryGo runs a function in a new goroutine only if the limit of the group
+// allows it to start right away. Does nothing if f is nil.
+//
+// Parameters:
+//   - f: The function to run.
+//
+// Returns:
+//   - bool: True if the function was started, false otherwise.
+func (g *ErrGroupLite) TryGo(f func(ctx context.Context) error) bool {
+	if f == nil {
+		return false
+	}
+
+	if g.sem != nil {
+		select {
+		case g.sem <- struct{}{}:
+		default:
+			return false
+		}
+	}
+
+	g.start(f)
+
+	return true
+}
+
+// start runs a function in a new goroutine once it holds a slot.
+//
+// Parameters:
+//   - f: The function to run.
+//
+// Assertions:
+//   - f != nil
+func (g *ErrGroupLite) start(f func(ctx context.Context) error) {
+	g.mu.Lock()
+	idx := g.count
+	g.count++
+	g.mu.Unlock()
+
+	g.wg.Add(1)
+
+	go func() {
+		defer g.wg.Done()
+
+		if g.sem != nil {
+			defer func() { <-g.sem }()
+		}
+
+		err := f(g.ctx)
+		if err == nil {
+			return
+		}
+
+		g.mu.Lock()
+		defer g.mu.Unlock()
+
+		if g.failed && errors.Is(err, context.Canceled) && errors.Is(g.ctx.Err(), context.Canceled) {
+			// The function only reports the cancellation caused by an earlier
+			// failure.
+			return
+		}
+
+		g.errs.AddAt(idx, err)
+
+		if !g.failed {
+			g.failed = true
+			g.cancel()
+		}
+	}()
+}
+
+// Wait waits for all the functions of the group to return.
+//
+// Returns:
+//   - error: The errors of the functions, or nil if all succeeded.
+//
+// Errors:
+//   - *ErrGroup: The errors, each keyed by "[idx]" where idx is the order in
+//     which its function was started. Cancellation errors returned after the
+//     first failure are left out.
+func (g *ErrGroupLite) Wait() error {
+	g.wg.Wait()
+	g.cancel()
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	return g.errs.ErrOrNil()
+}
@@ -0,0 +1,65 @@
+package common
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+)
+
+func TestErrGroupLite(t *testing.T) {
+	g := NewErrGroupLite(nil, 2)
+
+	var running, peak atomic.Int32
+
+	for i := 0; i < 10; i++ {
+		g.Go(func(ctx context.Context) error {
+			n := running.Add(1)
+			defer running.Add(-1)
+
+			for {
+				old := peak.Load()
+				if n <= old || peak.CompareAndSwap(old, n) {
+					break
+				}
+			}
+
+			return nil
+		})
+	}
+
+	err := g.Wait()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if peak.Load() > 2 {
+		t.Errorf("expected at most 2 running functions, got %d", peak.Load())
+	}
+}
+
+func TestErrGroupLiteCancel(t *testing.T) {
+	g := NewErrGroupLite(context.Background(), 0)
+
+	failure := errors.New("failure")
+
+	g.Go(func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	})
+
+	g.Go(func(ctx context.Context) error {
+		return failure
+	})
+
+	err := g.Wait()
+	if !errors.Is(err, failure) {
+		t.Fatalf("expected failure, got %v", err)
+	}
+
+	var group *ErrGroup
+
+	if !errors.As(err, &group) || group.Len() != 1 || group.Key(0) != "[1]" {
+		t.Errorf("expected only the failure at [1], got %v", err)
+	}
+}
@@ -26,6 +26,8 @@ type ErrOrSol[T any] struct {
 //   - If an error has been added with a level greater than the current level,
 //     the error list is reset and the new level is updated.
 //   - If the error is nil, the ignoreErr flag is set to true and the error list is reset.
+//   - If an equal error (see ErrorsEqual) was already added at the same level, the
+//     error is not added again.
 func (e *ErrOrSol[T]) AddErr(err error, level int) {
 	if level < e.level || e.ignoreErr {
 		// Do nothing.
@@ -37,7 +39,9 @@ func (e *ErrOrSol[T]) AddErr(err error, level int) {
 		e.errorList = nil
 	} else {
 		if level == e.level {
-			e.errorList = append(e.errorList, err)
+			if !contains_error(e.errorList, err) {
+				e.errorList = append(e.errorList, err)
+			}
 		} else {
 			e.errorList = []error{err}
 			e.level = level
@@ -87,6 +91,8 @@ func (e *ErrOrSol[T]) AddSol(sol T, level int) {
 //     the error list is reset and the new level is updated.
 //   - If a solution has been added with a level greater than the current level,
 //     the solution list is reset and the new level is updated.
+//   - If an equal error (see ErrorsEqual) was already added at the same level, the
+//     error is not added again.
 func (e *ErrOrSol[T]) AddAny(elem any, level int) {
 	if level < e.level {
 		// Do nothing.
@@ -105,7 +111,9 @@ func (e *ErrOrSol[T]) AddAny(elem any, level int) {
 			e.errorList = nil
 		} else {
 			if level == e.level {
-				e.errorList = append(e.errorList, elem)
+				if !contains_error(e.errorList, elem) {
+					e.errorList = append(e.errorList, elem)
+				}
 			} else {
 				e.errorList = []error{elem}
 				e.level = level
@@ -0,0 +1,204 @@
+package common
+
+import (
+	"errors"
+	"maps"
+)
+
+// ErrorCoder is implemented by errors that carry a stable, machine-readable
+// code and metadata, so that callers can map them to responses without
+// matching messages.
+type ErrorCoder interface {
+	error
+
+	// ErrorCode returns the code of the error.
+	//
+	// Returns:
+	//   - string: The code.
+	ErrorCode() string
+
+	// Context returns the metadata of the error.
+	//
+	// Returns:
+	//   - map[string]any: The metadata. Nil if none.
+	Context() map[string]any
+}
+
+// ErrCoded attaches a code and metadata to an error without changing its
+// message.
+type ErrCoded struct {
+	// Code is the stable code of the error.
+	Code string
+
+	// Metadata is the key/value metadata of the error.
+	Metadata map[string]any
+
+	// Err is the error being annotated.
+	Err error
+}
+
+// Error implements the Unwrapper interface.
+//
+// Message: the message of the annotated error, or "error {code}" if it is nil.
+func (e *ErrCoded) Error() string {
+	if e.Err == nil {
+		return "error " + e.Code
+	}
+
+	return e.Err.Error()
+}
+
+// GoString implements the fmt.GoStringer interface.
+//
+// See DumpError.
+func (e *ErrCoded) GoString() string {
+	return DumpError(e)
+}
+
+// Unwrap implements the Unwrapper interface.
+func (e *ErrCoded) Unwrap() error {
+	return e.Err
+}
+
+// ChangeReason implements the Unwrapper interface.
+func (e *ErrCoded) ChangeReason(reason error) {
+	e.Err = reason
+}
+
+// ErrorCode implements the ErrorCoder interface.
+func (e *ErrCoded) ErrorCode() string {
+	return e.Code
+}
+
+// Context implements the ErrorCoder interface.
+//
+// The returned map is a copy; modifying it does not affect the error.
+func (e *ErrCoded) Context() map[string]any {
+	if len(e.Metadata) == 0 {
+		return nil
+	}
+
+	return maps.Clone(e.Metadata)
+}
+
+// With adds a key/value pair to the metadata of the error, replacing any
+// previous value of the key.
+//
+// Parameters:
+//   - key: The key.
+//   - value: The value.
+//
+// Returns:
+//   - *ErrCoded: The error itself, for chaining. Nil if e is nil.
+func (e *ErrCoded) With(key string, value any) *ErrCoded {
+	if e == nil {
+		return nil
+	}
+
+	if e.Metadata == nil {
+		e.Metadata = make(map[string]any)
+	}
+
+	e.Metadata[key] = value
+
+	return e
+}
+
+// WithCode attaches a code to an error.
+//
+// Parameters:
+//   - err: The error to annotate.
+//   - code: The code of the error.
+//
+// Returns:
+//   - *ErrCoded: A pointer to the new error. Nil if err is nil.
+//
+// WithCode returns a pointer so that With can be chained; but, as with any
+// typed nil, storing the result of WithCode(nil, code) in an error variable
+// yields a non-nil error. Check err before calling WithCode when the result is
+// returned as an error:
+//
+//	if err != nil {
+//		return WithCode(err, "E_IO")
+//	}
+//
+//	return nil
+//
+// Example:
+//
+//	err := WithCode(gcers.NewErrNilParameter("name"), "E_MISSING_NAME").
+//		With("field", "name")
+func WithCode(err error, code string) *ErrCoded {
+	if err == nil {
+		return nil
+	}
+
+	e := &ErrCoded{
+		Code: code,
+		Err:  err,
+	}
+
+	return e
+}
+
+// CodeOf returns the code of the outermost ErrorCoder in the chain of an
+// error.
+//
+// Parameters:
+//   - err: The error.
+//
+// Returns:
+//   - string: The code. Empty if there is none.
+//   - bool: True if the chain contains an ErrorCoder, false otherwise.
+func CodeOf(err error) (string, bool) {
+	var coder ErrorCoder
+
+	if !errors.As(err, &coder) {
+		return "", false
+	}
+
+	return coder.ErrorCode(), true
+}
+
+// ContextOf merges the metadata of every ErrorCoder in the chain of an error.
+//
+// Parameters:
+//   - err: The error.
+//
+// Returns:
+//   - map[string]any: The merged metadata. Nil if there is none.
+//
+// When several errors of the chain define the same key, the outermost one
+// wins.
+func ContextOf(err error) map[string]any {
+	var result map[string]any
+
+	todo := []error{err}
+
+	for len(todo) > 0 {
+		curr := todo[0]
+		todo = todo[1:]
+
+		if curr == nil {
+			continue
+		}
+
+		coder, ok := curr.(ErrorCoder)
+		if ok {
+			for k, v := range coder.Context() {
+				if result == nil {
+					result = make(map[string]any)
+				}
+
+				_, ok := result[k]
+				if !ok {
+					result[k] = v
+				}
+			}
+		}
+
+		todo = append(todo, unwrap_all(curr)...)
+	}
+
+	return result
+}
@@ -0,0 +1,199 @@
+package common
+
+import (
+	"strings"
+)
+
+// trace_config is the configuration of FormatErrorTrace.
+type trace_config struct {
+	// max_depth is the maximum number of levels to print. 0 means no limit.
+	max_depth int
+
+	// indent is the indentation added at each level.
+	indent string
+
+	// single_line is true if the levels are printed on one line.
+	single_line bool
+}
+
+// TraceOption is an option of FormatErrorTrace.
+type TraceOption func(*trace_config)
+
+// WithTraceMaxDepth limits the number of levels printed by FormatErrorTrace.
+// Deeper levels are replaced by "...".
+//
+// Parameters:
+//   - depth: The maximum number of levels. Values below 1 mean no limit.
+//
+// Returns:
+//   - TraceOption: The option.
+func WithTraceMaxDepth(depth int) TraceOption {
+	return func(tc *trace_config) {
+		tc.max_depth = max(depth, 0)
+	}
+}
+
+// WithTraceIndent sets the indentation added at each level of a multi-line
+// trace. The default is two spaces.
+//
+// Parameters:
+//   - indent: The indentation.
+//
+// Returns:
+//   - TraceOption: The option.
+func WithTraceIndent(indent string) TraceOption {
+	return func(tc *trace_config) {
+		tc.indent = indent
+	}
+}
+
+// WithTraceSingleLine prints the trace on a single line, separating the levels
+// with ": ".
+//
+// Returns:
+//   - TraceOption: The option.
+func WithTraceSingleLine() TraceOption {
+	return func(tc *trace_config) {
+		tc.single_line = true
+	}
+}
+
+// own_message returns the part of the message of an error that does not come
+// from the errors it wraps.
+//
+// Parameters:
+//   - err: The error.
+//   - reasons: The errors it wraps.
+//
+// Returns:
+//   - string: The message of the error alone.
+//
+// Assertions:
+//   - err != nil
+func own_message(err error, reasons []error) string {
+	group, ok := err.(*ErrGroup)
+	if ok {
+		header, _, _ := strings.Cut(group.Tree(), "\n")
+		return header
+	}
+
+	msg := err.Error()
+
+	if len(reasons) != 1 {
+		return msg
+	}
+
+	trimmed, ok := strings.CutSuffix(msg, reasons[0].Error())
+	if !ok {
+		return msg
+	}
+
+	trimmed = strings.TrimSuffix(strings.TrimRight(trimmed, " "), ":")
+	if trimmed == "" {
+		return msg
+	}
+
+	return trimmed
+}
+
+// write_trace writes an error and the errors it wraps to the builder.
+//
+// Parameters:
+//   - builder: The builder to write to.
+//   - err: The error.
+//   - depth: The depth of the error.
+//   - cfg: The configuration.
+//
+// Assertions:
+//   - builder != nil
+//   - err != nil
+//   - cfg != nil
+func write_trace(builder *strings.Builder, err error, depth int, cfg *trace_config) {
+	if cfg.max_depth > 0 && depth >= cfg.max_depth {
+		builder.WriteString("...")
+		return
+	}
+
+	var reasons []error
+
+	for _, reason := range unwrap_all(err) {
+		if reason != nil {
+			reasons = append(reasons, reason)
+		}
+	}
+
+	builder.WriteString(own_message(err, reasons))
+
+	if cfg.single_line {
+		switch len(reasons) {
+		case 0:
+		case 1:
+			builder.WriteString(": ")
+			write_trace(builder, reasons[0], depth+1, cfg)
+		default:
+			builder.WriteString(": [")
+
+			for i, reason := range reasons {
+				if i > 0 {
+					builder.WriteString("; ")
+				}
+
+				write_trace(builder, reason, depth+1, cfg)
+			}
+
+			builder.WriteRune(']')
+		}
+
+		return
+	}
+
+	for _, reason := range reasons {
+		builder.WriteRune('\n')
+		builder.WriteString(strings.Repeat(cfg.indent, depth+1))
+
+		write_trace(builder, reason, depth+1, cfg)
+	}
+}
+
+// FormatErrorTrace renders an error and the chain of errors it wraps, one
+// level per line, each level indented below the error that wraps it.
+//
+// Parameters:
+//   - err: The error to format.
+//   - opts: The options.
+//
+// Returns:
+//   - string: The trace. Empty if err is nil.
+//
+// Format:
+//
+//	error while parsing
+//	  3rd index is invalid
+//	    invalid rune
+//	      bad
+//
+// Each level only shows the part of its message that does not come from the
+// error it wraps. Errors that wrap several errors (Unwrap() []error) show each
+// of them as a sibling at the next level; in single-line mode they are listed
+// between brackets. Unlike LimitErrorMsg, the error is never modified.
+func FormatErrorTrace(err error, opts ...TraceOption) string {
+	if err == nil {
+		return ""
+	}
+
+	cfg := &trace_config{
+		indent: "  ",
+	}
+
+	for _, opt := range opts {
+		if opt != nil {
+			opt(cfg)
+		}
+	}
+
+	var builder strings.Builder
+
+	write_trace(&builder, err, 0, cfg)
+
+	return builder.String()
+}
@@ -2,23 +2,43 @@
 package common
 
 import (
+	"errors"
 	"fmt"
+	"io"
+	"strconv"
 	"strings"
+	"time"
 )
 
 // ErrPanic represents an error when a panic occurs.
 type ErrPanic struct {
 	// Value is the value that caused the panic.
 	Value any
+
+	// Stack is the stack trace of the goroutine that panicked, as returned by
+	// runtime/debug.Stack. Nil if it was not captured.
+	Stack []byte
+
+	// Entry is the name of the entry point at which the panic was recovered,
+	// as given to Guard. Empty if unknown.
+	Entry string
 }
 
 // Error implements the error interface.
 //
-// Message: "panic: {value}"
+// Message: "panic in {entry}: {value}", or "panic: {value}" if the entry
+// point is unknown.
 func (e *ErrPanic) Error() string {
 	var builder strings.Builder
 
-	builder.WriteString("panic: ")
+	if e.Entry != "" {
+		builder.WriteString("panic in ")
+		builder.WriteString(e.Entry)
+		builder.WriteString(": ")
+	} else {
+		builder.WriteString("panic: ")
+	}
+
 	fmt.Fprintf(&builder, "%v", e.Value)
 
 	str := builder.String()
@@ -26,6 +46,22 @@ func (e *ErrPanic) Error() string {
 	return str
 }
 
+// GoString implements the fmt.GoStringer interface.
+//
+// See DumpError.
+func (e *ErrPanic) GoString() string {
+	return DumpError(e)
+}
+
+// Unwrap returns the value that caused the panic if it is an error.
+//
+// Returns:
+//   - error: The value as an error. Nil if it is not an error.
+func (e *ErrPanic) Unwrap() error {
+	err, _ := e.Value.(error)
+	return err
+}
+
 // NewErrPanic creates a new ErrPanic error.
 //
 // Parameters:
@@ -67,6 +103,13 @@ func (e *ErrUnexpectedType[T]) Error() string {
 	return str
 }
 
+// GoString implements the fmt.GoStringer interface.
+//
+// See DumpError.
+func (e *ErrUnexpectedType[T]) GoString() string {
+	return DumpError(e)
+}
+
 // NewErrUnexpectedType creates a new ErrUnexpectedType error.
 //
 // Parameters:
@@ -83,6 +126,10 @@ func NewErrUnexpectedType[T any](kind string, elem T) *ErrUnexpectedType[T] {
 	return e
 }
 
+// ExhaustedIter is the sentinel error returned when an iterator is exhausted.
+// Use errors.Is(err, ExhaustedIter) to check for it.
+var ExhaustedIter = &ErrExhaustedIter{}
+
 // ErrExhaustedIter is an error type that is returned when an iterator
 // is exhausted (i.e., there are no more elements to consume).
 type ErrExhaustedIter struct{}
@@ -94,11 +141,219 @@ func (e *ErrExhaustedIter) Error() string {
 	return "iterator is exhausted"
 }
 
-// NewErrExhaustedIter creates a new ErrExhaustedIter error.
+// GoString implements the fmt.GoStringer interface.
+//
+// See DumpError.
+func (e *ErrExhaustedIter) GoString() string {
+	return DumpError(e)
+}
+
+// Is implements the errors.Is interface.
+//
+// Any *ErrExhaustedIter matches, not only the ExhaustedIter sentinel.
+func (e *ErrExhaustedIter) Is(target error) bool {
+	_, ok := target.(*ErrExhaustedIter)
+	return ok
+}
+
+// NewErrExhaustedIter returns the ExhaustedIter sentinel error.
 //
 // Returns:
-//   - *ErrExhaustedIter: A pointer to the new error.
+//   - *ErrExhaustedIter: The ExhaustedIter sentinel. Never nil.
 func NewErrExhaustedIter() *ErrExhaustedIter {
-	e := &ErrExhaustedIter{}
+	return ExhaustedIter
+}
+
+// ExhaustedToEOF maps an exhausted-iterator error to io.EOF so that iterators can
+// be bridged to Reader-style APIs.
+//
+// Parameters:
+//   - err: The error to map.
+//
+// Returns:
+//   - error: io.EOF if err is an *ErrExhaustedIter, err otherwise.
+func ExhaustedToEOF(err error) error {
+	if errors.Is(err, ExhaustedIter) {
+		return io.EOF
+	}
+
+	return err
+}
+
+// EOFToExhausted is the inverse of ExhaustedToEOF; it maps io.EOF to the
+// ExhaustedIter sentinel.
+//
+// Parameters:
+//   - err: The error to map.
+//
+// Returns:
+//   - error: ExhaustedIter if err is io.EOF, err otherwise.
+func EOFToExhausted(err error) error {
+	if errors.Is(err, io.EOF) {
+		return ExhaustedIter
+	}
+
+	return err
+}
+
+// ErrUnknownValue represents an error when a value is not one of the accepted
+// values, optionally with the accepted values that are the closest to it.
+type ErrUnknownValue struct {
+	// Got is the value that was given.
+	Got string
+
+	// Suggestions are the accepted values that are the closest to Got, the
+	// closest first.
+	Suggestions []string
+}
+
+// Error implements the error interface.
+//
+// Message: "unknown value {got}", followed by "; did you mean {a}, {b} or
+// {c}?" if there are suggestions.
+func (e *ErrUnknownValue) Error() string {
+	var builder strings.Builder
+
+	builder.WriteString("unknown value ")
+	builder.WriteString(strconv.Quote(e.Got))
+
+	if len(e.Suggestions) == 0 {
+		return builder.String()
+	}
+
+	builder.WriteString("; did you mean ")
+
+	for i, suggestion := range e.Suggestions {
+		switch {
+		case i == 0:
+		case i == len(e.Suggestions)-1:
+			builder.WriteString(" or ")
+		default:
+			builder.WriteString(", ")
+		}
+
+		builder.WriteString(strconv.Quote(suggestion))
+	}
+
+	builder.WriteRune('?')
+
+	return builder.String()
+}
+
+// GoString implements the fmt.GoStringer interface.
+//
+// See DumpError.
+func (e *ErrUnknownValue) GoString() string {
+	return DumpError(e)
+}
+
+// NewErrUnknownValue creates a new ErrUnknownValue error.
+//
+// Parameters:
+//   - got: The value that was given.
+//   - suggestions: The accepted values that are the closest to got.
+//
+// Returns:
+//   - *ErrUnknownValue: A pointer to the newly created ErrUnknownValue.
+func NewErrUnknownValue(got string, suggestions []string) *ErrUnknownValue {
+	e := &ErrUnknownValue{
+		Got:         got,
+		Suggestions: suggestions,
+	}
+
+	return e
+}
+
+// NewErrUnknownValueWithSuggestions creates a new ErrUnknownValue error whose
+// suggestions are the candidates closest to the given value by Levenshtein
+// distance.
+//
+// Parameters:
+//   - got: The value that was given.
+//   - candidates: The accepted values.
+//
+// Returns:
+//   - *ErrUnknownValue: A pointer to the newly created ErrUnknownValue.
+//
+// See ClosestCandidates for how the suggestions are chosen.
+func NewErrUnknownValueWithSuggestions(got string, candidates []string) *ErrUnknownValue {
+	e := &ErrUnknownValue{
+		Got:         got,
+		Suggestions: ClosestCandidates(got, candidates),
+	}
+
+	return e
+}
+
+// ErrBudgetExceeded represents an error when a search ran out of budget or
+// passed its deadline before it was done.
+type ErrBudgetExceeded struct {
+	// Budget is the total cost that was allowed. Zero if unlimited.
+	Budget float64
+
+	// Spent is the total cost of the candidates that were collected.
+	Spent float64
+
+	// Deadline is the time by which the search had to be done. Zero if none.
+	Deadline time.Time
+
+	// Partial is the number of solutions collected before the budget ran out.
+	Partial int
+}
+
+// Error implements the error interface.
+//
+// Message: "budget of {budget} exceeded (spent {spent})" or "deadline
+// exceeded", followed by "; {partial} partial solution(s)" if there are
+// any.
+func (e *ErrBudgetExceeded) Error() string {
+	var builder strings.Builder
+
+	if e.Budget > 0 && e.Spent > e.Budget {
+		builder.WriteString("budget of ")
+		builder.WriteString(strconv.FormatFloat(e.Budget, 'g', -1, 64))
+		builder.WriteString(" exceeded (spent ")
+		builder.WriteString(strconv.FormatFloat(e.Spent, 'g', -1, 64))
+		builder.WriteRune(')')
+	} else {
+		builder.WriteString("deadline exceeded")
+	}
+
+	switch e.Partial {
+	case 0:
+	case 1:
+		builder.WriteString("; 1 partial solution")
+	default:
+		builder.WriteString("; ")
+		builder.WriteString(strconv.Itoa(e.Partial))
+		builder.WriteString(" partial solutions")
+	}
+
+	return builder.String()
+}
+
+// GoString implements the fmt.GoStringer interface.
+//
+// See DumpError.
+func (e *ErrBudgetExceeded) GoString() string {
+	return DumpError(e)
+}
+
+// NewErrBudgetExceeded creates a new ErrBudgetExceeded error.
+//
+// Parameters:
+//   - budget: The total cost that was allowed. Zero if unlimited.
+//   - spent: The total cost that was spent.
+//   - partial: The number of solutions collected so far.
+//
+// Returns:
+//   - *ErrBudgetExceeded: A pointer to the newly created ErrBudgetExceeded.
+func NewErrBudgetExceeded(budget, spent float64, partial int) *ErrBudgetExceeded {
+	e := &ErrBudgetExceeded{
+		Budget:  budget,
+		Spent:   spent,
+		Partial: partial,
+	}
+
 	return e
 }
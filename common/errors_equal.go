@@ -0,0 +1,120 @@
+package common
+
+import (
+	"errors"
+	"hash/fnv"
+	"reflect"
+)
+
+// unwrap_all returns the errors directly wrapped by the given error.
+//
+// Parameters:
+//   - err: The error to unwrap.
+//
+// Returns:
+//   - []error: The wrapped errors. Nil if the error wraps nothing.
+//
+// Both the "Unwrap() error" and the "Unwrap() []error" forms are supported.
+func unwrap_all(err error) []error {
+	switch err := err.(type) {
+	case interface{ Unwrap() []error }:
+		return err.Unwrap()
+	default:
+		reason := errors.Unwrap(err)
+		if reason == nil {
+			return nil
+		}
+
+		return []error{reason}
+	}
+}
+
+// ErrorsEqual checks whether two errors are equal; that is, they have the same
+// type and message and their unwrap chains have the same shape and are equal
+// as well.
+//
+// Parameters:
+//   - a: The first error.
+//   - b: The second error.
+//
+// Returns:
+//   - bool: True if the errors are equal, false otherwise.
+//
+// Two nil errors are equal. A nil error is never equal to a non-nil one.
+func ErrorsEqual(a, b error) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+
+	if reflect.TypeOf(a) != reflect.TypeOf(b) || a.Error() != b.Error() {
+		return false
+	}
+
+	reasons_a := unwrap_all(a)
+	reasons_b := unwrap_all(b)
+
+	if len(reasons_a) != len(reasons_b) {
+		return false
+	}
+
+	for i, reason := range reasons_a {
+		if !ErrorsEqual(reason, reasons_b[i]) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// HashError computes a hash of the error that is consistent with ErrorsEqual;
+// that is, equal errors always have the same hash.
+//
+// Parameters:
+//   - err: The error to hash.
+//
+// Returns:
+//   - uint64: The hash of the error. 0 if the error is nil.
+func HashError(err error) uint64 {
+	if err == nil {
+		return 0
+	}
+
+	h := fnv.New64a()
+
+	_, _ = h.Write([]byte(reflect.TypeOf(err).String()))
+	_, _ = h.Write([]byte{0})
+	_, _ = h.Write([]byte(err.Error()))
+
+	var buf [8]byte
+
+	for _, reason := range unwrap_all(err) {
+		sub := HashError(reason)
+
+		for i := 0; i < 8; i++ {
+			buf[i] = byte(sub >> (8 * i))
+		}
+
+		_, _ = h.Write([]byte{1})
+		_, _ = h.Write(buf[:])
+	}
+
+	return h.Sum64()
+}
+
+// contains_error checks whether an error equal to the target is in the list.
+//
+// Parameters:
+//   - errs: The list of errors.
+//   - target: The error to search for.
+//
+// Returns:
+//   - bool: True if an equal error is found, false otherwise.
+func contains_error(errs []error, target error) bool {
+	for _, err := range errs {
+		if ErrorsEqual(err, target) {
+			return true
+		}
+	}
+
+	return false
+}
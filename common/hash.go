@@ -0,0 +1,373 @@
+package common
+
+import (
+	"encoding/binary"
+	"hash"
+	"hash/fnv"
+	"math"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+// Hasher is implemented by values that compute their own hash. HashOf uses
+// it instead of inspecting the value.
+type Hasher interface {
+	// Hash returns the hash of the value. Equal values must have equal hashes.
+	//
+	// Returns:
+	//   - uint64: The hash.
+	Hash() uint64
+}
+
+// ErrUnhashable represents an error when a value cannot be hashed.
+type ErrUnhashable struct {
+	// Type is the type of the value, or of the part of it, that cannot be
+	// hashed.
+	Type reflect.Type
+
+	// IsCycle is true if the value cannot be hashed because it references
+	// itself.
+	IsCycle bool
+}
+
+// Error implements the error interface.
+//
+// Message: "values of type {type} cannot be hashed" or, for cycles,
+// "value of type {type} references itself".
+func (e *ErrUnhashable) Error() string {
+	var builder strings.Builder
+
+	if e.IsCycle {
+		builder.WriteString("value of type ")
+		builder.WriteString(e.Type.String())
+		builder.WriteString(" references itself")
+	} else {
+		builder.WriteString("values of type ")
+		builder.WriteString(e.Type.String())
+		builder.WriteString(" cannot be hashed")
+	}
+
+	return builder.String()
+}
+
+// GoString implements the fmt.GoStringer interface.
+//
+// See DumpError.
+func (e *ErrUnhashable) GoString() string {
+	return DumpError(e)
+}
+
+// NewErrUnhashable creates a new ErrUnhashable error.
+//
+// Parameters:
+//   - t: The type that cannot be hashed.
+//   - is_cycle: Whether the value references itself.
+//
+// Returns:
+//   - *ErrUnhashable: A pointer to the new error. Never nil.
+func NewErrUnhashable(t reflect.Type, is_cycle bool) *ErrUnhashable {
+	e := &ErrUnhashable{
+		Type:    t,
+		IsCycle: is_cycle,
+	}
+
+	return e
+}
+
+// visit_key identifies a value that can reference itself: a pointer, a map or
+// a slice.
+type visit_key struct {
+	// typ is the type of the value.
+	typ reflect.Type
+
+	// ptr is the address the value refers to.
+	ptr uintptr
+
+	// len is the length of the value, for slices; so that a slice and the
+	// slices of it are told apart.
+	len int
+}
+
+// new_visit_key returns the key of a value that can reference itself.
+//
+// Parameters:
+//   - v: The value.
+//
+// Returns:
+//   - visit_key: The key of the value.
+//   - bool: False if the value cannot reference itself, true otherwise.
+//
+// Nil values and empty slices hold no value, hence they cannot reference
+// themselves.
+func new_visit_key(v reflect.Value) (visit_key, bool) {
+	switch v.Kind() {
+	case reflect.Pointer, reflect.Map:
+		if v.IsNil() {
+			return visit_key{}, false
+		}
+
+		return visit_key{typ: v.Type(), ptr: v.Pointer()}, true
+	case reflect.Slice:
+		if v.Len() == 0 {
+			return visit_key{}, false
+		}
+
+		return visit_key{typ: v.Type(), ptr: v.Pointer(), len: v.Len()}, true
+	default:
+		return visit_key{}, false
+	}
+}
+
+// value_hasher is the state of a HashOf computation.
+type value_hasher struct {
+	// h is the underlying hash function.
+	h hash.Hash64
+
+	// visiting is the set of pointers, maps and slices on the current path,
+	// used to detect cycles.
+	visiting map[visit_key]bool
+
+	// buf is a scratch buffer.
+	buf [8]byte
+}
+
+// write_u64 writes an integer to the hash.
+//
+// Parameters:
+//   - v: The integer to write.
+func (vh *value_hasher) write_u64(v uint64) {
+	binary.LittleEndian.PutUint64(vh.buf[:], v)
+
+	_, _ = vh.h.Write(vh.buf[:])
+}
+
+// write_string writes a length-prefixed string to the hash.
+//
+// Parameters:
+//   - s: The string to write.
+func (vh *value_hasher) write_string(s string) {
+	vh.write_u64(uint64(len(s)))
+
+	_, _ = vh.h.Write([]byte(s))
+}
+
+// write_float writes a float to the hash so that 0 and -0 collide, as do all
+// the NaNs.
+//
+// Parameters:
+//   - f: The float to write.
+func (vh *value_hasher) write_float(f float64) {
+	switch {
+	case f == 0:
+		f = 0
+	case math.IsNaN(f):
+		f = math.NaN()
+	}
+
+	vh.write_u64(math.Float64bits(f))
+}
+
+// sub_hash computes the hash of a value independently of the current state.
+//
+// Parameters:
+//   - v: The value to hash.
+//
+// Returns:
+//   - uint64: The hash.
+//   - error: An error if the value cannot be hashed.
+func (vh *value_hasher) sub_hash(v reflect.Value) (uint64, error) {
+	sub := &value_hasher{
+		h:        fnv.New64a(),
+		visiting: vh.visiting,
+	}
+
+	err := sub.write_value(v)
+	if err != nil {
+		return 0, err
+	}
+
+	return sub.h.Sum64(), nil
+}
+
+// write_value writes a value to the hash.
+//
+// Parameters:
+//   - v: The value to write.
+//
+// Returns:
+//   - error: An error if the value cannot be hashed.
+//
+// Errors:
+//   - *ErrUnhashable: If the value contains functions, channels or unsafe
+//     pointers, or if it references itself.
+func (vh *value_hasher) write_value(v reflect.Value) error {
+	if !v.IsValid() {
+		vh.write_u64(0)
+
+		return nil
+	}
+
+	if v.CanInterface() {
+		hasher, ok := v.Interface().(Hasher)
+		if ok && (v.Kind() != reflect.Pointer || !v.IsNil()) {
+			vh.write_string(v.Type().String())
+			vh.write_u64(hasher.Hash())
+
+			return nil
+		}
+	}
+
+	vh.write_u64(uint64(v.Kind()))
+
+	key, ok := new_visit_key(v)
+	if ok {
+		if vh.visiting[key] {
+			return NewErrUnhashable(v.Type(), true)
+		}
+
+		vh.visiting[key] = true
+		defer delete(vh.visiting, key)
+	}
+
+	switch v.Kind() {
+	case reflect.Bool:
+		if v.Bool() {
+			vh.write_u64(1)
+		} else {
+			vh.write_u64(0)
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		vh.write_u64(uint64(v.Int()))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		vh.write_u64(v.Uint())
+	case reflect.Float32, reflect.Float64:
+		vh.write_float(v.Float())
+	case reflect.Complex64, reflect.Complex128:
+		c := v.Complex()
+
+		vh.write_float(real(c))
+		vh.write_float(imag(c))
+	case reflect.String:
+		vh.write_string(v.String())
+	case reflect.Slice, reflect.Array:
+		if v.Kind() == reflect.Slice && v.IsNil() {
+			vh.write_u64(math.MaxUint64)
+
+			return nil
+		}
+
+		vh.write_u64(uint64(v.Len()))
+
+		for i := 0; i < v.Len(); i++ {
+			err := vh.write_value(v.Index(i))
+			if err != nil {
+				return err
+			}
+		}
+	case reflect.Map:
+		if v.IsNil() {
+			vh.write_u64(math.MaxUint64)
+
+			return nil
+		}
+
+		// The entries are hashed separately and sorted so that the result does
+		// not depend on the iteration order of the map.
+		entries := make([]uint64, 0, v.Len())
+
+		iter := v.MapRange()
+		for iter.Next() {
+			kh, err := vh.sub_hash(iter.Key())
+			if err != nil {
+				return err
+			}
+
+			eh, err := vh.sub_hash(iter.Value())
+			if err != nil {
+				return err
+			}
+
+			entries = append(entries, kh*31+eh)
+		}
+
+		slices.Sort(entries)
+
+		vh.write_u64(uint64(len(entries)))
+
+		for _, entry := range entries {
+			vh.write_u64(entry)
+		}
+	case reflect.Struct:
+		t := v.Type()
+
+		vh.write_string(t.String())
+
+		for i := 0; i < v.NumField(); i++ {
+			vh.write_string(t.Field(i).Name)
+
+			err := vh.write_value(v.Field(i))
+			if err != nil {
+				return err
+			}
+		}
+	case reflect.Pointer:
+		if v.IsNil() {
+			vh.write_u64(0)
+
+			return nil
+		}
+
+		vh.write_u64(1)
+
+		return vh.write_value(v.Elem())
+	case reflect.Interface:
+		if v.IsNil() {
+			vh.write_u64(0)
+
+			return nil
+		}
+
+		vh.write_string(v.Elem().Type().String())
+
+		return vh.write_value(v.Elem())
+	default:
+		return NewErrUnhashable(v.Type(), false)
+	}
+
+	return nil
+}
+
+// HashOf computes a hash of an arbitrary value that only depends on its
+// contents; thus, equal values have equal hashes across calls and runs.
+//
+// Parameters:
+//   - v: The value to hash.
+//
+// Returns:
+//   - uint64: The hash of the value.
+//   - error: An error if the value cannot be hashed.
+//
+// Errors:
+//   - *ErrUnhashable: If the value contains functions, channels or unsafe
+//     pointers, or if it references itself.
+//
+// Behaviors:
+//   - Values implementing Hasher are hashed with their Hash method.
+//   - Pointers are followed; two pointers to equal values hash the same.
+//   - Maps are hashed independently of their iteration order.
+//   - Struct fields, exported or not, are all hashed.
+//   - 0 and -0 hash the same, as do all the NaNs.
+func HashOf(v any) (uint64, error) {
+	vh := &value_hasher{
+		h:        fnv.New64a(),
+		visiting: make(map[visit_key]bool),
+	}
+
+	err := vh.write_value(reflect.ValueOf(v))
+	if err != nil {
+		return 0, err
+	}
+
+	return vh.h.Sum64(), nil
+}
@@ -0,0 +1,70 @@
+package common
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestHashOf(t *testing.T) {
+	h1, err := HashOf(map[string][]int{"a": {1, 2}, "b": {3}})
+	if err != nil {
+		t.Fatalf("HashOf() error = %v", err)
+	}
+
+	h2, err := HashOf(map[string][]int{"b": {3}, "a": {1, 2}})
+	if err != nil {
+		t.Fatalf("HashOf() error = %v", err)
+	}
+
+	if h1 != h2 {
+		t.Errorf("HashOf() = %d, %d, want equal hashes", h1, h2)
+	}
+
+	_, err = HashOf(func() {})
+
+	var unhashable *ErrUnhashable
+
+	if !errors.As(err, &unhashable) || unhashable.IsCycle {
+		t.Errorf("HashOf(func) error = %v, want *ErrUnhashable", err)
+	}
+}
+
+func TestHashOfCycle(t *testing.T) {
+	type node struct {
+		next *node
+	}
+
+	n := &node{}
+	n.next = n
+
+	s := []any{nil}
+	s[0] = s
+
+	m := map[string]any{}
+	m["self"] = m
+
+	tests := map[string]any{
+		"pointer": n,
+		"slice":   s,
+		"map":     m,
+	}
+
+	for name, v := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := HashOf(v)
+
+			var unhashable *ErrUnhashable
+
+			if !errors.As(err, &unhashable) || !unhashable.IsCycle {
+				t.Errorf("HashOf() error = %v, want a cycle", err)
+			}
+		})
+	}
+
+	shared := []int{1, 2}
+
+	_, err := HashOf([][]int{shared, shared, shared[:1]})
+	if err != nil {
+		t.Errorf("HashOf(shared) error = %v, want nil", err)
+	}
+}
@@ -0,0 +1,137 @@
+package common
+
+// Iterater is an interface that defines methods for an iterator over a
+// collection of elements.
+type Iterater[T any] interface {
+	// Consume returns the next element of the iterator while advancing it.
+	//
+	// Returns:
+	//   - T: The next element.
+	//   - error: An error if the element could not be consumed.
+	//
+	// Errors:
+	//   - *ErrExhaustedIter: If the iterator has no more elements.
+	//   - any other error: Implementation-specific error.
+	Consume() (T, error)
+
+	// Restart restarts the iterator from the first element.
+	Restart()
+}
+
+// Peeker is implemented by iterators that can return their next element
+// without consuming it.
+type Peeker[T any] interface {
+	Iterater[T]
+
+	// Peek returns the next element of the iterator without advancing it.
+	//
+	// Returns:
+	//   - T: The next element.
+	//   - error: An error if the element could not be read.
+	//
+	// Errors:
+	//   - *ErrExhaustedIter: If the iterator has no more elements.
+	//   - any other error: Implementation-specific error.
+	Peek() (T, error)
+}
+
+// ReversibleIterater is implemented by iterators that can move backwards.
+type ReversibleIterater[T any] interface {
+	Iterater[T]
+
+	// Previous returns the element before the current position while moving
+	// the iterator back. Thus, calling Previous right after Consume returns
+	// the same element again.
+	//
+	// Returns:
+	//   - T: The previous element.
+	//   - error: An error if the element could not be read.
+	//
+	// Errors:
+	//   - *ErrExhaustedIter: If the iterator is at the first element.
+	//   - any other error: Implementation-specific error.
+	Previous() (T, error)
+
+	// SeekEnd moves the iterator past the last element, so that the next call
+	// to Previous returns the last element.
+	SeekEnd()
+}
+
+// Iterable is an interface that defines a method to get an iterator over a
+// collection of elements.
+type Iterable[T any] interface {
+	// Iterator returns an iterator over the collection of elements.
+	//
+	// Returns:
+	//   - Iterater[T]: An iterator over the collection of elements. Never nil.
+	Iterator() Iterater[T]
+}
+
+// SimpleIterator is an iterator over a slice of elements.
+type SimpleIterator[T any] struct {
+	// values is the slice of elements.
+	values []T
+
+	// index is the index of the next element to consume.
+	index int
+}
+
+// Consume implements the Iterater interface.
+func (si *SimpleIterator[T]) Consume() (T, error) {
+	if si.index >= len(si.values) {
+		return *new(T), NewErrExhaustedIter()
+	}
+
+	value := si.values[si.index]
+	si.index++
+
+	return value, nil
+}
+
+// Peek implements the Peeker interface.
+func (si *SimpleIterator[T]) Peek() (T, error) {
+	if si.index >= len(si.values) {
+		return *new(T), NewErrExhaustedIter()
+	}
+
+	return si.values[si.index], nil
+}
+
+// Restart implements the Iterater interface.
+func (si *SimpleIterator[T]) Restart() {
+	si.index = 0
+}
+
+// Previous implements the ReversibleIterater interface.
+func (si *SimpleIterator[T]) Previous() (T, error) {
+	if si.index <= 0 {
+		return *new(T), NewErrExhaustedIter()
+	}
+
+	si.index--
+
+	return si.values[si.index], nil
+}
+
+// SeekEnd implements the ReversibleIterater interface.
+func (si *SimpleIterator[T]) SeekEnd() {
+	si.index = len(si.values)
+}
+
+// NewSimpleIterator creates a new SimpleIterator over the given values.
+//
+// Parameters:
+//   - values: The values to iterate over.
+//
+// Returns:
+//   - *SimpleIterator[T]: The new iterator. Never nil.
+//
+// The slice is not copied; thus, changes to it are visible to the iterator.
+func NewSimpleIterator[T any](values []T) *SimpleIterator[T] {
+	si := &SimpleIterator[T]{
+		values: values,
+		index:  0,
+	}
+
+	return si
+}
@@ -0,0 +1,68 @@
+package common
+
+import (
+	"strconv"
+	"strings"
+)
+
+// LazyError is an error whose message is only built when it is asked for.
+//
+// It is meant for errors created on hot paths, such as the mismatches of a
+// matcher that tries many candidates, whose callers usually discard them or
+// only check their type; formatting their message there would be wasted work.
+type LazyError struct {
+	// msg_fn builds the message.
+	msg_fn func() string
+}
+
+// Error implements the error interface.
+//
+// Message: the result of the function given to NewLazyError.
+//
+// The function is called on every call, since the message is not cached; that
+// keeps the error small and safe to share between goroutines.
+func (e *LazyError) Error() string {
+	if e.msg_fn == nil {
+		return ""
+	}
+
+	return e.msg_fn()
+}
+
+// GoString implements the fmt.GoStringer interface.
+//
+// Unlike the other error types of this package, the message is shown as a call
+// to NewLazyError instead of through DumpError, which would show the internal
+// state of the error. Building it builds the message.
+func (e *LazyError) GoString() string {
+	var builder strings.Builder
+
+	builder.WriteString("common.NewLazyError(func() string { return ")
+	builder.WriteString(strconv.Quote(e.Error()))
+	builder.WriteString(" })")
+
+	return builder.String()
+}
+
+// NewLazyError creates a new LazyError.
+//
+// Parameters:
+//   - msg_fn: The function that builds the message. It must not depend on
+//     state that changes after the error is created, and should be cheap to
+//     call more than once. If nil, the message is empty.
+//
+// Returns:
+//   - *LazyError: A pointer to the newly created LazyError. Never nil.
+//
+// Example:
+//
+//	err := NewLazyError(func() string {
+//		return fmt.Sprintf("expected %q, got %q instead", want, got)
+//	})
+func NewLazyError(msg_fn func() string) *LazyError {
+	e := &LazyError{
+		msg_fn: msg_fn,
+	}
+
+	return e
+}
@@ -0,0 +1,32 @@
+package common
+
+import (
+	"testing"
+)
+
+func TestLazyError(t *testing.T) {
+	var calls int
+
+	err := NewLazyError(func() string {
+		calls++
+		return "expected 'a', got 'b' instead"
+	})
+
+	if calls != 0 {
+		t.Fatalf("expected the message not to be built yet")
+	}
+
+	for i := 0; i < 2; i++ {
+		if msg := err.Error(); msg != "expected 'a', got 'b' instead" {
+			t.Errorf("unexpected message %q", msg)
+		}
+	}
+
+	if calls != 2 {
+		t.Errorf("expected the message to be built on each call, got %d calls", calls)
+	}
+
+	if got := NewLazyError(nil).Error(); got != "" {
+		t.Errorf("expected an empty message, got %q", got)
+	}
+}
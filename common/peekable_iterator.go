@@ -0,0 +1,82 @@
+package common
+
+// PeekableIterator is an iterator that wraps another iterator and buffers one
+// element so that it can be looked at before being consumed.
+type PeekableIterator[T any] struct {
+	// src is the wrapped iterator.
+	src Iterater[T]
+
+	// next is the buffered element.
+	next T
+
+	// next_err is the error returned when next was read.
+	next_err error
+
+	// has_next is true if an element is buffered.
+	has_next bool
+}
+
+// Consume implements the Iterater interface.
+func (pi *PeekableIterator[T]) Consume() (T, error) {
+	if !pi.has_next {
+		return pi.src.Consume()
+	}
+
+	value, err := pi.next, pi.next_err
+
+	pi.next = *new(T)
+	pi.next_err = nil
+	pi.has_next = false
+
+	return value, err
+}
+
+// Peek implements the Peeker interface.
+//
+// Errors are buffered like elements; thus, peeking twice never consumes more
+// than one element of the wrapped iterator.
+func (pi *PeekableIterator[T]) Peek() (T, error) {
+	if !pi.has_next {
+		pi.next, pi.next_err = pi.src.Consume()
+		pi.has_next = true
+	}
+
+	return pi.next, pi.next_err
+}
+
+// Restart implements the Iterater interface.
+//
+// The buffered element, if any, is discarded.
+func (pi *PeekableIterator[T]) Restart() {
+	pi.src.Restart()
+
+	pi.next = *new(T)
+	pi.next_err = nil
+	pi.has_next = false
+}
+
+// NewPeekableIterator creates a new PeekableIterator.
+//
+// Parameters:
+//   - src: The iterator to wrap.
+//
+// Returns:
+//   - Peeker[T]: The new iterator. Nil if src is nil.
+//
+// If src already implements Peeker, it is returned as is.
+func NewPeekableIterator[T any](src Iterater[T]) Peeker[T] {
+	if src == nil {
+		return nil
+	}
+
+	p, ok := src.(Peeker[T])
+	if ok {
+		return p
+	}
+
+	pi := &PeekableIterator[T]{
+		src: src,
+	}
+
+	return pi
+}
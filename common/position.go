@@ -0,0 +1,167 @@
+package common
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+)
+
+// Position is a location in a source, such as a file or a stream.
+type Position struct {
+	// File is the name of the file. Empty if unknown.
+	File string
+
+	// Line is the 1-based line number. 0 if unknown.
+	Line int
+
+	// Col is the 1-based column number. 0 if unknown.
+	Col int
+
+	// Offset is the 0-based byte offset from the start of the source.
+	Offset int
+}
+
+// IsValid checks whether the position has a line number.
+//
+// Returns:
+//   - bool: True if the line number is known, false otherwise.
+func (p Position) IsValid() bool {
+	return p.Line > 0
+}
+
+// String implements the fmt.Stringer interface.
+//
+// Format: one of the following, depending on which fields are known:
+//   - "file:line:col"
+//   - "file:line"
+//   - "file"
+//   - "line:col"
+//   - "line"
+//   - "-"
+func (p Position) String() string {
+	var builder strings.Builder
+
+	builder.WriteString(p.File)
+
+	if p.IsValid() {
+		if builder.Len() > 0 {
+			builder.WriteRune(':')
+		}
+
+		builder.WriteString(strconv.Itoa(p.Line))
+
+		if p.Col > 0 {
+			builder.WriteRune(':')
+			builder.WriteString(strconv.Itoa(p.Col))
+		}
+	}
+
+	if builder.Len() == 0 {
+		return "-"
+	}
+
+	return builder.String()
+}
+
+// NewPosition creates a new Position.
+//
+// Parameters:
+//   - file: The name of the file. Empty if unknown.
+//   - line: The 1-based line number. 0 if unknown.
+//   - col: The 1-based column number. 0 if unknown.
+//   - offset: The 0-based byte offset.
+//
+// Returns:
+//   - Position: The new position.
+func NewPosition(file string, line, col, offset int) Position {
+	p := Position{
+		File:   file,
+		Line:   line,
+		Col:    col,
+		Offset: offset,
+	}
+
+	return p
+}
+
+// ErrAtPosition represents an error that occurred at a specific position.
+type ErrAtPosition struct {
+	// Pos is the position at which the error occurred.
+	Pos Position
+
+	// Reason is the reason for the error.
+	Reason error
+}
+
+// Error implements the Unwrapper interface.
+//
+// Message: "{position}: {reason}".
+//
+// However, if the reason is nil, the message is "an error occurred at {position}"
+// instead.
+func (e *ErrAtPosition) Error() string {
+	var builder strings.Builder
+
+	if e.Reason == nil {
+		builder.WriteString("an error occurred at ")
+		builder.WriteString(e.Pos.String())
+	} else {
+		builder.WriteString(e.Pos.String())
+		builder.WriteString(": ")
+		builder.WriteString(e.Reason.Error())
+	}
+
+	return builder.String()
+}
+
+// GoString implements the fmt.GoStringer interface.
+//
+// See DumpError.
+func (e *ErrAtPosition) GoString() string {
+	return DumpError(e)
+}
+
+// Unwrap implements the Unwrapper interface.
+func (e *ErrAtPosition) Unwrap() error {
+	return e.Reason
+}
+
+// ChangeReason implements the Unwrapper interface.
+func (e *ErrAtPosition) ChangeReason(reason error) {
+	e.Reason = reason
+}
+
+// NewErrAtPosition creates a new ErrAtPosition error.
+//
+// Parameters:
+//   - pos: The position at which the error occurred.
+//   - reason: The reason for the error.
+//
+// Returns:
+//   - *ErrAtPosition: A pointer to the new ErrAtPosition error.
+func NewErrAtPosition(pos Position, reason error) *ErrAtPosition {
+	return &ErrAtPosition{
+		Pos:    pos,
+		Reason: reason,
+	}
+}
+
+// PositionOf returns the position of the outermost *ErrAtPosition in the error
+// chain.
+//
+// Parameters:
+//   - err: The error to inspect.
+//
+// Returns:
+//   - Position: The position of the error.
+//   - bool: True if a position was found, false otherwise.
+func PositionOf(err error) (Position, bool) {
+	var target *ErrAtPosition
+
+	ok := errors.As(err, &target)
+	if !ok {
+		return Position{}, false
+	}
+
+	return target.Pos, true
+}
@@ -0,0 +1,68 @@
+package common
+
+// ReverseIterator is an iterator that walks a ReversibleIterater backwards:
+// Consume moves towards the first element and Restart starts again from the
+// last one.
+type ReverseIterator[T any] struct {
+	// src is the wrapped iterator.
+	src ReversibleIterater[T]
+}
+
+// Consume implements the Iterater interface.
+func (ri *ReverseIterator[T]) Consume() (T, error) {
+	return ri.src.Previous()
+}
+
+// Restart implements the Iterater interface.
+func (ri *ReverseIterator[T]) Restart() {
+	ri.src.SeekEnd()
+}
+
+// Previous implements the ReversibleIterater interface.
+func (ri *ReverseIterator[T]) Previous() (T, error) {
+	return ri.src.Consume()
+}
+
+// SeekEnd implements the ReversibleIterater interface.
+func (ri *ReverseIterator[T]) SeekEnd() {
+	ri.src.Restart()
+}
+
+// NewReverseIterator creates a new ReverseIterator that starts from the last
+// element of src.
+//
+// Parameters:
+//   - src: The iterator to walk backwards.
+//
+// Returns:
+//   - *ReverseIterator[T]: The new iterator. Nil if src is nil.
+//
+// The source iterator is moved past its last element; the two share their
+// position afterwards.
+func NewReverseIterator[T any](src ReversibleIterater[T]) *ReverseIterator[T] {
+	if src == nil {
+		return nil
+	}
+
+	src.SeekEnd()
+
+	ri := &ReverseIterator[T]{
+		src: src,
+	}
+
+	return ri
+}
+
+// NewSliceReverseIterator creates an iterator over the values, from the last
+// to the first.
+//
+// Parameters:
+//   - values: The values to iterate over.
+//
+// Returns:
+//   - *ReverseIterator[T]: The new iterator. Never nil.
+//
+// The slice is not copied; thus, changes to it are visible to the iterator.
+func NewSliceReverseIterator[T any](values []T) *ReverseIterator[T] {
+	return NewReverseIterator[T](NewSimpleIterator(values))
+}
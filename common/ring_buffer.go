@@ -0,0 +1,191 @@
+package common
+
+// RingBuffer is a fixed-capacity buffer that overwrites its oldest element
+// when it is full. The zero value is a buffer of capacity zero; use
+// NewRingBuffer to get one that holds elements.
+type RingBuffer[T any] struct {
+	// buf is the backing storage.
+	buf []T
+
+	// head is the index of the oldest element in buf.
+	head int
+
+	// size is the number of elements in the buffer.
+	size int
+}
+
+// NewRingBuffer creates a new, empty RingBuffer.
+//
+// Parameters:
+//   - capacity: The maximum number of elements the buffer can hold.
+//
+// Returns:
+//   - *RingBuffer[T]: The new buffer. Nil if capacity is not positive.
+func NewRingBuffer[T any](capacity int) *RingBuffer[T] {
+	if capacity <= 0 {
+		return nil
+	}
+
+	rb := &RingBuffer[T]{
+		buf: make([]T, capacity),
+	}
+
+	return rb
+}
+
+// Push adds an element after the newest one. If the buffer is full, the
+// oldest element is overwritten.
+//
+// Parameters:
+//   - elem: The element to add.
+//
+// Returns:
+//   - T: The element that was overwritten. The zero value if none was.
+//   - bool: True if an element was overwritten, false otherwise.
+//
+// A buffer of capacity zero cannot hold elem; thus, elem itself is returned as
+// the overwritten element.
+func (rb *RingBuffer[T]) Push(elem T) (T, bool) {
+	if len(rb.buf) == 0 {
+		return elem, true
+	}
+
+	if rb.size < len(rb.buf) {
+		rb.buf[(rb.head+rb.size)%len(rb.buf)] = elem
+		rb.size++
+
+		return *new(T), false
+	}
+
+	evicted := rb.buf[rb.head]
+
+	rb.buf[rb.head] = elem
+	rb.head = (rb.head + 1) % len(rb.buf)
+
+	return evicted, true
+}
+
+// PopFront removes the oldest element.
+//
+// Returns:
+//   - T: The oldest element. The zero value if the buffer is empty.
+//   - bool: True if an element was removed, false if the buffer is empty.
+func (rb *RingBuffer[T]) PopFront() (T, bool) {
+	if rb.size == 0 {
+		return *new(T), false
+	}
+
+	elem := rb.buf[rb.head]
+	rb.buf[rb.head] = *new(T)
+
+	rb.head = (rb.head + 1) % len(rb.buf)
+	rb.size--
+
+	return elem, true
+}
+
+// PopBack removes the newest element.
+//
+// Returns:
+//   - T: The newest element. The zero value if the buffer is empty.
+//   - bool: True if an element was removed, false if the buffer is empty.
+func (rb *RingBuffer[T]) PopBack() (T, bool) {
+	if rb.size == 0 {
+		return *new(T), false
+	}
+
+	idx := (rb.head + rb.size - 1) % len(rb.buf)
+
+	elem := rb.buf[idx]
+	rb.buf[idx] = *new(T)
+
+	rb.size--
+
+	return elem, true
+}
+
+// At returns the element at the given position, where 0 is the oldest
+// element.
+//
+// Parameters:
+//   - idx: The position of the element.
+//
+// Returns:
+//   - T: The element. The zero value if idx is out of range.
+//   - bool: True if idx is in range, false otherwise.
+func (rb *RingBuffer[T]) At(idx int) (T, bool) {
+	if idx < 0 || idx >= rb.size {
+		return *new(T), false
+	}
+
+	return rb.buf[(rb.head+idx)%len(rb.buf)], true
+}
+
+// Len returns the number of elements in the buffer.
+//
+// Returns:
+//   - int: The number of elements.
+func (rb *RingBuffer[T]) Len() int {
+	return rb.size
+}
+
+// Cap returns the capacity of the buffer.
+//
+// Returns:
+//   - int: The capacity.
+func (rb *RingBuffer[T]) Cap() int {
+	return len(rb.buf)
+}
+
+// IsFull checks whether the next Push overwrites an element.
+//
+// Returns:
+//   - bool: True if the buffer is full, false otherwise.
+func (rb *RingBuffer[T]) IsFull() bool {
+	return rb.size == len(rb.buf)
+}
+
+// Reset removes all the elements from the buffer.
+//
+// Only the slots that hold elements are cleared, so that resetting a buffer
+// that is almost empty is cheap.
+func (rb *RingBuffer[T]) Reset() {
+	end := rb.head + rb.size
+
+	if end <= len(rb.buf) {
+		clear(rb.buf[rb.head:end])
+	} else {
+		clear(rb.buf[rb.head:])
+		clear(rb.buf[:end-len(rb.buf)])
+	}
+
+	rb.head = 0
+	rb.size = 0
+}
+
+// Slice returns the elements from the oldest to the newest.
+//
+// Returns:
+//   - []T: A copy of the elements. Never nil.
+func (rb *RingBuffer[T]) Slice() []T {
+	elems := make([]T, 0, rb.size)
+
+	end := rb.head + rb.size
+
+	if end <= len(rb.buf) {
+		elems = append(elems, rb.buf[rb.head:end]...)
+	} else {
+		elems = append(elems, rb.buf[rb.head:]...)
+		elems = append(elems, rb.buf[:end-len(rb.buf)]...)
+	}
+
+	return elems
+}
+
+// Iterator implements the Iterable interface.
+//
+// The iterator walks a snapshot of the elements, from the oldest to the
+// newest; later pushes do not affect it.
+func (rb *RingBuffer[T]) Iterator() Iterater[T] {
+	return NewSimpleIterator(rb.Slice())
+}
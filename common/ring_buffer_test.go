@@ -0,0 +1,45 @@
+package common
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestRingBuffer(t *testing.T) {
+	rb := NewRingBuffer[int](3)
+
+	for i := 1; i <= 4; i++ {
+		evicted, ok := rb.Push(i)
+
+		if ok != (i == 4) || (ok && evicted != 1) {
+			t.Errorf("Push(%d) = (%d, %t)", i, evicted, ok)
+		}
+	}
+
+	if !slices.Equal(rb.Slice(), []int{2, 3, 4}) {
+		t.Errorf("expected [2 3 4], got %v", rb.Slice())
+	}
+
+	back, _ := rb.PopBack()
+	front, _ := rb.PopFront()
+
+	if back != 4 || front != 2 || rb.Len() != 1 {
+		t.Errorf("expected to pop 4 and 2, got %d and %d with %d left", back, front, rb.Len())
+	}
+}
+
+func TestRingBufferZeroValue(t *testing.T) {
+	var rb RingBuffer[int]
+
+	evicted, ok := rb.Push(1)
+	if !ok || evicted != 1 {
+		t.Errorf("Push(1) = (%d, %t), want (1, true)", evicted, ok)
+	}
+
+	_, ok = rb.PopFront()
+	if ok || rb.Len() != 0 || !rb.IsFull() {
+		t.Errorf("expected an empty buffer of capacity zero")
+	}
+
+	rb.Reset()
+}
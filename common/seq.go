@@ -0,0 +1,136 @@
+//go:build go1.23
+
+package common
+
+import (
+	"errors"
+	"iter"
+)
+
+// Seq returns a range-over-func sequence over the remaining elements of the
+// iterator.
+//
+// Parameters:
+//   - it: The iterator.
+//
+// Returns:
+//   - iter.Seq[T]: The sequence. Empty if it is nil.
+//
+// The sequence stops at the first error of the iterator, exhaustion or not;
+// use SeqErr to observe the other errors.
+func Seq[T any](it Iterater[T]) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		if it == nil {
+			return
+		}
+
+		for {
+			value, err := it.Consume()
+			if err != nil || !yield(value) {
+				return
+			}
+		}
+	}
+}
+
+// SeqErr is like Seq but also yields the error that stopped the iterator,
+// unless it is an *ErrExhaustedIter.
+//
+// Parameters:
+//   - it: The iterator.
+//
+// Returns:
+//   - iter.Seq2[T, error]: The sequence. Empty if it is nil.
+//
+// When an error is yielded, it is the last pair of the sequence and its
+// element is the zero value.
+func SeqErr[T any](it Iterater[T]) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		if it == nil {
+			return
+		}
+
+		for {
+			value, err := it.Consume()
+			if errors.Is(err, ExhaustedIter) {
+				return
+			} else if err != nil {
+				yield(*new(T), err)
+
+				return
+			}
+
+			if !yield(value, nil) {
+				return
+			}
+		}
+	}
+}
+
+// SeqIterator is an iterator over a range-over-func sequence.
+type SeqIterator[T any] struct {
+	// seq is the sequence.
+	seq iter.Seq[T]
+
+	// next is the function that pulls the next element. Nil if the sequence
+	// has not been started.
+	next func() (T, bool)
+
+	// stop is the function that stops the sequence. Nil if the sequence has
+	// not been started.
+	stop func()
+}
+
+// Consume implements the Iterater interface.
+func (si *SeqIterator[T]) Consume() (T, error) {
+	if si.next == nil {
+		si.next, si.stop = iter.Pull(si.seq)
+	}
+
+	value, ok := si.next()
+	if !ok {
+		return *new(T), NewErrExhaustedIter()
+	}
+
+	return value, nil
+}
+
+// Restart implements the Iterater interface.
+//
+// The sequence is started over from its beginning.
+func (si *SeqIterator[T]) Restart() {
+	si.Stop()
+}
+
+// Stop releases the resources held by a sequence that is not consumed until
+// its end. The next call to Consume starts the sequence over.
+func (si *SeqIterator[T]) Stop() {
+	if si.stop != nil {
+		si.stop()
+	}
+
+	si.next = nil
+	si.stop = nil
+}
+
+// FromSeq creates an iterator over a range-over-func sequence.
+//
+// Parameters:
+//   - seq: The sequence.
+//
+// Returns:
+//   - *SeqIterator[T]: The new iterator. Nil if seq is nil.
+//
+// The sequence is pulled lazily. If the iterator is abandoned before it is
+// exhausted, Stop must be called to release the sequence.
+func FromSeq[T any](seq iter.Seq[T]) *SeqIterator[T] {
+	if seq == nil {
+		return nil
+	}
+
+	si := &SeqIterator[T]{
+		seq: seq,
+	}
+
+	return si
+}
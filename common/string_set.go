@@ -0,0 +1,128 @@
+package common
+
+import (
+	"slices"
+)
+
+// StringSet is a set of unique strings kept in ascending order.
+type StringSet struct {
+	// elems is the sorted list of strings.
+	elems []string
+}
+
+// NewStringSet creates a new StringSet with the given strings.
+//
+// Parameters:
+//   - elems: The strings to add to the set. Duplicates are ignored.
+//
+// Returns:
+//   - *StringSet: The new set. Never nil.
+func NewStringSet(elems ...string) *StringSet {
+	s := &StringSet{
+		elems: make([]string, 0, len(elems)),
+	}
+
+	for _, elem := range elems {
+		s.Add(elem)
+	}
+
+	return s
+}
+
+// Add adds a string to the set.
+//
+// Parameters:
+//   - elem: The string to add.
+//
+// Returns:
+//   - bool: True if the string was added, false if it was already in the set.
+func (s *StringSet) Add(elem string) bool {
+	pos, ok := slices.BinarySearch(s.elems, elem)
+	if ok {
+		return false
+	}
+
+	s.elems = slices.Insert(s.elems, pos, elem)
+
+	return true
+}
+
+// Contains checks whether a string is in the set.
+//
+// Parameters:
+//   - elem: The string to check.
+//
+// Returns:
+//   - bool: True if the string is in the set, false otherwise.
+func (s *StringSet) Contains(elem string) bool {
+	_, ok := slices.BinarySearch(s.elems, elem)
+	return ok
+}
+
+// Remove removes a string from the set.
+//
+// Parameters:
+//   - elem: The string to remove.
+//
+// Returns:
+//   - bool: True if the string was removed, false if it was not in the set.
+func (s *StringSet) Remove(elem string) bool {
+	pos, ok := slices.BinarySearch(s.elems, elem)
+	if !ok {
+		return false
+	}
+
+	s.elems = slices.Delete(s.elems, pos, pos+1)
+
+	return true
+}
+
+// Size returns the number of strings in the set.
+//
+// Returns:
+//   - int: The number of strings.
+func (s *StringSet) Size() int {
+	return len(s.elems)
+}
+
+// Slice returns the strings of the set in ascending order.
+//
+// Returns:
+//   - []string: A copy of the strings. Never nil.
+func (s *StringSet) Slice() []string {
+	elems := make([]string, len(s.elems))
+	copy(elems, s.elems)
+
+	return elems
+}
+
+// Union returns a new set containing the strings of both sets.
+//
+// Parameters:
+//   - other: The other set. A nil set is treated as an empty one.
+//
+// Returns:
+//   - *StringSet: The union of the two sets. Never nil.
+func (s *StringSet) Union(other *StringSet) *StringSet {
+	union := &StringSet{
+		elems: s.Slice(),
+	}
+
+	if other == nil {
+		return union
+	}
+
+	for _, elem := range other.elems {
+		union.Add(elem)
+	}
+
+	return union
+}
+
+// Iterator returns an iterator over the strings of the set in ascending order.
+//
+// Returns:
+//   - Iterater[string]: The iterator. Never nil.
+func (s *StringSet) Iterator() Iterater[string] {
+	return NewSimpleIterator(s.Slice())
+}
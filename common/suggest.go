@@ -0,0 +1,118 @@
+package common
+
+import (
+	"slices"
+)
+
+// edit_distance computes the Levenshtein distance between two strings; that
+// is, the number of rune insertions, deletions and substitutions needed to
+// turn one into the other.
+//
+// Parameters:
+//   - a: The first string.
+//   - b: The second string.
+//
+// Returns:
+//   - int: The distance.
+//
+// This mirrors the distance of runes.LevenshteinTable, which cannot be used
+// here without an import cycle.
+func edit_distance(a, b []rune) int {
+	prev := make([]int, len(b)+1)
+	curr := make([]int, len(b)+1)
+
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(a); i++ {
+		curr[0] = i
+
+		for j := 1; j <= len(b); j++ {
+			if a[i-1] == b[j-1] {
+				curr[j] = prev[j-1]
+			} else {
+				curr[j] = 1 + Min(prev[j-1], Min(prev[j], curr[j-1]))
+			}
+		}
+
+		prev, curr = curr, prev
+	}
+
+	return prev[len(b)]
+}
+
+// ClosestCandidates returns the candidates that are close enough to a value to
+// be suggested in its place.
+//
+// Parameters:
+//   - got: The value.
+//   - candidates: The candidates.
+//
+// Returns:
+//   - []string: At most three candidates, the closest first. Nil if none is
+//     close enough.
+//
+// It is the same as ClosestCandidatesN(got, candidates, 3).
+func ClosestCandidates(got string, candidates []string) []string {
+	return ClosestCandidatesN(got, candidates, 3)
+}
+
+// ClosestCandidatesN returns at most n candidates that are close enough to a
+// value to be suggested in its place.
+//
+// Parameters:
+//   - got: The value.
+//   - candidates: The candidates.
+//   - n: The maximum number of candidates to return. Values below 1 mean no
+//     limit.
+//
+// Returns:
+//   - []string: The candidates, the closest first. Nil if none is close
+//     enough.
+//
+// A candidate is close enough if its Levenshtein distance to got is at most a
+// third of the length of got (and at least 1). Candidates at the same distance
+// keep their order; duplicates and candidates equal to got are ignored.
+func ClosestCandidatesN(got string, candidates []string, n int) []string {
+	target := []rune(got)
+	limit := Max(1, len(target)/3)
+
+	type scored struct {
+		word string
+		dist int
+	}
+
+	var matches []scored
+
+	for _, candidate := range candidates {
+		if candidate == got || slices.ContainsFunc(matches, func(s scored) bool { return s.word == candidate }) {
+			continue
+		}
+
+		d := edit_distance(target, []rune(candidate))
+		if d <= limit {
+			matches = append(matches, scored{word: candidate, dist: d})
+		}
+	}
+
+	if len(matches) == 0 {
+		return nil
+	}
+
+	slices.SortStableFunc(matches, func(a, b scored) int {
+		return a.dist - b.dist
+	})
+
+	if n > 0 && len(matches) > n {
+		matches = matches[:n]
+	}
+
+	result := make([]string, 0, len(matches))
+
+	for _, match := range matches {
+		result = append(result, match.word)
+	}
+
+	return result
+}
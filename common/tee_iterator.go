@@ -0,0 +1,182 @@
+package common
+
+import (
+	"errors"
+	"sync"
+)
+
+// tee_result is an element read from the source of a tee, with its error.
+type tee_result[T any] struct {
+	// value is the element.
+	value T
+
+	// err is the error returned with the element.
+	err error
+}
+
+// tee_source is the state shared by the branches of a tee.
+type tee_source[T any] struct {
+	// mu protects the fields below.
+	mu sync.Mutex
+
+	// src is the iterator being shared.
+	src Iterater[T]
+
+	// buf holds the elements read from src that some branch has not consumed
+	// yet.
+	buf []tee_result[T]
+
+	// base is the index, in src, of buf[0].
+	base int
+
+	// positions are the indices, in src, of the next element of each branch.
+	positions []int
+
+	// exhausted is true once src returned an *ErrExhaustedIter.
+	exhausted bool
+}
+
+// trim drops the elements that every branch consumed.
+func (ts *tee_source[T]) trim() {
+	lowest := ts.positions[0]
+
+	for _, pos := range ts.positions[1:] {
+		lowest = min(lowest, pos)
+	}
+
+	if lowest == ts.base {
+		return
+	}
+
+	drop := lowest - ts.base
+
+	clear(ts.buf[:drop])
+	ts.buf = ts.buf[drop:]
+	ts.base = lowest
+}
+
+// consume returns the next element of a branch.
+//
+// Parameters:
+//   - id: The index of the branch.
+//
+// Returns:
+//   - T: The element.
+//   - error: The error returned by src with the element.
+func (ts *tee_source[T]) consume(id int) (T, error) {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+
+	pos := ts.positions[id]
+
+	if pos < ts.base+len(ts.buf) {
+		res := ts.buf[pos-ts.base]
+
+		ts.positions[id]++
+		ts.trim()
+
+		return res.value, res.err
+	}
+
+	if ts.exhausted {
+		return *new(T), NewErrExhaustedIter()
+	}
+
+	value, err := ts.src.Consume()
+	if errors.Is(err, ExhaustedIter) {
+		ts.exhausted = true
+
+		return value, err
+	}
+
+	ts.buf = append(ts.buf, tee_result[T]{value: value, err: err})
+
+	ts.positions[id]++
+	ts.trim()
+
+	return value, err
+}
+
+// restart restarts src and every branch.
+func (ts *tee_source[T]) restart() {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+
+	ts.src.Restart()
+
+	clear(ts.buf)
+	ts.buf = ts.buf[:0]
+	ts.base = 0
+	ts.exhausted = false
+
+	for i := range ts.positions {
+		ts.positions[i] = 0
+	}
+}
+
+// tee_iterator is a branch of a tee.
+type tee_iterator[T any] struct {
+	// source is the shared state.
+	source *tee_source[T]
+
+	// id is the index of the branch.
+	id int
+}
+
+// Consume implements the Iterater interface.
+func (ti *tee_iterator[T]) Consume() (T, error) {
+	return ti.source.consume(ti.id)
+}
+
+// Restart implements the Iterater interface.
+//
+// Restarting a branch restarts the source and every other branch.
+func (ti *tee_iterator[T]) Restart() {
+	ti.source.restart()
+}
+
+// TeeIterator splits an iterator into several iterators that each yield
+// every element of it, independently of each other.
+//
+// Parameters:
+//   - src: The iterator to split. It must not be consumed directly anymore.
+//   - n: The number of iterators to create.
+//
+// Returns:
+//   - []Iterater[T]: The iterators. Nil if src is nil or n is not positive.
+//
+// Example:
+//
+//	iters := TeeIterator(tokens, 2)
+//	go collect_stats(iters[1])
+//	parse(iters[0])
+//
+// Behaviors:
+//   - Only the elements between the slowest and the fastest iterators are
+//     buffered; an iterator that is never consumed thus makes the whole source
+//     be buffered.
+//   - Errors are buffered like elements, so every iterator sees the same
+//     sequence; exhaustion is not buffered.
+//   - The iterators can be consumed from different goroutines.
+//   - Restarting any of the iterators restarts all of them.
+func TeeIterator[T any](src Iterater[T], n int) []Iterater[T] {
+	if src == nil || n <= 0 {
+		return nil
+	}
+
+	source := &tee_source[T]{
+		src:       src,
+		positions: make([]int, n),
+	}
+
+	iters := make([]Iterater[T], 0, n)
+
+	for i := 0; i < n; i++ {
+		iters = append(iters, &tee_iterator[T]{
+			source: source,
+			id:     i,
+		})
+	}
+
+	return iters
+}
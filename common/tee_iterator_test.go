@@ -0,0 +1,46 @@
+package common
+
+import (
+	"errors"
+	"slices"
+	"testing"
+)
+
+func TestTeeIterator(t *testing.T) {
+	iters := TeeIterator[int](NewSimpleIterator([]int{1, 2, 3}), 2)
+
+	var firsts []int
+
+	for {
+		value, err := iters[0].Consume()
+		if errors.Is(err, ExhaustedIter) {
+			break
+		}
+
+		firsts = append(firsts, value)
+	}
+
+	source := iters[0].(*tee_iterator[int]).source
+	if len(source.buf) != 3 {
+		t.Errorf("expected 3 buffered elements, got %d", len(source.buf))
+	}
+
+	var seconds []int
+
+	for {
+		value, err := iters[1].Consume()
+		if errors.Is(err, ExhaustedIter) {
+			break
+		}
+
+		seconds = append(seconds, value)
+	}
+
+	if !slices.Equal(firsts, []int{1, 2, 3}) || !slices.Equal(seconds, firsts) {
+		t.Errorf("expected both iterators to yield [1 2 3], got %v and %v", firsts, seconds)
+	}
+
+	if len(source.buf) != 0 {
+		t.Errorf("expected the buffer to be empty, got %d elements", len(source.buf))
+	}
+}
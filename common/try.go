@@ -0,0 +1,172 @@
+package common
+
+import (
+	"log"
+	"runtime/debug"
+	"sync"
+
+	gcers "github.com/PlayerR9/go-commons/errors"
+)
+
+// Try calls a function and turns a panic into an error.
+//
+// Parameters:
+//   - fn: The function to call.
+//
+// Returns:
+//   - error: The error returned by fn, or the panic it raised.
+//
+// Errors:
+//   - *errors.ErrInvalidParameter: If fn is nil.
+//   - *ErrPanic: If fn panics. Its Stack field holds the stack trace at the
+//     point of the panic.
+//   - any other error returned by fn.
+func Try(fn func() error) (err error) {
+	if fn == nil {
+		return gcers.NewErrNilParameter("fn")
+	}
+
+	defer func() {
+		r := recover()
+		if r == nil {
+			return
+		}
+
+		err = &ErrPanic{
+			Value: r,
+			Stack: debug.Stack(),
+		}
+	}()
+
+	err = fn()
+
+	return err
+}
+
+// TryWithValue calls a function and turns a panic into an error.
+//
+// Parameters:
+//   - fn: The function to call.
+//
+// Returns:
+//   - T: The value returned by fn. The zero value if it panics.
+//   - error: The error returned by fn, or the panic it raised.
+//
+// Errors:
+//   - *errors.ErrInvalidParameter: If fn is nil.
+//   - *ErrPanic: If fn panics. Its Stack field holds the stack trace at the
+//     point of the panic.
+//   - any other error returned by fn.
+func TryWithValue[T any](fn func() (T, error)) (value T, err error) {
+	if fn == nil {
+		return value, gcers.NewErrNilParameter("fn")
+	}
+
+	defer func() {
+		r := recover()
+		if r == nil {
+			return
+		}
+
+		var zero T
+
+		value = zero
+		err = &ErrPanic{
+			Value: r,
+			Stack: debug.Stack(),
+		}
+	}()
+
+	value, err = fn()
+
+	return value, err
+}
+
+var (
+	// guard_mu protects guard_logger.
+	guard_mu sync.RWMutex
+
+	// guard_logger logs the panics recovered by Guard. Nil if they are not
+	// logged.
+	guard_logger *log.Logger
+)
+
+// SetGuardLogger sets the logger to which Guard reports the panics it
+// recovers, with their stack traces. By default, they are not logged.
+//
+// Parameters:
+//   - logger: The logger. If nil, panics are not logged.
+//
+// Returns:
+//   - *log.Logger: The previous logger. Nil if there was none.
+func SetGuardLogger(logger *log.Logger) *log.Logger {
+	guard_mu.Lock()
+	defer guard_mu.Unlock()
+
+	prev := guard_logger
+	guard_logger = logger
+
+	return prev
+}
+
+// Guard calls the body of a public entry point and turns a panic into an
+// error, so that a bug in the library does not crash the host application.
+//
+// Parameters:
+//   - name: The name of the entry point, such as "WordMatcher.Match".
+//   - fn: The body of the entry point.
+//
+// Returns:
+//   - error: The error returned by fn, or the panic it raised.
+//
+// Errors:
+//   - *errors.ErrInvalidParameter: If fn is nil.
+//   - *ErrPanic: If fn panics. Its Entry field is name and its Stack field
+//     holds the stack trace at the point of the panic.
+//   - any other error returned by fn.
+//
+// Example:
+//
+//	func (p *Parser) Parse(input []byte) (tree *Tree, err error) {
+//		err = Guard("Parser.Parse", func() error {
+//			tree, err = p.parse(input)
+//			return err
+//		})
+//
+//		return tree, err
+//	}
+//
+// Unlike Try, the recovered panic is reported to the logger set with
+// SetGuardLogger, if any.
+func Guard(name string, fn func() error) (err error) {
+	if fn == nil {
+		return gcers.NewErrNilParameter("fn")
+	}
+
+	defer func() {
+		r := recover()
+		if r == nil {
+			return
+		}
+
+		e := &ErrPanic{
+			Value: r,
+			Stack: debug.Stack(),
+			Entry: name,
+		}
+
+		guard_mu.RLock()
+		logger := guard_logger
+		guard_mu.RUnlock()
+
+		if logger != nil {
+			logger.Printf("%s\n%s", e.Error(), e.Stack)
+		}
+
+		err = e
+	}()
+
+	err = fn()
+
+	return err
+}
@@ -0,0 +1,48 @@
+package common
+
+import (
+	"bytes"
+	"errors"
+	"log"
+	"strings"
+	"testing"
+)
+
+func TestGuard(t *testing.T) {
+	var buf bytes.Buffer
+
+	prev := SetGuardLogger(log.New(&buf, "", 0))
+	defer SetGuardLogger(prev)
+
+	err := Guard("Parser.Parse", func() error {
+		var m map[string]int
+		m["x"] = 1
+
+		return nil
+	})
+
+	var e *ErrPanic
+
+	if !errors.As(err, &e) {
+		t.Fatalf("expected an *ErrPanic, got %v", err)
+	}
+
+	if e.Entry != "Parser.Parse" || len(e.Stack) == 0 {
+		t.Errorf("expected the entry point and the stack to be recorded, got %q and %d bytes", e.Entry, len(e.Stack))
+	}
+
+	if !strings.HasPrefix(err.Error(), "panic in Parser.Parse: ") {
+		t.Errorf("unexpected message %q", err.Error())
+	}
+
+	if !strings.Contains(buf.String(), "panic in Parser.Parse") {
+		t.Errorf("expected the panic to be logged, got %q", buf.String())
+	}
+
+	want := errors.New("failed")
+
+	err = Guard("Parser.Parse", func() error { return want })
+	if err != want {
+		t.Errorf("expected the error of fn, got %v", err)
+	}
+}
@@ -37,6 +37,13 @@ func (e *ErrWhile) Error() string {
 	return builder.String()
 }
 
+// GoString implements the fmt.GoStringer interface.
+//
+// See DumpError.
+func (e *ErrWhile) GoString() string {
+	return DumpError(e)
+}
+
 // Unwrap implements the Unwrapper interface.
 func (e *ErrWhile) Unwrap() error {
 	return e.Reason
@@ -85,6 +92,13 @@ func (e *ErrNoError) Error() string {
 	return msg
 }
 
+// GoString implements the fmt.GoStringer interface.
+//
+// See DumpError.
+func (e *ErrNoError) GoString() string {
+	return DumpError(e)
+}
+
 // Unwrap implements the Unwrapper interface.
 func (e *ErrNoError) Unwrap() error {
 	return e.Err
@@ -130,6 +144,13 @@ func (e *ErrIgnorable) Error() string {
 	return msg
 }
 
+// GoString implements the fmt.GoStringer interface.
+//
+// See DumpError.
+func (e *ErrIgnorable) GoString() string {
+	return DumpError(e)
+}
+
 // Unwrap implements the Unwrapper interface.
 func (e *ErrIgnorable) Unwrap() error {
 	return e.Err
@@ -177,6 +198,13 @@ func (e *ErrInvalidRune) Error() string {
 	return builder.String()
 }
 
+// GoString implements the fmt.GoStringer interface.
+//
+// See DumpError.
+func (e *ErrInvalidRune) GoString() string {
+	return DumpError(e)
+}
+
 // Unwrap implements the Unwrapper interface.
 func (e *ErrInvalidRune) Unwrap() error {
 	return e.Reason
@@ -232,6 +260,13 @@ func (e *ErrAfter) Error() string {
 	return builder.String()
 }
 
+// GoString implements the fmt.GoStringer interface.
+//
+// See DumpError.
+func (e *ErrAfter) GoString() string {
+	return DumpError(e)
+}
+
 // Unwrap implements the Unwrapper interface.
 func (e *ErrAfter) Unwrap() error {
 	return e.Reason
@@ -289,6 +324,13 @@ func (e *ErrBefore) Error() string {
 	return builder.String()
 }
 
+// GoString implements the fmt.GoStringer interface.
+//
+// See DumpError.
+func (e *ErrBefore) GoString() string {
+	return DumpError(e)
+}
+
 // Unwrap implements the Unwrapper interface.
 func (e *ErrBefore) Unwrap() error {
 	return e.Reason
@@ -338,6 +380,13 @@ func (e *ErrUnexpectedError) Error() string {
 	return builder.String()
 }
 
+// GoString implements the fmt.GoStringer interface.
+//
+// See DumpError.
+func (e *ErrUnexpectedError) GoString() string {
+	return DumpError(e)
+}
+
 // Unwrap implements the Unwrapper interface.
 func (e *ErrUnexpectedError) Unwrap() error {
 	return e.Reason
@@ -390,6 +439,13 @@ func (e *ErrVariableError) Error() string {
 	return builder.String()
 }
 
+// GoString implements the fmt.GoStringer interface.
+//
+// See DumpError.
+func (e *ErrVariableError) GoString() string {
+	return DumpError(e)
+}
+
 // Unwrap implements the Unwrapper interface.
 func (e *ErrVariableError) Unwrap() error {
 	return e.Reason
@@ -449,6 +505,13 @@ func (e *ErrPossibleError) Error() string {
 	return builder.String()
 }
 
+// GoString implements the fmt.GoStringer interface.
+//
+// See DumpError.
+func (e *ErrPossibleError) GoString() string {
+	return DumpError(e)
+}
+
 // Unwrap implements the Unwrapper interface.
 func (e *ErrPossibleError) Unwrap() error {
 	return e.Reason
@@ -0,0 +1,243 @@
+package features
+
+import (
+	"os"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// EnvVar is the environment variable from which the initial toggles are read.
+//
+// Its value is a comma-separated list of feature names. A name prefixed with
+// '-' disables the feature instead of enabling it. For example:
+//
+//	LIB_UNITS_FEATURES="generator.cache,-generator.watch"
+const EnvVar string = "LIB_UNITS_FEATURES"
+
+// Feature is a snapshot of a feature toggle.
+type Feature struct {
+	// Name is the name of the feature.
+	Name string
+
+	// Description is the description given at registration. Empty if the
+	// feature was toggled without being registered.
+	Description string
+
+	// Enabled is true if the feature is enabled.
+	Enabled bool
+}
+
+// toggle is the state of a feature in the registry.
+type toggle struct {
+	// description is the description of the feature.
+	description string
+
+	// enabled is true if the feature is enabled.
+	enabled bool
+
+	// explicit is true if the feature was toggled through Enable, Disable or
+	// the environment, in which case registration does not override it.
+	explicit bool
+
+	// registered is true if the feature was registered.
+	registered bool
+}
+
+var (
+	// registry is the set of known features.
+	registry map[string]*toggle
+
+	// mu is the mutex that protects the registry.
+	mu sync.RWMutex
+)
+
+func init() {
+	registry = make(map[string]*toggle)
+
+	LoadEnv()
+}
+
+// Register registers a feature with its default state.
+//
+// Parameters:
+//   - name: The name of the feature.
+//   - description: A short description of the feature.
+//   - default_enabled: Whether the feature is enabled when nobody toggled it.
+//
+// Returns:
+//   - bool: False if the name is empty or the feature was already registered,
+//     true otherwise.
+//
+// If the feature was toggled before being registered (for instance, through the
+// environment), that state is kept.
+func Register(name, description string, default_enabled bool) bool {
+	if name == "" {
+		return false
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	t, ok := registry[name]
+	if !ok {
+		registry[name] = &toggle{
+			description: description,
+			enabled:     default_enabled,
+			registered:  true,
+		}
+
+		return true
+	}
+
+	if t.registered {
+		return false
+	}
+
+	t.description = description
+	t.registered = true
+
+	if !t.explicit {
+		t.enabled = default_enabled
+	}
+
+	return true
+}
+
+// set sets the state of a feature.
+//
+// Parameters:
+//   - name: The name of the feature.
+//   - enabled: The new state.
+//
+// Assertions:
+//   - The caller holds the lock.
+func set(name string, enabled bool) {
+	t, ok := registry[name]
+	if !ok {
+		t = &toggle{}
+		registry[name] = t
+	}
+
+	t.enabled = enabled
+	t.explicit = true
+}
+
+// Enable enables a feature. Does nothing if the name is empty.
+//
+// Parameters:
+//   - name: The name of the feature.
+func Enable(name string) {
+	if name == "" {
+		return
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	set(name, true)
+}
+
+// Disable disables a feature. Does nothing if the name is empty.
+//
+// Parameters:
+//   - name: The name of the feature.
+func Disable(name string) {
+	if name == "" {
+		return
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	set(name, false)
+}
+
+// IsEnabled checks whether a feature is enabled.
+//
+// Parameters:
+//   - name: The name of the feature.
+//
+// Returns:
+//   - bool: True if the feature is enabled, false otherwise (including when the
+//     feature is unknown).
+func IsEnabled(name string) bool {
+	mu.RLock()
+	defer mu.RUnlock()
+
+	t, ok := registry[name]
+	return ok && t.enabled
+}
+
+// List returns all the known features sorted by name.
+//
+// Returns:
+//   - []Feature: The features. Never nil.
+func List() []Feature {
+	mu.RLock()
+	defer mu.RUnlock()
+
+	list := make([]Feature, 0, len(registry))
+
+	for name, t := range registry {
+		list = append(list, Feature{
+			Name:        name,
+			Description: t.description,
+			Enabled:     t.enabled,
+		})
+	}
+
+	slices.SortFunc(list, func(a, b Feature) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+
+	return list
+}
+
+// ParseToggles parses a comma-separated list of toggles in the format of
+// EnvVar.
+//
+// Parameters:
+//   - str: The string to parse.
+//
+// Returns:
+//   - map[string]bool: The state of each named feature. Never nil.
+//
+// Behaviors:
+//   - Whitespace around the names is ignored, as are empty entries.
+//   - If a name appears several times, the last occurrence wins.
+func ParseToggles(str string) map[string]bool {
+	toggles := make(map[string]bool)
+
+	for _, field := range strings.Split(str, ",") {
+		field = strings.TrimSpace(field)
+
+		enabled := true
+
+		if strings.HasPrefix(field, "-") {
+			enabled = false
+			field = strings.TrimSpace(field[1:])
+		}
+
+		if field == "" {
+			continue
+		}
+
+		toggles[field] = enabled
+	}
+
+	return toggles
+}
+
+// LoadEnv applies the toggles found in the EnvVar environment variable. It is
+// called once at initialization and can be called again to reload them.
+func LoadEnv() {
+	toggles := ParseToggles(os.Getenv(EnvVar))
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	for name, enabled := range toggles {
+		set(name, enabled)
+	}
+}
@@ -0,0 +1,59 @@
+package features
+
+import (
+	"testing"
+)
+
+func TestParseToggles(t *testing.T) {
+	toggles := ParseToggles(" a.b , -c,, d ,-a.b")
+
+	if len(toggles) != 3 {
+		t.Fatalf("expected 3 toggles, got %d", len(toggles))
+	}
+
+	if toggles["a.b"] || toggles["c"] || !toggles["d"] {
+		t.Errorf("unexpected toggles: %v", toggles)
+	}
+}
+
+func TestRegister(t *testing.T) {
+	Enable("test.explicit")
+
+	ok := Register("test.explicit", "explicit", false)
+	if !ok {
+		t.Fatalf("expected registration to succeed")
+	}
+
+	if !IsEnabled("test.explicit") {
+		t.Errorf("explicit toggle was overridden by the default")
+	}
+
+	ok = Register("test.explicit", "again", false)
+	if ok {
+		t.Errorf("expected duplicate registration to fail")
+	}
+
+	ok = Register("test.undescribed", "", false)
+	if !ok {
+		t.Fatalf("expected registration without a description to succeed")
+	}
+
+	ok = Register("test.undescribed", "", true)
+	if ok {
+		t.Errorf("expected duplicate registration without a description to fail")
+	}
+
+	if IsEnabled("test.undescribed") {
+		t.Errorf("duplicate registration overrode the default")
+	}
+
+	Register("test.default", "default", true)
+
+	if !IsEnabled("test.default") {
+		t.Errorf("expected default to be enabled")
+	}
+
+	if IsEnabled("test.unknown") {
+		t.Errorf("expected unknown feature to be disabled")
+	}
+}
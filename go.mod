@@ -4,4 +4,8 @@ go 1.22.5
 
 require golang.org/x/exp v0.0.0-20240719175910-8a7402abbf56 // indirect
 
-require github.com/PlayerR9/go-commons v0.1.2
+require (
+	github.com/PlayerR9/go-commons v0.1.2
+	golang.org/x/image v0.18.0
+	golang.org/x/text v0.16.0
+)
@@ -0,0 +1,419 @@
+package helpers
+
+import (
+	"bytes"
+	"encoding/gob"
+	"errors"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+
+	gcers "github.com/PlayerR9/go-commons/errors"
+	luc "github.com/PlayerR9/lib_units/common"
+)
+
+// CheckpointStore persists the progress of EvaluateWithCheckpoint.
+type CheckpointStore interface {
+	// Load returns the last saved checkpoint.
+	//
+	// Returns:
+	//   - []byte: The checkpoint. Nil if none was saved.
+	//   - error: An error if the checkpoint cannot be read.
+	Load() ([]byte, error)
+
+	// Save replaces the saved checkpoint.
+	//
+	// Parameters:
+	//   - data: The checkpoint.
+	//
+	// Returns:
+	//   - error: An error if the checkpoint cannot be written.
+	Save(data []byte) error
+}
+
+// MemoryCheckpointStore is a CheckpointStore that keeps the checkpoint in
+// memory. Useful for tests and for resuming within the same process.
+type MemoryCheckpointStore struct {
+	// mu protects data.
+	mu sync.Mutex
+
+	// data is the saved checkpoint.
+	data []byte
+}
+
+// Load implements the CheckpointStore interface.
+func (s *MemoryCheckpointStore) Load() ([]byte, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return bytes.Clone(s.data), nil
+}
+
+// Save implements the CheckpointStore interface.
+func (s *MemoryCheckpointStore) Save(data []byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.data = bytes.Clone(data)
+
+	return nil
+}
+
+// FileCheckpointStore is a CheckpointStore that keeps the checkpoint in a
+// file.
+type FileCheckpointStore struct {
+	// Path is the path of the file.
+	Path string
+}
+
+// Load implements the CheckpointStore interface.
+//
+// A missing file means that no checkpoint was saved.
+func (s *FileCheckpointStore) Load() ([]byte, error) {
+	data, err := os.ReadFile(s.Path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+
+	return data, err
+}
+
+// Save implements the CheckpointStore interface.
+//
+// The file is replaced atomically so that an interruption never leaves a
+// partial checkpoint behind.
+func (s *FileCheckpointStore) Save(data []byte) error {
+	tmp, err := os.CreateTemp(filepath.Dir(s.Path), filepath.Base(s.Path)+".tmp*")
+	if err != nil {
+		return err
+	}
+
+	_, err = tmp.Write(data)
+	if err == nil {
+		err = tmp.Close()
+	} else {
+		_ = tmp.Close()
+	}
+
+	if err == nil {
+		err = os.Rename(tmp.Name(), s.Path)
+	}
+
+	if err != nil {
+		_ = os.Remove(tmp.Name())
+	}
+
+	return err
+}
+
+// NewFileCheckpointStore creates a new FileCheckpointStore.
+//
+// Parameters:
+//   - path: The path of the file.
+//
+// Returns:
+//   - *FileCheckpointStore: A pointer to the new store. Never nil.
+func NewFileCheckpointStore(path string) *FileCheckpointStore {
+	s := &FileCheckpointStore{
+		Path: path,
+	}
+
+	return s
+}
+
+// checkpoint_header is the first value of a serialized checkpoint.
+type checkpoint_header struct {
+	// Size is the size of the batch.
+	Size int
+}
+
+// checkpoint_entry is the serialized result of an evaluated element. A
+// serialized checkpoint is a checkpoint_header followed by one entry per
+// evaluated element.
+//
+// Only the evaluated elements are saved, as gob cannot encode the nil
+// results of the other ones when O is a pointer type.
+type checkpoint_entry[O any] struct {
+	// Index is the index of the element in the batch.
+	Index int
+
+	// Result is the result of the evaluation.
+	Result O
+
+	// Failed is true if the evaluation failed.
+	Failed bool
+
+	// Reason is the error message of the evaluation, if it failed.
+	Reason string
+}
+
+// checkpoint_state is the progress of EvaluateWithCheckpoint.
+type checkpoint_state[O any] struct {
+	// done tells, for each element of the batch, whether it was evaluated.
+	done []bool
+
+	// results are the results of the evaluated elements.
+	results []O
+
+	// failed tells, for each evaluated element, whether its evaluation
+	// failed.
+	failed []bool
+
+	// reasons are the error messages of the failed elements.
+	reasons []string
+
+	// buf is the serialized checkpoint; enc appends the entries to it.
+	buf bytes.Buffer
+
+	// enc is the encoder that writes into buf.
+	enc *gob.Encoder
+
+	// unsaved are the indices of the elements evaluated since the last save.
+	unsaved []int
+}
+
+// new_checkpoint_state creates the state of a batch where nothing was
+// evaluated.
+//
+// Parameters:
+//   - size: The size of the batch.
+//
+// Returns:
+//   - *checkpoint_state[O]: The state.
+//   - error: An error if the header cannot be encoded.
+func new_checkpoint_state[O any](size int) (*checkpoint_state[O], error) {
+	cs := &checkpoint_state[O]{
+		done:    make([]bool, size),
+		results: make([]O, size),
+		failed:  make([]bool, size),
+		reasons: make([]string, size),
+	}
+
+	cs.enc = gob.NewEncoder(&cs.buf)
+
+	err := cs.enc.Encode(&checkpoint_header{Size: size})
+	if err != nil {
+		return nil, luc.NewErrWhile("encoding checkpoint", err)
+	}
+
+	return cs, nil
+}
+
+// record records the evaluation of an element.
+//
+// Parameters:
+//   - idx: The index of the element.
+//   - res: The result of the evaluation.
+//   - err: The error of the evaluation.
+func (cs *checkpoint_state[O]) record(idx int, res O, err error) {
+	cs.done[idx] = true
+	cs.results[idx] = res
+	cs.failed[idx] = err != nil
+
+	if err != nil {
+		cs.reasons[idx] = err.Error()
+	}
+
+	cs.unsaved = append(cs.unsaved, idx)
+}
+
+// save encodes the elements evaluated since the last save and saves the
+// checkpoint to the store.
+//
+// Parameters:
+//   - store: The store.
+//
+// Returns:
+//   - error: An error if the state cannot be encoded or saved.
+//
+// Only the new entries are encoded, but the whole checkpoint is passed to
+// the store.
+func (cs *checkpoint_state[O]) save(store CheckpointStore) error {
+	for _, idx := range cs.unsaved {
+		entry := checkpoint_entry[O]{
+			Index:  idx,
+			Result: cs.results[idx],
+			Failed: cs.failed[idx],
+			Reason: cs.reasons[idx],
+		}
+
+		err := cs.enc.Encode(&entry)
+		if err != nil {
+			return luc.NewErrWhile("encoding checkpoint", err)
+		}
+	}
+
+	cs.unsaved = cs.unsaved[:0]
+
+	err := store.Save(cs.buf.Bytes())
+	if err != nil {
+		return luc.NewErrWhile("saving checkpoint", err)
+	}
+
+	return nil
+}
+
+// load_checkpoint loads the state of a batch from the store.
+//
+// Parameters:
+//   - store: The store.
+//   - size: The size of the batch.
+//
+// Returns:
+//   - *checkpoint_state[O]: The state. A fresh one if nothing was saved.
+//   - error: An error if the checkpoint cannot be loaded.
+//
+// The loaded entries are encoded again, into a fresh checkpoint, with the
+// next save.
+func load_checkpoint[O any](store CheckpointStore, size int) (*checkpoint_state[O], error) {
+	raw, err := store.Load()
+	if err != nil {
+		return nil, luc.NewErrWhile("loading checkpoint", err)
+	}
+
+	cs, err := new_checkpoint_state[O](size)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(raw) == 0 {
+		return cs, nil
+	}
+
+	dec := gob.NewDecoder(bytes.NewReader(raw))
+
+	var header checkpoint_header
+
+	err = dec.Decode(&header)
+	if err != nil {
+		return nil, luc.NewErrWhile("decoding checkpoint", err)
+	}
+
+	if header.Size != size {
+		return nil, luc.NewErrWhile("loading checkpoint", errors.New("checkpoint is not for a batch of "+strconv.Itoa(size)+" elements"))
+	}
+
+	for {
+		// gob leaves the fields of zero values untouched; thus, each entry is
+		// decoded into a fresh value.
+		var entry checkpoint_entry[O]
+
+		err := dec.Decode(&entry)
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, luc.NewErrWhile("decoding checkpoint", err)
+		}
+
+		if entry.Index < 0 || entry.Index >= size {
+			return nil, luc.NewErrWhile("loading checkpoint", errors.New("checkpoint has an entry out of the batch"))
+		}
+
+		if cs.done[entry.Index] {
+			continue
+		}
+
+		var reason error
+
+		if entry.Failed {
+			reason = errors.New(entry.Reason)
+		}
+
+		cs.record(entry.Index, entry.Result, reason)
+	}
+
+	return cs, nil
+}
+
+// EvaluateWithCheckpoint evaluates a batch while periodically saving its
+// progress, so that an interrupted evaluation can resume where it stopped.
+//
+// Parameters:
+//   - batch: The elements to evaluate.
+//   - f: The evaluation function.
+//   - store: Where the progress is saved.
+//   - every: The number of evaluations between two saves. Values below 1
+//     mean that progress is only saved at the end.
+//
+// Returns:
+//   - []*SimpleHelper[O]: The results, one per element of the batch and in the
+//     same order.
+//   - error: An error if the progress cannot be loaded or saved.
+//
+// Errors:
+//   - *errors.ErrInvalidParameter: If f or store is nil.
+//   - *common.ErrWhile: If the checkpoint cannot be loaded, decoded, encoded
+//     or saved, or if it belongs to a batch of another size.
+//
+// Behaviors:
+//   - Elements already evaluated according to the store are not evaluated
+//     again. The results must be encodable with encoding/gob; as gob
+//     flattens pointers, a pointer to a zero value is restored as nil.
+//   - Evaluation errors are saved as messages; the errors of elements
+//     evaluated before a resume are restored as plain errors with the same
+//     message, even if it is empty.
+//   - Each save only encodes the elements evaluated since the previous one,
+//     but the store receives the whole checkpoint; thus, a save costs time
+//     proportional to the progress so far, and a small every makes the whole
+//     evaluation quadratic in the size of the batch for stores that write
+//     everything, such as FileCheckpointStore.
+func EvaluateWithCheckpoint[T, O any](batch []T, f EvalOneFunc[T, O], store CheckpointStore, every int) ([]*SimpleHelper[O], error) {
+	if f == nil {
+		return nil, gcers.NewErrNilParameter("f")
+	} else if store == nil {
+		return nil, gcers.NewErrNilParameter("store")
+	}
+
+	cs, err := load_checkpoint[O](store, len(batch))
+	if err != nil {
+		return nil, err
+	}
+
+	reasons := make([]error, len(batch))
+
+	// The entries loaded from the store must be saved again; thus, they do
+	// not count as new evaluations.
+	loaded := len(cs.unsaved)
+
+	for i, elem := range batch {
+		if cs.done[i] {
+			continue
+		}
+
+		res, err := f(elem)
+
+		cs.record(i, res, err)
+		reasons[i] = err
+
+		if every > 0 && len(cs.unsaved)-loaded >= every {
+			err := cs.save(store)
+			if err != nil {
+				return nil, err
+			}
+
+			loaded = 0
+		}
+	}
+
+	if len(cs.unsaved) > loaded || every <= 0 {
+		err := cs.save(store)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	solutions := make([]*SimpleHelper[O], 0, len(batch))
+
+	for i, reason := range reasons {
+		if reason == nil && cs.failed[i] {
+			reason = errors.New(cs.reasons[i])
+		}
+
+		solutions = append(solutions, NewSimpleHelper(cs.results[i], reason))
+	}
+
+	return solutions, nil
+}
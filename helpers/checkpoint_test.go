@@ -0,0 +1,122 @@
+package helpers
+
+import (
+	"errors"
+	"testing"
+)
+
+// limited_store is a CheckpointStore that fails once it saved a given number
+// of checkpoints, to simulate an interruption.
+type limited_store struct {
+	*MemoryCheckpointStore
+
+	// left is the number of saves that still succeed.
+	left int
+}
+
+func (s *limited_store) Save(data []byte) error {
+	if s.left == 0 {
+		return errors.New("interrupted")
+	}
+
+	s.left--
+
+	return s.MemoryCheckpointStore.Save(data)
+}
+
+func TestEvaluateWithCheckpoint(t *testing.T) {
+	var evaluated []int
+
+	f := func(elem int) (*int, error) {
+		evaluated = append(evaluated, elem)
+
+		if elem%2 != 0 {
+			return nil, errors.New("odd")
+		}
+
+		res := elem * 10
+
+		return &res, nil
+	}
+
+	batch := []int{2, 3, 4, 5, 6}
+
+	mem := &MemoryCheckpointStore{}
+
+	_, err := EvaluateWithCheckpoint(batch, f, &limited_store{MemoryCheckpointStore: mem, left: 3}, 1)
+	if err == nil {
+		t.Fatalf("EvaluateWithCheckpoint() error = nil, want an interruption")
+	}
+
+	evaluated = nil
+
+	got, err := EvaluateWithCheckpoint(batch, f, mem, 1)
+	if err != nil {
+		t.Fatalf("EvaluateWithCheckpoint() error = %v", err)
+	}
+
+	if len(evaluated) != 2 || evaluated[0] != 5 || evaluated[1] != 6 {
+		t.Errorf("resume evaluated %v, want [5 6]", evaluated)
+	}
+
+	if len(got) != len(batch) {
+		t.Fatalf("EvaluateWithCheckpoint() returned %d results, want %d", len(got), len(batch))
+	}
+
+	for i, elem := range batch {
+		res, err := got[i].Data()
+
+		if elem%2 != 0 {
+			if res != nil || err == nil || err.Error() != "odd" {
+				t.Errorf("result %d = (%v, %v), want (nil, odd)", i, res, err)
+			}
+
+			continue
+		}
+
+		if err != nil || res == nil || *res != elem*10 {
+			t.Errorf("result %d = (%v, %v), want %d", i, res, err, elem*10)
+		}
+	}
+
+	_, err = EvaluateWithCheckpoint(batch[:3], f, mem, 1)
+	if err == nil {
+		t.Errorf("EvaluateWithCheckpoint() error = nil, want a size mismatch")
+	}
+}
+
+func TestEvaluateWithCheckpointEmptyError(t *testing.T) {
+	f := func(elem int) (int, error) {
+		if elem == 0 {
+			return 0, errors.New("")
+		}
+
+		return elem, nil
+	}
+
+	batch := []int{0, 1, 2}
+
+	mem := &MemoryCheckpointStore{}
+
+	_, err := EvaluateWithCheckpoint(batch, f, &limited_store{MemoryCheckpointStore: mem, left: 2}, 1)
+	if err == nil {
+		t.Fatalf("EvaluateWithCheckpoint() error = nil, want an interruption")
+	}
+
+	got, err := EvaluateWithCheckpoint(batch, f, mem, 1)
+	if err != nil {
+		t.Fatalf("EvaluateWithCheckpoint() error = %v", err)
+	}
+
+	_, err = got[0].Data()
+	if err == nil {
+		t.Errorf("result 0 restored as a success, want a failure")
+	}
+
+	for i := 1; i < len(batch); i++ {
+		res, err := got[i].Data()
+		if err != nil || res != i {
+			t.Errorf("result %d = (%d, %v), want %d", i, res, err, i)
+		}
+	}
+}
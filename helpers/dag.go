@@ -0,0 +1,237 @@
+package helpers
+
+import (
+	"sync"
+
+	gcers "github.com/PlayerR9/go-commons/errors"
+	gcint "github.com/PlayerR9/go-commons/ints"
+)
+
+// DepsFunc is a function that returns the indices, within the batch, of the
+// elements that an element depends on.
+//
+// Parameters:
+//   - elem: The element.
+//
+// Returns:
+//   - []int: The indices of the dependencies.
+type DepsFunc[T any] func(elem T) []int
+
+// plan_dag is a helper function that sorts the elements of a batch
+// topologically and groups them in levels: the elements of a level only depend
+// on elements of previous levels.
+//
+// Parameters:
+//   - batch: The batch.
+//   - deps: The dependency function. If nil, no element has dependencies.
+//
+// Returns:
+//   - [][]int: The levels.
+//   - [][]int: The dependencies of each element.
+//   - error: An error if the dependencies are invalid.
+//
+// Errors:
+//   - *ints.ErrAt: If a dependency index is out of range. Its index is the
+//     1-based position of the element in the batch.
+//   - *ErrDependencyCycle: If the dependencies form a cycle.
+func plan_dag[T any](batch []T, deps DepsFunc[T]) ([][]int, [][]int, error) {
+	reqs := make([][]int, len(batch))
+	dependents := make([][]int, len(batch))
+	indegree := make([]int, len(batch))
+
+	if deps != nil {
+		for i, elem := range batch {
+			for _, dep := range deps(elem) {
+				if dep < 0 || dep >= len(batch) {
+					return nil, nil, gcint.NewErrAt(i+1, "element", gcint.NewErrOutOfBounds(dep, 0, len(batch)))
+				}
+
+				reqs[i] = append(reqs[i], dep)
+				dependents[dep] = append(dependents[dep], i)
+				indegree[i]++
+			}
+		}
+	}
+
+	var level []int
+
+	for i, count := range indegree {
+		if count == 0 {
+			level = append(level, i)
+		}
+	}
+
+	var levels [][]int
+
+	done := 0
+
+	for len(level) > 0 {
+		levels = append(levels, level)
+		done += len(level)
+
+		var next []int
+
+		for _, idx := range level {
+			for _, dependent := range dependents[idx] {
+				indegree[dependent]--
+
+				if indegree[dependent] == 0 {
+					next = append(next, dependent)
+				}
+			}
+		}
+
+		level = next
+	}
+
+	if done < len(batch) {
+		var stuck []int
+
+		for i, count := range indegree {
+			if count > 0 {
+				stuck = append(stuck, i)
+			}
+		}
+
+		return nil, nil, NewErrDependencyCycle(stuck)
+	}
+
+	return levels, reqs, nil
+}
+
+// evaluate_node is a helper function that evaluates one element of a batch
+// unless one of its dependencies failed.
+//
+// Parameters:
+//   - elem: The element.
+//   - reqs: The indices of its dependencies.
+//   - results: The results evaluated so far.
+//   - f: The evaluation function.
+//
+// Returns:
+//   - *SimpleHelper[O]: The result. Never nil.
+//
+// Assertions:
+//   - All the dependencies have been evaluated.
+//   - f != nil
+func evaluate_node[T, O any](elem T, reqs []int, results []*SimpleHelper[O], f EvalOneFunc[T, O]) *SimpleHelper[O] {
+	for _, dep := range reqs {
+		_, err := results[dep].Data()
+		if err != nil {
+			return NewSimpleHelper(*new(O), NewErrDependencyFailed(dep, err))
+		}
+	}
+
+	res, err := f(elem)
+
+	return NewSimpleHelper(res, err)
+}
+
+// EvaluateDAG evaluates a batch whose elements depend on each other,
+// evaluating each element only after all its dependencies.
+//
+// Parameters:
+//   - batch: The slice of elements.
+//   - deps: The dependency function. If nil, the elements are independent.
+//   - f: The evaluation function.
+//
+// Returns:
+//   - []*SimpleHelper[O]: The results, where the i-th result belongs to the
+//     i-th element of the batch.
+//   - error: An error if the batch could not be ordered.
+//
+// Errors:
+//   - *errors.ErrInvalidParameter: If f is nil.
+//   - *ints.ErrAt: If a dependency index is out of range. Its index is the
+//     1-based position of the element in the batch.
+//   - *ErrDependencyCycle: If the dependencies form a cycle.
+//
+// Behaviors:
+//   - If a dependency fails, f is not called on its dependents; their result
+//     holds an *ErrDependencyFailed instead. This propagates transitively.
+//   - Nothing is evaluated if an error is returned.
+func EvaluateDAG[T, O any](batch []T, deps DepsFunc[T], f EvalOneFunc[T, O]) ([]*SimpleHelper[O], error) {
+	if f == nil {
+		return nil, gcers.NewErrNilParameter("f")
+	}
+
+	levels, reqs, err := plan_dag(batch, deps)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]*SimpleHelper[O], len(batch))
+
+	for _, level := range levels {
+		for _, idx := range level {
+			results[idx] = evaluate_node(batch[idx], reqs[idx], results, f)
+		}
+	}
+
+	return results, nil
+}
+
+// EvaluateDAGParallel is like EvaluateDAG but evaluates the independent
+// elements of each level concurrently.
+//
+// Parameters:
+//   - batch: The slice of elements.
+//   - deps: The dependency function. If nil, the elements are independent.
+//   - f: The evaluation function. It must be safe for concurrent use.
+//   - workers: The maximum number of concurrent evaluations. If not positive,
+//     there is no limit.
+//
+// Returns:
+//   - []*SimpleHelper[O]: The results, where the i-th result belongs to the
+//     i-th element of the batch.
+//   - error: An error if the batch could not be ordered.
+//
+// Errors:
+//   - *errors.ErrInvalidParameter: If f is nil.
+//   - *ints.ErrAt: If a dependency index is out of range. Its index is the
+//     1-based position of the element in the batch.
+//   - *ErrDependencyCycle: If the dependencies form a cycle.
+func EvaluateDAGParallel[T, O any](batch []T, deps DepsFunc[T], f EvalOneFunc[T, O], workers int) ([]*SimpleHelper[O], error) {
+	if f == nil {
+		return nil, gcers.NewErrNilParameter("f")
+	}
+
+	levels, reqs, err := plan_dag(batch, deps)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]*SimpleHelper[O], len(batch))
+
+	var sem chan struct{}
+
+	if workers > 0 {
+		sem = make(chan struct{}, workers)
+	}
+
+	for _, level := range levels {
+		var wg sync.WaitGroup
+
+		for _, idx := range level {
+			wg.Add(1)
+
+			if sem != nil {
+				sem <- struct{}{}
+			}
+
+			go func(idx int) {
+				defer wg.Done()
+
+				results[idx] = evaluate_node(batch[idx], reqs[idx], results, f)
+
+				if sem != nil {
+					<-sem
+				}
+			}(idx)
+		}
+
+		wg.Wait()
+	}
+
+	return results, nil
+}
@@ -0,0 +1,153 @@
+package helpers
+
+import (
+	"errors"
+	"slices"
+	"sync"
+	"testing"
+
+	gcint "github.com/PlayerR9/go-commons/ints"
+)
+
+// dag_node is an element of the batches of the DAG tests.
+type dag_node struct {
+	// id is the index of the node in its batch.
+	id int
+
+	// deps are the indices of the dependencies.
+	deps []int
+
+	// fail is true if the evaluation of the node fails.
+	fail bool
+}
+
+// dag_evaluator is EvaluateDAG or EvaluateDAGParallel.
+type dag_evaluator func(batch []dag_node, deps DepsFunc[dag_node], f EvalOneFunc[dag_node, int]) ([]*SimpleHelper[int], error)
+
+// dag_evaluators returns the evaluators under test, by name.
+func dag_evaluators() map[string]dag_evaluator {
+	parallel := func(workers int) dag_evaluator {
+		return func(batch []dag_node, deps DepsFunc[dag_node], f EvalOneFunc[dag_node, int]) ([]*SimpleHelper[int], error) {
+			return EvaluateDAGParallel(batch, deps, f, workers)
+		}
+	}
+
+	return map[string]dag_evaluator{
+		"EvaluateDAG":                    EvaluateDAG[dag_node, int],
+		"EvaluateDAGParallel":            parallel(0),
+		"EvaluateDAGParallel(workers=1)": parallel(1),
+		"EvaluateDAGParallel(workers=2)": parallel(2),
+	}
+}
+
+func dag_deps(node dag_node) []int {
+	return node.deps
+}
+
+func TestEvaluateDAG(t *testing.T) {
+	// 0 and 1 are roots; 2 fails; 4 depends on 2 and 5 on 4.
+	batch := []dag_node{
+		{id: 0},
+		{id: 1},
+		{id: 2, deps: []int{0}, fail: true},
+		{id: 3, deps: []int{0, 1}},
+		{id: 4, deps: []int{2, 3}},
+		{id: 5, deps: []int{4}},
+	}
+
+	for name, evaluate := range dag_evaluators() {
+		var mu sync.Mutex
+
+		done := make([]bool, len(batch))
+		var calls []int
+
+		f := func(node dag_node) (int, error) {
+			idx := node.id
+
+			mu.Lock()
+			defer mu.Unlock()
+
+			for _, dep := range node.deps {
+				if !done[dep] {
+					t.Errorf("%s: element %d evaluated before its dependency %d", name, idx, dep)
+				}
+			}
+
+			done[idx] = true
+			calls = append(calls, idx)
+
+			if node.fail {
+				return 0, errors.New("failed")
+			}
+
+			return idx * 10, nil
+		}
+
+		results, err := evaluate(batch, dag_deps, f)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", name, err)
+		}
+
+		slices.Sort(calls)
+
+		if !slices.Equal(calls, []int{0, 1, 2, 3}) {
+			t.Errorf("%s: evaluated %v, want [0 1 2 3]", name, calls)
+		}
+
+		for _, idx := range []int{0, 1, 3} {
+			res, err := results[idx].Data()
+			if err != nil || res != idx*10 {
+				t.Errorf("%s: result %d = (%d, %v), want %d", name, idx, res, err, idx*10)
+			}
+		}
+
+		_, err = results[2].Data()
+		if err == nil || err.Error() != "failed" {
+			t.Errorf("%s: result 2 error = %v, want failed", name, err)
+		}
+
+		for idx, dep := range map[int]int{4: 2, 5: 4} {
+			_, err := results[idx].Data()
+
+			var failed *ErrDependencyFailed
+
+			if !errors.As(err, &failed) || failed.Index != dep {
+				t.Errorf("%s: result %d error = %v, want dependency %d failed", name, idx, err, dep)
+			}
+		}
+	}
+}
+
+func TestEvaluateDAGErrors(t *testing.T) {
+	f := func(node dag_node) (int, error) {
+		t.Errorf("unexpected evaluation of %v", node)
+		return 0, nil
+	}
+
+	for name, evaluate := range dag_evaluators() {
+		cycle := []dag_node{{}, {deps: []int{2}}, {deps: []int{3}}, {deps: []int{1}}, {deps: []int{3}}}
+
+		_, err := evaluate(cycle, dag_deps, f)
+
+		var cycle_err *ErrDependencyCycle
+
+		if !errors.As(err, &cycle_err) || !slices.Equal(cycle_err.Indices, []int{1, 2, 3, 4}) {
+			t.Errorf("%s: error = %v, want a cycle among [1 2 3 4]", name, err)
+		}
+
+		out_of_range := []dag_node{{}, {deps: []int{0}}, {deps: []int{3}}}
+
+		_, err = evaluate(out_of_range, dag_deps, f)
+
+		var at *gcint.ErrAt
+
+		if !errors.As(err, &at) || at.Idx != 3 {
+			t.Errorf("%s: error = %v, want an error at the 3rd element", name, err)
+		}
+
+		_, err = evaluate(out_of_range, dag_deps, nil)
+		if err == nil {
+			t.Errorf("%s: error = nil, want an error for a nil function", name)
+		}
+	}
+}
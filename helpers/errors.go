@@ -0,0 +1,157 @@
+package helpers
+
+import (
+	"strconv"
+	"strings"
+)
+
+// ErrDependencyFailed is an error that is returned when an element is not
+// evaluated because one of its dependencies failed.
+type ErrDependencyFailed struct {
+	// Index is the index of the dependency that failed.
+	Index int
+
+	// Reason is the reason why the dependency failed.
+	Reason error
+}
+
+// Error implements the Unwrapper interface.
+//
+// Message: "dependency {index} failed: {reason}".
+//
+// However, if the reason is nil, the message is "dependency {index} failed"
+// instead.
+func (e *ErrDependencyFailed) Error() string {
+	var builder strings.Builder
+
+	builder.WriteString("dependency ")
+	builder.WriteString(strconv.Itoa(e.Index))
+	builder.WriteString(" failed")
+
+	if e.Reason != nil {
+		builder.WriteString(": ")
+		builder.WriteString(e.Reason.Error())
+	}
+
+	return builder.String()
+}
+
+// Unwrap implements the Unwrapper interface.
+func (e *ErrDependencyFailed) Unwrap() error {
+	return e.Reason
+}
+
+// ChangeReason implements the Unwrapper interface.
+func (e *ErrDependencyFailed) ChangeReason(reason error) {
+	e.Reason = reason
+}
+
+// NewErrDependencyFailed creates a new ErrDependencyFailed error.
+//
+// Parameters:
+//   - index: The index of the dependency that failed.
+//   - reason: The reason why the dependency failed.
+//
+// Returns:
+//   - *ErrDependencyFailed: A pointer to the new error. Never nil.
+func NewErrDependencyFailed(index int, reason error) *ErrDependencyFailed {
+	e := &ErrDependencyFailed{
+		Index:  index,
+		Reason: reason,
+	}
+
+	return e
+}
+
+// ErrWeighted is an error that carries a weight, such as how close a failed
+// evaluation got to succeeding. It lets failures be ranked like successes.
+type ErrWeighted struct {
+	// Reason is the reason for the failure.
+	Reason error
+
+	// Weight is the weight of the failure. The higher the weight, the closer
+	// the evaluation got to succeeding.
+	Weight float64
+}
+
+// Error implements the Unwrapper interface.
+//
+// Message: "{reason}", since the weight is meant for ranking only.
+//
+// However, if the reason is nil, the message is "failure with weight
+// {weight}" instead.
+func (e *ErrWeighted) Error() string {
+	if e.Reason != nil {
+		return e.Reason.Error()
+	}
+
+	return "failure with weight " + strconv.FormatFloat(e.Weight, 'g', -1, 64)
+}
+
+// Unwrap implements the Unwrapper interface.
+func (e *ErrWeighted) Unwrap() error {
+	return e.Reason
+}
+
+// ChangeReason implements the Unwrapper interface.
+func (e *ErrWeighted) ChangeReason(reason error) {
+	e.Reason = reason
+}
+
+// NewErrWeighted creates a new ErrWeighted error.
+//
+// Parameters:
+//   - reason: The reason for the failure.
+//   - weight: The weight of the failure.
+//
+// Returns:
+//   - *ErrWeighted: A pointer to the new error. Never nil.
+func NewErrWeighted(reason error, weight float64) *ErrWeighted {
+	e := &ErrWeighted{
+		Reason: reason,
+		Weight: weight,
+	}
+
+	return e
+}
+
+// ErrDependencyCycle is an error that is returned when the dependencies of a
+// batch form a cycle.
+type ErrDependencyCycle struct {
+	// Indices are the indices of the elements that could not be ordered,
+	// in ascending order.
+	Indices []int
+}
+
+// Error implements the error interface.
+//
+// Message: "dependency cycle among elements {indices}".
+func (e *ErrDependencyCycle) Error() string {
+	values := make([]string, 0, len(e.Indices))
+
+	for _, idx := range e.Indices {
+		values = append(values, strconv.Itoa(idx))
+	}
+
+	var builder strings.Builder
+
+	builder.WriteString("dependency cycle among elements ")
+	builder.WriteString(strings.Join(values, ", "))
+
+	return builder.String()
+}
+
+// NewErrDependencyCycle creates a new ErrDependencyCycle error.
+//
+// Parameters:
+//   - indices: The indices of the elements that could not be ordered.
+//
+// Returns:
+//   - *ErrDependencyCycle: A pointer to the new error. Never nil.
+func NewErrDependencyCycle(indices []int) *ErrDependencyCycle {
+	e := &ErrDependencyCycle{
+		Indices: indices,
+	}
+
+	return e
+}
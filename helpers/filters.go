@@ -1,7 +1,12 @@
 package helpers
 
 import (
+	"errors"
+	"math"
+	"slices"
+
 	lus "github.com/PlayerR9/go-commons/slices"
+	luc "github.com/PlayerR9/lib_units/common"
 )
 
 // EvalOneFunc is a function that evaluates one element.
@@ -110,6 +115,101 @@ func FilterByNegativeWeight[T Helperer[O], O any](S []T) []T {
 	return solution
 }
 
+// WeightStats computes the minimum, maximum and mean weights of the helpers
+// in a single pass.
+//
+// Parameters:
+//   - S: slice of helpers.
+//
+// Returns:
+//   - min: the minimum weight.
+//   - max: the maximum weight.
+//   - mean: the mean weight.
+//
+// Behaviors:
+//   - If S is empty, all the statistics are 0.
+func WeightStats[T Helperer[O], O any](S []T) (min, max, mean float64) {
+	if len(S) == 0 {
+		return
+	}
+
+	min = S[0].Weight()
+	max = min
+
+	var sum float64
+
+	for _, h := range S {
+		weight := h.Weight()
+
+		if weight < min {
+			min = weight
+		} else if weight > max {
+			max = weight
+		}
+
+		sum += weight
+	}
+
+	mean = sum / float64(len(S))
+
+	return
+}
+
+// FilterByWeightPercentile keeps the helpers whose weight is above or below the
+// p-th percentile of the weights.
+//
+// Parameters:
+//   - S: slice of helpers.
+//   - p: the percentile, between 0 and 100. Out of range values are clamped.
+//   - top: if true, the helpers with a weight greater than or equal to the
+//     percentile are kept. Otherwise, the ones with a weight less than or equal
+//     to the percentile are kept.
+//
+// Returns:
+//   - []T: slice of the kept helpers, in their original order.
+//
+// Behaviors:
+//   - If S is empty or p is NaN, the function returns a nil slice.
+//   - The percentile is computed with linear interpolation between the closest
+//     ranks.
+func FilterByWeightPercentile[T Helperer[O], O any](S []T, p float64, top bool) []T {
+	if len(S) == 0 || math.IsNaN(p) {
+		return nil
+	}
+
+	if p < 0 {
+		p = 0
+	} else if p > 100 {
+		p = 100
+	}
+
+	weights := make([]float64, 0, len(S))
+
+	for _, h := range S {
+		weights = append(weights, h.Weight())
+	}
+
+	slices.Sort(weights)
+
+	rank := p / 100 * float64(len(weights)-1)
+	lower := int(math.Floor(rank))
+	upper := int(math.Ceil(rank))
+
+	threshold := weights[lower] + (weights[upper]-weights[lower])*(rank-float64(lower))
+
+	var solution []T
+
+	for _, h := range S {
+		weight := h.Weight()
+
+		if (top && weight >= threshold) || (!top && weight <= threshold) {
+			solution = append(solution, h)
+		}
+	}
+
+	return solution
+}
+
 // SuccessOrFail returns the results with the maximum weight.
 //
 // Parameters:
@@ -124,8 +224,65 @@ func FilterByNegativeWeight[T Helperer[O], O any](S []T) []T {
 //   - If the slice is empty, the function returns a nil slice and true.
 //   - The result can either be the sucessful results or the original slice.
 //     Nonetheless, the maximum weight is always applied.
+//
+// This is the same as SuccessOrFailWith with FailByWeight.
 func SuccessOrFail[T Helperer[O], O any](batch []T, useMax bool) ([]T, bool) {
-	// 1. Remove nil elements.
+	return SuccessOrFailWith[T, O](batch, useMax, FailByWeight)
+}
+
+// FailureMode is how SuccessOrFailWith ranks the failures when there are no
+// successes.
+type FailureMode int
+
+const (
+	// FailByWeight ranks the failures by the weights of their helpers, as the
+	// successes are.
+	FailByWeight FailureMode = iota
+
+	// FailByErrorWeight ranks the failures by the weights of their errors,
+	// highest first; see FailureWeight. Failures whose error has no weight
+	// rank below the others.
+	FailByErrorWeight
+)
+
+// FailureWeight returns the weight of a failure.
+//
+// Parameters:
+//   - err: The error of the failure.
+//
+// Returns:
+//   - float64: The weight of the outermost *ErrWeighted in the error chain.
+//   - bool: True if there is one, false otherwise.
+func FailureWeight(err error) (float64, bool) {
+	var target *ErrWeighted
+
+	ok := errors.As(err, &target)
+	if !ok {
+		return 0, false
+	}
+
+	return target.Weight, true
+}
+
+// SuccessOrFailWith is like SuccessOrFail but lets the failures be ranked
+// differently from the successes.
+//
+// Parameters:
+//   - batch: The slice of results.
+//   - useMax: True if the maximum weight should be used, false otherwise.
+//   - mode: How the failures are ranked when there are no successes.
+//
+// Returns:
+//   - []T: The best successes or, if there are none, the best failures.
+//   - bool: True if the results are successes, false otherwise.
+//
+// Behaviors:
+//   - If the slice is empty, the function returns a nil slice and true.
+//   - With FailByErrorWeight, useMax only applies to the successes; the
+//     failures that got the closest to succeeding are returned, and only
+//     them, whatever the weights of their helpers.
+//   - Ties are all returned, in their order in the batch.
+func SuccessOrFailWith[T Helperer[O], O any](batch []T, useMax bool, mode FailureMode) ([]T, bool) {
 	if len(batch) == 0 {
 		return nil, true
 	}
@@ -135,6 +292,10 @@ func SuccessOrFail[T Helperer[O], O any](batch []T, useMax bool) ([]T, bool) {
 	var target, solution []T
 
 	if len(success) == 0 {
+		if mode == FailByErrorWeight {
+			return filter_by_error_weight[T, O](fail), false
+		}
+
 		target = fail
 	} else {
 		target = success
@@ -149,6 +310,40 @@ func SuccessOrFail[T Helperer[O], O any](batch []T, useMax bool) ([]T, bool) {
 	return solution, len(success) > 0
 }
 
+// filter_by_error_weight returns the failures with the highest error weight.
+//
+// Parameters:
+//   - fail: The failures.
+//
+// Returns:
+//   - []T: The failures with the highest error weight, or every failure if
+//     none has one. Nil if fail is empty.
+func filter_by_error_weight[T Helperer[O], O any](fail []T) []T {
+	var solution []T
+
+	best := math.Inf(-1)
+
+	for _, h := range fail {
+		_, err := h.Data()
+
+		weight, ok := FailureWeight(err)
+		if !ok {
+			weight = math.Inf(-1)
+		}
+
+		if weight > best {
+			best = weight
+			solution = solution[:0]
+		}
+
+		if weight == best {
+			solution = append(solution, h)
+		}
+	}
+
+	return solution
+}
+
 // EvaluateSimpleHelpers is a function that evaluates a batch of helpers and returns
 // the results.
 //
@@ -162,6 +357,8 @@ func SuccessOrFail[T Helperer[O], O any](batch []T, useMax bool) ([]T, bool) {
 //
 // Behaviors:
 //   - This function returns either the successful results or the original slice.
+//   - A panic in f is recovered and recorded as a failed result with an
+//     *common.ErrPanic.
 func EvaluateSimpleHelpers[T, O any](batch []T, f EvalOneFunc[T, O]) ([]*SimpleHelper[O], bool) {
 	if len(batch) == 0 || f == nil {
 		return nil, true
@@ -170,7 +367,14 @@ func EvaluateSimpleHelpers[T, O any](batch []T, f EvalOneFunc[T, O]) ([]*SimpleH
 	solutions := make([]*SimpleHelper[O], 0, len(batch))
 
 	for _, h := range batch {
-		res, err := f(h)
+		var res O
+
+		err := luc.Guard("EvaluateSimpleHelpers", func() error {
+			var err error
+
+			res, err = f(h)
+			return err
+		})
 
 		helper := NewSimpleHelper(res, err)
 		solutions = append(solutions, helper)
@@ -204,6 +408,8 @@ func EvaluateSimpleHelpers[T, O any](batch []T, f EvalOneFunc[T, O]) ([]*SimpleH
 //
 // Behaviors:
 //   - This function returns either the successful results or the original slice.
+//   - A panic in f is recovered and recorded as a failed result with an
+//     *common.ErrPanic.
 func EvaluateWeightHelpers[T, O any](batch []T, f EvalOneFunc[T, O], wf WeightFunc[T], useMax bool) ([]*WeightedHelper[O], bool) {
 	if len(batch) == 0 || f == nil || wf == nil {
 		return nil, true
@@ -212,7 +418,14 @@ func EvaluateWeightHelpers[T, O any](batch []T, f EvalOneFunc[T, O], wf WeightFu
 	solutions := make([]*WeightedHelper[O], 0, len(batch))
 
 	for _, h := range batch {
-		res, err := f(h)
+		var res O
+
+		err := luc.Guard("EvaluateWeightHelpers", func() error {
+			var err error
+
+			res, err = f(h)
+			return err
+		})
 
 		weight, ok := wf(h)
 		if !ok {
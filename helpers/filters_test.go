@@ -0,0 +1,34 @@
+package helpers
+
+import (
+	"math"
+	"testing"
+)
+
+func TestFilterByWeightPercentile(t *testing.T) {
+	var batch []*WeightedHelper[int]
+
+	for i := 1; i <= 5; i++ {
+		batch = append(batch, NewWeightedHelper(i, nil, float64(i)))
+	}
+
+	tests := []struct {
+		p    float64
+		top  bool
+		want int
+	}{
+		{50, true, 3},
+		{50, false, 3},
+		{-10, true, 5},
+		{math.Inf(1), true, 1},
+		{math.NaN(), true, 0},
+	}
+
+	for _, test := range tests {
+		got := FilterByWeightPercentile(batch, test.p, test.top)
+
+		if len(got) != test.want {
+			t.Errorf("FilterByWeightPercentile(p=%v, top=%v) kept %d helpers, want %d", test.p, test.top, len(got), test.want)
+		}
+	}
+}
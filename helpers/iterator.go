@@ -0,0 +1,81 @@
+package helpers
+
+import (
+	luc "github.com/PlayerR9/lib_units/common"
+)
+
+// batch_iterable is an iterable over a batch of helpers.
+type batch_iterable[T any] struct {
+	// batch is the batch of helpers.
+	batch []T
+}
+
+// Iterator implements the common.Iterable interface.
+func (bi *batch_iterable[T]) Iterator() luc.Iterater[T] {
+	return luc.NewSimpleIterator(bi.batch)
+}
+
+// BatchIterable exposes a batch of helpers as a common.Iterable.
+//
+// Parameters:
+//   - S: slice of helpers.
+//
+// Returns:
+//   - common.Iterable[T]: The iterable over the helpers. Never nil.
+//
+// The slice is not copied; thus, changes to it are visible to the iterators.
+func BatchIterable[T Helperer[O], O any](S []T) luc.Iterable[T] {
+	bi := &batch_iterable[T]{
+		batch: S,
+	}
+
+	return bi
+}
+
+// results_iterator is an iterator over the results of a batch of helpers.
+type results_iterator[T Helperer[O], O any] struct {
+	// batch is the batch of helpers.
+	batch []T
+
+	// index is the index of the next helper to consume.
+	index int
+}
+
+// Consume implements the common.Iterater interface.
+func (ri *results_iterator[T, O]) Consume() (O, error) {
+	if ri.index >= len(ri.batch) {
+		return *new(O), luc.NewErrExhaustedIter()
+	}
+
+	h := ri.batch[ri.index]
+	ri.index++
+
+	data, _ := h.Data()
+
+	return data, nil
+}
+
+// Restart implements the common.Iterater interface.
+func (ri *results_iterator[T, O]) Restart() {
+	ri.index = 0
+}
+
+// ResultsIterator returns an iterator over the results of the helpers. Like
+// ExtractResults, but the results are extracted lazily.
+//
+// Parameters:
+//   - S: slice of helpers.
+//
+// Returns:
+//   - common.Iterater[O]: The iterator over the results. Never nil.
+//
+// Behaviors:
+//   - The results are returned regardless of whether the helper is successful or not.
+func ResultsIterator[T Helperer[O], O any](S []T) luc.Iterater[O] {
+	ri := &results_iterator[T, O]{
+		batch: S,
+		index: 0,
+	}
+
+	return ri
+}
@@ -0,0 +1,52 @@
+package helpers
+
+import (
+	"github.com/PlayerR9/lib_units/pair"
+)
+
+// FromPair turns the result of a Go function call into a helper.
+//
+// Parameters:
+//   - value: The result of the call.
+//   - err: The error of the call.
+//
+// Returns:
+//   - Helperer[O]: The helper, of weight 0. Never nil.
+//
+// Example:
+//
+//	h := FromPair(strconv.Atoi(s))
+func FromPair[O any](value O, err error) Helperer[O] {
+	return NewSimpleHelper(value, err)
+}
+
+// FromPairValue turns a pair of a result and an error into a helper.
+//
+// Parameters:
+//   - p: The pair.
+//
+// Returns:
+//   - Helperer[O]: The helper, of weight 0. Never nil.
+func FromPairValue[O any](p pair.Pair[O, error]) Helperer[O] {
+	return NewSimpleHelper(p.First, p.Second)
+}
+
+// ToPair turns a helper into a pair of its result and its error.
+//
+// Parameters:
+//   - h: The helper.
+//
+// Returns:
+//   - pair.Pair[O, error]: The pair. The zero value and a nil error if h is
+//     nil.
+//
+// The weight of the helper is lost.
+func ToPair[O any](h Helperer[O]) pair.Pair[O, error] {
+	if h == nil {
+		return pair.Pair[O, error]{}
+	}
+
+	value, err := h.Data()
+
+	return pair.NewPair(value, err)
+}
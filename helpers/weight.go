@@ -79,3 +79,71 @@ func NewWeightedElement[O any](elem O, weight float64) *WeightedElement[O] {
 
 	return we
 }
+
+// ToWeightedHelpers converts weighted elements into successful weighted helpers
+// with the same elements and weights.
+//
+// Parameters:
+//   - elems: slice of weighted elements.
+//
+// Returns:
+//   - []*WeightedHelper[O]: slice of weighted helpers. Nil if elems is empty.
+//
+// Behaviors:
+//   - Nil elements are skipped.
+func ToWeightedHelpers[O any](elems []*WeightedElement[O]) []*WeightedHelper[O] {
+	if len(elems) == 0 {
+		return nil
+	}
+
+	helpers := make([]*WeightedHelper[O], 0, len(elems))
+
+	for _, we := range elems {
+		if we == nil {
+			continue
+		}
+
+		h := NewWeightedHelper(we.elem, nil, we.weight)
+		helpers = append(helpers, h)
+	}
+
+	return helpers
+}
+
+// WeighAndEvaluate applies the weight function and the evaluation function to
+// each element in a single pass.
+//
+// Parameters:
+//   - S: slice of elements.
+//   - wf: the weight function.
+//   - f: the evaluation function.
+//
+// Returns:
+//   - []*WeightedHelper[O]: slice of weighted helpers. Nil if S is empty or either
+//     function is nil.
+//
+// Behaviors:
+//   - If the weight function returns false, the element is neither evaluated nor
+//     included in the result.
+//   - Unlike EvaluateWeightHelpers, no filtering is applied to the results.
+func WeighAndEvaluate[T, O any](S []T, wf WeightFunc[T], f EvalOneFunc[T, O]) []*WeightedHelper[O] {
+	if len(S) == 0 || wf == nil || f == nil {
+		return nil
+	}
+
+	var helpers []*WeightedHelper[O]
+
+	for _, e := range S {
+		weight, ok := wf(e)
+		if !ok {
+			continue
+		}
+
+		res, err := f(e)
+
+		h := NewWeightedHelper(res, err, weight)
+		helpers = append(helpers, h)
+	}
+
+	return helpers
+}
@@ -0,0 +1,176 @@
+// Package tokenizer holds the walk shared by the Tokenizers of the bytes and
+// strings packages.
+package tokenizer
+
+import (
+	"errors"
+
+	gcers "github.com/PlayerR9/go-commons/errors"
+)
+
+// Pair is a pair of tokens that open and close a region.
+type Pair[T any] struct {
+	// Open is the token that marks the beginning of a region.
+	Open T
+
+	// Close is the token that marks the end of a region.
+	Close T
+}
+
+// Region is a balanced top-level region.
+type Region struct {
+	// Pair is the index of the delimiters of the region.
+	Pair int
+
+	// Start is the index of the first token after the opening token.
+	Start int
+
+	// End is the index right after the closing token.
+	End int
+}
+
+// Failure tells why a slice of tokens is not balanced.
+type Failure struct {
+	// Index is the index of the offending token.
+	Index int
+
+	// Pair is the index of the delimiters of the offending token.
+	Pair int
+
+	// NeverOpened is true if the offending token closes a region that was
+	// never opened, and false if it opens a region that is never closed.
+	NeverOpened bool
+}
+
+// open_region is a region whose closing token is not found yet.
+type open_region struct {
+	// index is the index of the opening token.
+	index int
+
+	// pair is the index of the delimiters of the region.
+	pair int
+}
+
+// Check checks that delimiters can be used to walk tokens.
+//
+// Parameters:
+//   - pairs: The delimiters.
+//   - is_empty: Whether a token is empty.
+//   - equal: Whether two tokens are equal.
+//
+// Returns:
+//   - error: An error if the delimiters are invalid.
+//
+// Errors:
+//   - *errors.ErrInvalidParameter: If a token is empty, or if a pair opens
+//     and closes with the same token.
+func Check[T any](pairs []Pair[T], is_empty func(tok T) bool, equal func(a, b T) bool) error {
+	for _, pair := range pairs {
+		if is_empty(pair.Open) || is_empty(pair.Close) {
+			return gcers.NewErrInvalidParameter("pairs", gcers.NewErrEmpty("token"))
+		} else if equal(pair.Open, pair.Close) {
+			return gcers.NewErrInvalidParameter("pairs", errors.New("opening and closing tokens must differ"))
+		}
+	}
+
+	return nil
+}
+
+// pair_of returns the delimiters a token belongs to.
+//
+// Parameters:
+//   - pairs: The delimiters.
+//   - tok: The token.
+//   - equal: Whether two tokens are equal.
+//
+// Returns:
+//   - int: The index of the delimiters. -1 if none.
+//   - bool: True if tok is a closing token, false otherwise.
+//
+// Closing tokens take precedence over opening ones.
+func pair_of[T any](pairs []Pair[T], tok T, equal func(a, b T) bool) (int, bool) {
+	for i, pair := range pairs {
+		if equal(tok, pair.Close) {
+			return i, true
+		}
+	}
+
+	for i, pair := range pairs {
+		if equal(tok, pair.Open) {
+			return i, false
+		}
+	}
+
+	return -1, false
+}
+
+// Regions walks a slice of tokens and returns its balanced top-level regions.
+// Regions of any kind may be nested in each other.
+//
+// Parameters:
+//   - pairs: The delimiters. Assumed to be valid; see Check.
+//   - tokens: The slice of tokens in which to search for the regions.
+//   - newline: The newline token. A last region closed by it may end at the
+//     end of the tokens.
+//   - equal: Whether two tokens are equal.
+//
+// Returns:
+//   - []Region: The regions, in order. Nil if there are none. When the tokens
+//     are not balanced, the regions found before the failure.
+//   - *Failure: Why the tokens are not balanced. Nil if they are.
+//
+// A region still open when an outer region is closed is never closed.
+func Regions[T any](pairs []Pair[T], tokens []T, newline T, equal func(a, b T) bool) ([]Region, *Failure) {
+	var regions []Region
+
+	// stack holds the open regions, innermost last.
+	var stack []open_region
+
+	for i, tok := range tokens {
+		pair, is_close := pair_of(pairs, tok, equal)
+		if pair == -1 {
+			continue
+		}
+
+		if !is_close {
+			stack = append(stack, open_region{index: i, pair: pair})
+			continue
+		}
+
+		depth := len(stack) - 1
+
+		for depth >= 0 && stack[depth].pair != pair {
+			depth--
+		}
+
+		if depth == -1 {
+			return regions, &Failure{Index: i, Pair: pair, NeverOpened: true}
+		}
+
+		if depth != len(stack)-1 {
+			top := stack[len(stack)-1]
+
+			return regions, &Failure{Index: top.index, Pair: top.pair}
+		}
+
+		if depth == 0 {
+			regions = append(regions, Region{Pair: pair, Start: stack[0].index + 1, End: i + 1})
+		}
+
+		stack = stack[:depth]
+	}
+
+	if len(stack) == 0 {
+		return regions, nil
+	}
+
+	top := stack[len(stack)-1]
+
+	if len(stack) == 1 && equal(pairs[top.pair].Close, newline) {
+		regions = append(regions, Region{Pair: top.pair, Start: top.index + 1, End: len(tokens)})
+
+		return regions, nil
+	}
+
+	return regions, &Failure{Index: top.index, Pair: top.pair}
+}
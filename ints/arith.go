@@ -0,0 +1,130 @@
+package ints
+
+import (
+	"math"
+)
+
+// SafeAdd adds two integers and reports whether the result overflowed.
+//
+// Parameters:
+//   - a: The first integer.
+//   - b: The second integer.
+//
+// Returns:
+//   - int: The sum, wrapped around on overflow.
+//   - bool: True if the sum did not overflow, false otherwise.
+func SafeAdd(a, b int) (int, bool) {
+	sum := a + b
+
+	ok := (b >= 0) == (sum >= a)
+
+	return sum, ok
+}
+
+// SatAdd adds two integers, clamping the result to [math.MinInt, math.MaxInt]
+// instead of overflowing.
+//
+// Parameters:
+//   - a: The first integer.
+//   - b: The second integer.
+//
+// Returns:
+//   - int: The saturated sum.
+func SatAdd(a, b int) int {
+	sum, ok := SafeAdd(a, b)
+	if ok {
+		return sum
+	}
+
+	if b > 0 {
+		return math.MaxInt
+	}
+
+	return math.MinInt
+}
+
+// SatSub subtracts b from a, clamping the result to
+// [math.MinInt, math.MaxInt] instead of overflowing.
+//
+// Parameters:
+//   - a: The integer to subtract from.
+//   - b: The integer to subtract.
+//
+// Returns:
+//   - int: The saturated difference.
+func SatSub(a, b int) int {
+	diff := a - b
+
+	ok := (b >= 0) == (diff <= a)
+	if ok {
+		return diff
+	}
+
+	if b < 0 {
+		return math.MaxInt
+	}
+
+	return math.MinInt
+}
+
+// SatMul multiplies two integers, clamping the result to
+// [math.MinInt, math.MaxInt] instead of overflowing.
+//
+// Parameters:
+//   - a: The first integer.
+//   - b: The second integer.
+//
+// Returns:
+//   - int: The saturated product.
+func SatMul(a, b int) int {
+	if a == 0 || b == 0 {
+		return 0
+	}
+
+	prod := a * b
+
+	overflow := prod/b != a || (a == -1 && b == math.MinInt) || (b == -1 && a == math.MinInt)
+	if !overflow {
+		return prod
+	}
+
+	if (a < 0) != (b < 0) {
+		return math.MinInt
+	}
+
+	return math.MaxInt
+}
+
+// WrapAdd adds two integers modulo n, which is how indices into circular
+// buffers and cyclic tables move.
+//
+// Parameters:
+//   - a: The first integer.
+//   - b: The second integer.
+//   - n: The modulus.
+//
+// Returns:
+//   - int: (a + b) mod n, in the range [0, n). 0 if n is not positive.
+//
+// The result is exact even when a + b would overflow.
+func WrapAdd(a, b, n int) int {
+	if n <= 0 {
+		return 0
+	}
+
+	ra := a % n
+	if ra < 0 {
+		ra += n
+	}
+
+	rb := b % n
+	if rb < 0 {
+		rb += n
+	}
+
+	if ra >= n-rb {
+		return ra - (n - rb)
+	}
+
+	return ra + rb
+}
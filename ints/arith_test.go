@@ -0,0 +1,45 @@
+package ints
+
+import (
+	"math"
+	"testing"
+)
+
+func TestSaturating(t *testing.T) {
+	tests := []struct {
+		name string
+		got  int
+		want int
+	}{
+		{"add overflow", SatAdd(math.MaxInt, 1), math.MaxInt},
+		{"add underflow", SatAdd(math.MinInt, -1), math.MinInt},
+		{"add", SatAdd(2, 3), 5},
+		{"sub overflow", SatSub(math.MaxInt, -1), math.MaxInt},
+		{"sub underflow", SatSub(math.MinInt, 1), math.MinInt},
+		{"sub", SatSub(2, 3), -1},
+		{"mul overflow", SatMul(math.MaxInt/2+1, 2), math.MaxInt},
+		{"mul underflow", SatMul(math.MaxInt, -2), math.MinInt},
+		{"mul min by -1", SatMul(math.MinInt, -1), math.MaxInt},
+		{"mul", SatMul(-4, 5), -20},
+	}
+
+	for _, test := range tests {
+		if test.got != test.want {
+			t.Errorf("%s: expected %d, got %d", test.name, test.want, test.got)
+		}
+	}
+}
+
+func TestWrapAdd(t *testing.T) {
+	if got := WrapAdd(3, 4, 5); got != 2 {
+		t.Errorf("expected 2, got %d", got)
+	}
+
+	if got := WrapAdd(0, -1, 5); got != 4 {
+		t.Errorf("expected 4, got %d", got)
+	}
+
+	if got := WrapAdd(math.MaxInt-1, math.MaxInt-1, math.MaxInt); got != math.MaxInt-2 {
+		t.Errorf("expected %d, got %d", math.MaxInt-2, got)
+	}
+}
@@ -0,0 +1,203 @@
+package maps
+
+import (
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
+	"errors"
+	"reflect"
+)
+
+// key_to_string is a helper function that converts a key to the string used
+// as the name of a JSON object member.
+//
+// Parameters:
+//   - key: The key to convert.
+//
+// Returns:
+//   - string: The member name.
+//   - error: An error if the key cannot be represented in JSON (e.g., NaN).
+func key_to_string[K comparable](key K) (string, error) {
+	rv := reflect.ValueOf(key)
+	if rv.Kind() == reflect.String {
+		return rv.String(), nil
+	}
+
+	data, err := json.Marshal(key)
+	if err != nil {
+		return "", err
+	}
+
+	return string(data), nil
+}
+
+// string_to_key is a helper function that converts the name of a JSON object
+// member back to a key.
+//
+// Parameters:
+//   - str: The member name.
+//
+// Returns:
+//   - K: The key.
+//   - error: An error if the name is not a valid key.
+func string_to_key[K comparable](str string) (K, error) {
+	var key K
+
+	rv := reflect.ValueOf(&key).Elem()
+	if rv.Kind() == reflect.String {
+		rv.SetString(str)
+
+		return key, nil
+	}
+
+	err := json.Unmarshal([]byte(str), &key)
+	if err != nil {
+		return key, err
+	}
+
+	return key, nil
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+//
+// The map is encoded as a JSON object whose members appear in ascending key
+// order. Non-string keys are written as their JSON representation (e.g.,
+// the number 12 becomes the member name "12").
+func (om *OrderedMap[K, V]) MarshalJSON() ([]byte, error) {
+	om.Reorder()
+
+	var buf bytes.Buffer
+
+	buf.WriteByte('{')
+
+	for i, key := range om.keys {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+
+		name, err := key_to_string(key)
+		if err != nil {
+			return nil, err
+		}
+
+		data, err := json.Marshal(name)
+		if err != nil {
+			return nil, err
+		}
+
+		buf.Write(data)
+		buf.WriteByte(':')
+
+		data, err = json.Marshal(om.values[key])
+		if err != nil {
+			return nil, err
+		}
+
+		buf.Write(data)
+	}
+
+	buf.WriteByte('}')
+
+	return buf.Bytes(), nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+//
+// The previous contents of the map are discarded. A JSON null yields an empty
+// map.
+func (om *OrderedMap[K, V]) UnmarshalJSON(data []byte) error {
+	var members map[string]json.RawMessage
+
+	err := json.Unmarshal(data, &members)
+	if err != nil {
+		return err
+	}
+
+	om.values = make(map[K]V, len(members))
+	om.keys = make([]K, 0, len(members))
+	om.dirty = false
+
+	for name, raw := range members {
+		key, err := string_to_key[K](name)
+		if err != nil {
+			return err
+		}
+
+		var value V
+
+		err = json.Unmarshal(raw, &value)
+		if err != nil {
+			return err
+		}
+
+		om.AddUnsorted(key, value)
+	}
+
+	om.Reorder()
+
+	return nil
+}
+
+// GobEncode implements the gob.GobEncoder interface.
+func (om *OrderedMap[K, V]) GobEncode() ([]byte, error) {
+	om.Reorder()
+
+	values := make([]V, 0, len(om.keys))
+
+	for _, key := range om.keys {
+		values = append(values, om.values[key])
+	}
+
+	var buf bytes.Buffer
+
+	enc := gob.NewEncoder(&buf)
+
+	err := enc.Encode(om.keys)
+	if err != nil {
+		return nil, err
+	}
+
+	err = enc.Encode(values)
+	if err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// GobDecode implements the gob.GobDecoder interface.
+//
+// The previous contents of the map are discarded.
+func (om *OrderedMap[K, V]) GobDecode(data []byte) error {
+	dec := gob.NewDecoder(bytes.NewReader(data))
+
+	var keys []K
+
+	err := dec.Decode(&keys)
+	if err != nil {
+		return err
+	}
+
+	var values []V
+
+	err = dec.Decode(&values)
+	if err != nil {
+		return err
+	}
+
+	if len(keys) != len(values) {
+		return errors.New("number of keys and values do not match")
+	}
+
+	om.values = make(map[K]V, len(keys))
+	om.keys = make([]K, 0, len(keys))
+	om.dirty = false
+
+	for i, key := range keys {
+		om.AddUnsorted(key, values[i])
+	}
+
+	om.Reorder()
+
+	return nil
+}
@@ -0,0 +1,89 @@
+package maps
+
+import (
+	"cmp"
+	"slices"
+
+	luc "github.com/PlayerR9/lib_units/common"
+)
+
+// OMIterator is an iterator over the entries of an OrderedMap.
+type OMIterator[K cmp.Ordered, V any] struct {
+	// om is the map being iterated over.
+	om *OrderedMap[K, V]
+
+	// keys is the sorted list of keys to iterate over.
+	keys []K
+
+	// index is the index of the next key to consume.
+	index int
+
+	// pred is the predicate that entries must satisfy. Nil if all the entries
+	// are accepted.
+	pred func(K, V) bool
+}
+
+// Consume implements the common.Iterater interface.
+//
+// Keys that were deleted from the map after the iterator was created, as well
+// as entries that do not satisfy the predicate, are skipped.
+func (it *OMIterator[K, V]) Consume() (*Entry[K, V], error) {
+	entry, err := it.Peek()
+	if err != nil {
+		return nil, err
+	}
+
+	it.index++
+
+	return entry, nil
+}
+
+// Peek implements the common.Peeker interface.
+//
+// Entries that would be skipped by Consume are skipped here too.
+func (it *OMIterator[K, V]) Peek() (*Entry[K, V], error) {
+	for ; it.index < len(it.keys); it.index++ {
+		key := it.keys[it.index]
+
+		value, ok := it.om.values[key]
+		if !ok {
+			continue
+		}
+
+		if it.pred == nil || it.pred(key, value) {
+			return NewEntry(key, value), nil
+		}
+	}
+
+	return nil, luc.NewErrExhaustedIter()
+}
+
+// Restart implements the common.Iterater interface.
+func (it *OMIterator[K, V]) Restart() {
+	it.index = 0
+}
+
+// new_om_iterator creates a new OMIterator over the given keys of the map.
+//
+// Parameters:
+//   - om: The map to iterate over.
+//   - keys: The sorted keys to iterate over.
+//
+// Returns:
+//   - *OMIterator[K, V]: The new iterator. Never nil.
+//
+// The keys are copied so that later modifications of the map do not shift
+// the iteration.
+//
+// Assertions:
+//   - om != nil
+func new_om_iterator[K cmp.Ordered, V any](om *OrderedMap[K, V], keys []K) *OMIterator[K, V] {
+	it := &OMIterator[K, V]{
+		om:    om,
+		keys:  slices.Clone(keys),
+		index: 0,
+		pred:  nil,
+	}
+
+	return it
+}
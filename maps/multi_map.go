@@ -0,0 +1,137 @@
+package maps
+
+import (
+	"cmp"
+	"slices"
+
+	luc "github.com/PlayerR9/lib_units/common"
+)
+
+// MultiMap is a map that associates several values with each key and keeps
+// the keys sorted in ascending order.
+type MultiMap[K cmp.Ordered, V any] struct {
+	// om is the underlying map.
+	om *OrderedMap[K, []V]
+}
+
+// NewMultiMap creates a new, empty MultiMap.
+//
+// Returns:
+//   - *MultiMap[K, V]: The new map. Never nil.
+func NewMultiMap[K cmp.Ordered, V any]() *MultiMap[K, V] {
+	mm := &MultiMap[K, V]{
+		om: NewOrderedMap[K, []V](),
+	}
+
+	return mm
+}
+
+// Add appends a value to the values of the key.
+//
+// Parameters:
+//   - key: The key.
+//   - value: The value to append.
+func (mm *MultiMap[K, V]) Add(key K, value V) {
+	values, _ := mm.om.Get(key)
+
+	mm.om.Add(key, append(values, value))
+}
+
+// Get returns the values associated with the key, in insertion order.
+//
+// Parameters:
+//   - key: The key.
+//
+// Returns:
+//   - []V: A copy of the values. Nil if the key does not exist.
+func (mm *MultiMap[K, V]) Get(key K) []V {
+	values, ok := mm.om.Get(key)
+	if !ok {
+		return nil
+	}
+
+	return slices.Clone(values)
+}
+
+// Delete removes the key and all its values.
+//
+// Parameters:
+//   - key: The key to remove.
+func (mm *MultiMap[K, V]) Delete(key K) {
+	mm.om.Delete(key)
+}
+
+// DeleteValue removes the values of the key that satisfy the predicate. If no
+// value remains, the key is removed too.
+//
+// Parameters:
+//   - key: The key.
+//   - pred: The predicate that selects the values to remove.
+//
+// Returns:
+//   - int: The number of values removed. 0 if pred is nil or the key does not
+//     exist.
+func (mm *MultiMap[K, V]) DeleteValue(key K, pred func(V) bool) int {
+	if pred == nil {
+		return 0
+	}
+
+	values, ok := mm.om.Get(key)
+	if !ok {
+		return 0
+	}
+
+	// The values are copied as the slices handed out by Iterator share them.
+	kept := slices.DeleteFunc(slices.Clone(values), pred)
+	removed := len(values) - len(kept)
+
+	if len(kept) == 0 {
+		mm.om.Delete(key)
+	} else {
+		mm.om.Add(key, kept)
+	}
+
+	return removed
+}
+
+// Size returns the number of keys in the map.
+//
+// Returns:
+//   - int: The number of keys.
+func (mm *MultiMap[K, V]) Size() int {
+	return mm.om.Size()
+}
+
+// Len returns the number of values in the map, across all the keys.
+//
+// Returns:
+//   - int: The number of values.
+func (mm *MultiMap[K, V]) Len() int {
+	var count int
+
+	for _, values := range mm.om.values {
+		count += len(values)
+	}
+
+	return count
+}
+
+// Keys returns the keys of the map in ascending order.
+//
+// Returns:
+//   - []K: A copy of the keys. Never nil.
+func (mm *MultiMap[K, V]) Keys() []K {
+	return mm.om.Keys()
+}
+
+// Iterator returns an iterator over the (key, values) entries of the map in
+// ascending key order.
+//
+// Returns:
+//   - common.Iterater[*Entry[K, []V]]: The iterator. Never nil.
+//
+// The value slices of the entries are shared with the map and must not be
+// modified.
+func (mm *MultiMap[K, V]) Iterator() luc.Iterater[*Entry[K, []V]] {
+	return mm.om.Iterator()
+}
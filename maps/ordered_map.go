@@ -0,0 +1,492 @@
+package maps
+
+import (
+	"cmp"
+	"slices"
+
+	luc "github.com/PlayerR9/lib_units/common"
+)
+
+// Entry is a key-value pair of an OrderedMap.
+type Entry[K cmp.Ordered, V any] struct {
+	// Key is the key of the entry.
+	Key K
+
+	// Value is the value of the entry.
+	Value V
+}
+
+// NewEntry creates a new entry.
+//
+// Parameters:
+//   - key: The key of the entry.
+//   - value: The value of the entry.
+//
+// Returns:
+//   - *Entry[K, V]: The new entry. Never nil.
+func NewEntry[K cmp.Ordered, V any](key K, value V) *Entry[K, V] {
+	e := &Entry[K, V]{
+		Key:   key,
+		Value: value,
+	}
+
+	return e
+}
+
+// OrderedMap is a map whose keys are kept sorted in ascending order.
+//
+// An OrderedMap is not safe for concurrent use, not even by readers only:
+// after AddUnsorted, the first method that needs the order, such as Keys or
+// Snapshot, sorts the keys in place. Call Reorder before sharing the map
+// between concurrent readers.
+type OrderedMap[K cmp.Ordered, V any] struct {
+	// values is the map of values.
+	values map[K]V
+
+	// keys is the sorted list of keys.
+	keys []K
+
+	// dirty is true if keys were added with AddUnsorted since the last sort.
+	dirty bool
+}
+
+// NewOrderedMap creates a new, empty OrderedMap.
+//
+// Returns:
+//   - *OrderedMap[K, V]: The new map. Never nil.
+func NewOrderedMap[K cmp.Ordered, V any]() *OrderedMap[K, V] {
+	om := &OrderedMap[K, V]{
+		values: make(map[K]V),
+		keys:   make([]K, 0),
+	}
+
+	return om
+}
+
+// Add adds a key-value pair to the map. If the key already exists, its value
+// is replaced.
+//
+// Parameters:
+//   - key: The key to add.
+//   - value: The value associated with the key.
+func (om *OrderedMap[K, V]) Add(key K, value V) {
+	om.Reorder()
+
+	pos, ok := slices.BinarySearch(om.keys, key)
+	if !ok {
+		om.keys = slices.Insert(om.keys, pos, key)
+	}
+
+	om.values[key] = value
+}
+
+// Size returns the number of entries in the map.
+//
+// Returns:
+//   - int: The number of entries.
+func (om *OrderedMap[K, V]) Size() int {
+	return len(om.keys)
+}
+
+// GetMap returns a copy of the underlying map.
+//
+// Returns:
+//   - map[K]V: The copy of the map. Never nil.
+func (om *OrderedMap[K, V]) GetMap() map[K]V {
+	m := make(map[K]V, len(om.values))
+
+	for k, v := range om.values {
+		m[k] = v
+	}
+
+	return m
+}
+
+// Iterator returns an iterator over the entries of the map in ascending key
+// order.
+//
+// Returns:
+//   - common.Iterater[*Entry[K, V]]: The iterator. Never nil.
+func (om *OrderedMap[K, V]) Iterator() luc.Iterater[*Entry[K, V]] {
+	om.Reorder()
+
+	return new_om_iterator(om, om.keys)
+}
+
+// KeyIterator returns an iterator over the keys of the map in ascending order.
+//
+// Returns:
+//   - common.Iterater[K]: The iterator. Never nil.
+func (om *OrderedMap[K, V]) KeyIterator() luc.Iterater[K] {
+	om.Reorder()
+
+	keys := slices.Clone(om.keys)
+
+	return luc.NewSimpleIterator(keys)
+}
+
+// First returns the entry with the smallest key.
+//
+// Returns:
+//   - *Entry[K, V]: The entry. Nil if the map is empty.
+//   - bool: True if the map is not empty, false otherwise.
+func (om *OrderedMap[K, V]) First() (*Entry[K, V], bool) {
+	om.Reorder()
+
+	if len(om.keys) == 0 {
+		return nil, false
+	}
+
+	return om.entry_at(0), true
+}
+
+// Last returns the entry with the largest key.
+//
+// Returns:
+//   - *Entry[K, V]: The entry. Nil if the map is empty.
+//   - bool: True if the map is not empty, false otherwise.
+func (om *OrderedMap[K, V]) Last() (*Entry[K, V], bool) {
+	om.Reorder()
+
+	if len(om.keys) == 0 {
+		return nil, false
+	}
+
+	return om.entry_at(len(om.keys) - 1), true
+}
+
+// Floor returns the entry with the largest key less than or equal to the
+// given key.
+//
+// Parameters:
+//   - key: The key to search for.
+//
+// Returns:
+//   - *Entry[K, V]: The entry. Nil if no such entry exists.
+//   - bool: True if the entry exists, false otherwise.
+func (om *OrderedMap[K, V]) Floor(key K) (*Entry[K, V], bool) {
+	om.Reorder()
+
+	pos, ok := slices.BinarySearch(om.keys, key)
+	if ok {
+		return om.entry_at(pos), true
+	}
+
+	if pos == 0 {
+		return nil, false
+	}
+
+	return om.entry_at(pos - 1), true
+}
+
+// Ceiling returns the entry with the smallest key greater than or equal to the
+// given key.
+//
+// Parameters:
+//   - key: The key to search for.
+//
+// Returns:
+//   - *Entry[K, V]: The entry. Nil if no such entry exists.
+//   - bool: True if the entry exists, false otherwise.
+func (om *OrderedMap[K, V]) Ceiling(key K) (*Entry[K, V], bool) {
+	om.Reorder()
+
+	pos, _ := slices.BinarySearch(om.keys, key)
+	if pos == len(om.keys) {
+		return nil, false
+	}
+
+	return om.entry_at(pos), true
+}
+
+// RangeQuery returns an iterator over the entries whose keys lie between lo
+// and hi, in ascending key order.
+//
+// Parameters:
+//   - lo: The lower bound of the range.
+//   - hi: The upper bound of the range.
+//   - lo_incl: Whether the lower bound is inclusive.
+//   - hi_incl: Whether the upper bound is inclusive.
+//
+// Returns:
+//   - common.Iterater[*Entry[K, V]]: The iterator. Never nil.
+//
+// If the range is empty (e.g., lo > hi), the iterator is exhausted from the start.
+func (om *OrderedMap[K, V]) RangeQuery(lo, hi K, lo_incl, hi_incl bool) luc.Iterater[*Entry[K, V]] {
+	om.Reorder()
+
+	start, ok := slices.BinarySearch(om.keys, lo)
+	if ok && !lo_incl {
+		start++
+	}
+
+	end, ok := slices.BinarySearch(om.keys, hi)
+	if ok && hi_incl {
+		end++
+	}
+
+	if start > end {
+		start = end
+	}
+
+	return new_om_iterator(om, om.keys[start:end])
+}
+
+// Get returns the value associated with the key.
+//
+// Parameters:
+//   - key: The key to search for.
+//
+// Returns:
+//   - V: The value. The zero value if the key does not exist.
+//   - bool: True if the key exists, false otherwise.
+func (om *OrderedMap[K, V]) Get(key K) (V, bool) {
+	value, ok := om.values[key]
+	return value, ok
+}
+
+// Delete removes the key and its value from the map. Does nothing if the key
+// does not exist.
+//
+// Parameters:
+//   - key: The key to remove.
+func (om *OrderedMap[K, V]) Delete(key K) {
+	_, _ = om.Pop(key)
+}
+
+// Pop removes the key from the map and returns its value.
+//
+// Parameters:
+//   - key: The key to remove.
+//
+// Returns:
+//   - V: The value that was associated with the key. The zero value if the key
+//     does not exist.
+//   - bool: True if the key existed, false otherwise.
+func (om *OrderedMap[K, V]) Pop(key K) (V, bool) {
+	value, ok := om.values[key]
+	if !ok {
+		return value, false
+	}
+
+	om.Reorder()
+
+	pos, _ := slices.BinarySearch(om.keys, key)
+	om.keys = slices.Delete(om.keys, pos, pos+1)
+
+	delete(om.values, key)
+
+	return value, true
+}
+
+// Keys returns the keys of the map in ascending order.
+//
+// Returns:
+//   - []K: A copy of the keys. Never nil.
+func (om *OrderedMap[K, V]) Keys() []K {
+	om.Reorder()
+
+	return slices.Clone(om.keys)
+}
+
+// Values returns the values of the map in ascending key order.
+//
+// Returns:
+//   - []V: The values. Never nil.
+func (om *OrderedMap[K, V]) Values() []V {
+	om.Reorder()
+
+	values := make([]V, 0, len(om.keys))
+
+	for _, key := range om.keys {
+		values = append(values, om.values[key])
+	}
+
+	return values
+}
+
+// AddUnsorted is like Add but does not keep the keys sorted, which makes bulk
+// insertion linear instead of quadratic. The keys are sorted again either by
+// an explicit call to Reorder or, lazily, by the next method that needs the
+// order; that method then writes to the map, even if it only reads it
+// otherwise.
+//
+// Parameters:
+//   - key: The key to add.
+//   - value: The value associated with the key.
+func (om *OrderedMap[K, V]) AddUnsorted(key K, value V) {
+	_, ok := om.values[key]
+	if !ok {
+		om.keys = append(om.keys, key)
+		om.dirty = true
+	}
+
+	om.values[key] = value
+}
+
+// Reorder sorts the keys added with AddUnsorted. Does nothing if the keys are
+// already sorted.
+func (om *OrderedMap[K, V]) Reorder() {
+	if !om.dirty {
+		return
+	}
+
+	slices.Sort(om.keys)
+	om.dirty = false
+}
+
+// entry_at returns the entry at the given position in the sorted keys.
+//
+// Parameters:
+//   - pos: The position of the key.
+//
+// Returns:
+//   - *Entry[K, V]: The entry. Never nil.
+//
+// Assertions:
+//   - 0 <= pos < len(om.keys)
+func (om *OrderedMap[K, V]) entry_at(pos int) *Entry[K, V] {
+	key := om.keys[pos]
+
+	return NewEntry(key, om.values[key])
+}
+
+// ReverseIterator returns an iterator over the entries of the map in
+// descending key order.
+//
+// Returns:
+//   - common.Iterater[*Entry[K, V]]: The iterator. Never nil.
+func (om *OrderedMap[K, V]) ReverseIterator() luc.Iterater[*Entry[K, V]] {
+	om.Reorder()
+
+	it := new_om_iterator(om, om.keys)
+	slices.Reverse(it.keys)
+
+	return it
+}
+
+// FilterIterator returns an iterator over the entries of the map that satisfy
+// the predicate, in ascending key order.
+//
+// Parameters:
+//   - pred: The predicate. If nil, all the entries are accepted.
+//
+// Returns:
+//   - common.Iterater[*Entry[K, V]]: The iterator. Never nil.
+//
+// The predicate is evaluated lazily, each time an entry is consumed.
+func (om *OrderedMap[K, V]) FilterIterator(pred func(K, V) bool) luc.Iterater[*Entry[K, V]] {
+	om.Reorder()
+
+	it := new_om_iterator(om, om.keys)
+	it.pred = pred
+
+	return it
+}
+
+// Clone returns a copy of the map that can be modified without affecting the
+// original.
+//
+// Returns:
+//   - *OrderedMap[K, V]: The copy. Never nil.
+//
+// Values implementing common.Copier[V] are copied with their Copy method;
+// other values are copied by assignment, so pointers, slices and maps among
+// them are shared with the original.
+func (om *OrderedMap[K, V]) Clone() *OrderedMap[K, V] {
+	return om.CloneFunc(nil)
+}
+
+// CloneFunc is like Clone but copies the values with the given function.
+//
+// Parameters:
+//   - f: The function that copies a value. If nil, values are copied as in
+//     Clone.
+//
+// Returns:
+//   - *OrderedMap[K, V]: The copy. Never nil.
+func (om *OrderedMap[K, V]) CloneFunc(f func(V) V) *OrderedMap[K, V] {
+	om.Reorder()
+
+	if f == nil {
+		f = copy_value[V]
+	}
+
+	values := make(map[K]V, len(om.values))
+
+	for key, value := range om.values {
+		values[key] = f(value)
+	}
+
+	clone := &OrderedMap[K, V]{
+		values: values,
+		keys:   slices.Clone(om.keys),
+	}
+
+	return clone
+}
+
+// copy_value copies a value with its Copy method, if it has one.
+//
+// Parameters:
+//   - value: The value to copy.
+//
+// Returns:
+//   - V: The copy.
+func copy_value[V any](value V) V {
+	copier, ok := any(value).(luc.Copier[V])
+	if !ok {
+		return value
+	}
+
+	return copier.Copy()
+}
+
+// Snapshot returns the entries of the map in ascending key order, so that the
+// map can later be rolled back with RestoreFromSnapshot.
+//
+// Returns:
+//   - []Entry[K, V]: The entries. Never nil.
+//
+// Values are copied by assignment; thus, changes made through pointers, slices
+// or maps stored in the map are not undone by a restore.
+func (om *OrderedMap[K, V]) Snapshot() []Entry[K, V] {
+	om.Reorder()
+
+	entries := make([]Entry[K, V], 0, len(om.keys))
+
+	for _, key := range om.keys {
+		entries = append(entries, Entry[K, V]{
+			Key:   key,
+			Value: om.values[key],
+		})
+	}
+
+	return entries
+}
+
+// RestoreFromSnapshot replaces the contents of the map with the given entries.
+//
+// Parameters:
+//   - entries: The entries, usually from Snapshot. They need not be sorted; if
+//     a key appears more than once, its last value wins.
+//
+// Example:
+//
+//	snap := om.Snapshot()
+//
+//	err := speculative_update(om)
+//	if err != nil {
+//		om.RestoreFromSnapshot(snap)
+//	}
+func (om *OrderedMap[K, V]) RestoreFromSnapshot(entries []Entry[K, V]) {
+	om.values = make(map[K]V, len(entries))
+	om.keys = make([]K, 0, len(entries))
+	om.dirty = false
+
+	for _, entry := range entries {
+		om.AddUnsorted(entry.Key, entry.Value)
+	}
+
+	om.Reorder()
+}
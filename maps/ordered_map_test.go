@@ -0,0 +1,258 @@
+package maps
+
+import (
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
+	"testing"
+)
+
+func TestRangeQuery(t *testing.T) {
+	om := NewOrderedMap[int, string]()
+
+	for _, k := range []int{5, 1, 3, 9, 7} {
+		om.Add(k, "")
+	}
+
+	iter := om.RangeQuery(3, 7, false, true)
+
+	var keys []int
+
+	for {
+		entry, err := iter.Consume()
+		if err != nil {
+			break
+		}
+
+		keys = append(keys, entry.Key)
+	}
+
+	if len(keys) != 2 || keys[0] != 5 || keys[1] != 7 {
+		t.Errorf("expected [5 7], got %v", keys)
+	}
+}
+
+func TestFloorCeiling(t *testing.T) {
+	om := NewOrderedMap[int, string]()
+
+	for _, k := range []int{1, 3, 5} {
+		om.Add(k, "")
+	}
+
+	entry, ok := om.Floor(4)
+	if !ok || entry.Key != 3 {
+		t.Errorf("expected floor 3, got %v", entry)
+	}
+
+	entry, ok = om.Ceiling(4)
+	if !ok || entry.Key != 5 {
+		t.Errorf("expected ceiling 5, got %v", entry)
+	}
+
+	_, ok = om.Floor(0)
+	if ok {
+		t.Errorf("expected no floor for 0")
+	}
+
+	_, ok = om.Ceiling(6)
+	if ok {
+		t.Errorf("expected no ceiling for 6")
+	}
+}
+
+func TestDeleteAndReorder(t *testing.T) {
+	om := NewOrderedMap[int, string]()
+
+	for _, k := range []int{4, 2, 8, 6, 2} {
+		om.AddUnsorted(k, "")
+	}
+
+	om.Delete(6)
+
+	keys := om.Keys()
+	if len(keys) != 3 || keys[0] != 2 || keys[1] != 4 || keys[2] != 8 {
+		t.Errorf("expected [2 4 8], got %v", keys)
+	}
+
+	_, ok := om.Pop(6)
+	if ok {
+		t.Errorf("expected 6 to be absent")
+	}
+}
+
+func TestReverseFilterIterator(t *testing.T) {
+	om := NewOrderedMap[int, string]()
+
+	for _, k := range []int{1, 2, 3, 4} {
+		om.Add(k, "")
+	}
+
+	iter := om.FilterIterator(func(k int, _ string) bool { return k%2 == 0 })
+
+	var keys []int
+
+	for {
+		entry, err := iter.Consume()
+		if err != nil {
+			break
+		}
+
+		keys = append(keys, entry.Key)
+	}
+
+	if len(keys) != 2 || keys[0] != 2 || keys[1] != 4 {
+		t.Errorf("expected [2 4], got %v", keys)
+	}
+
+	rev := om.ReverseIterator()
+
+	entry, err := rev.Consume()
+	if err != nil || entry.Key != 4 {
+		t.Errorf("expected first reversed key to be 4")
+	}
+}
+
+func TestMultiMap(t *testing.T) {
+	mm := NewMultiMap[string, int]()
+
+	mm.Add("b", 1)
+	mm.Add("a", 2)
+	mm.Add("b", 3)
+
+	values := mm.Get("b")
+	if len(values) != 2 || values[0] != 1 || values[1] != 3 {
+		t.Errorf("expected [1 3], got %v", values)
+	}
+
+	removed := mm.DeleteValue("a", func(v int) bool { return v == 2 })
+	if removed != 1 || mm.Size() != 1 {
+		t.Errorf("expected key a to be removed, got %v", mm.Keys())
+	}
+
+	entry, err := mm.Iterator().Consume()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	removed = mm.DeleteValue("b", func(v int) bool { return v == 1 })
+	if removed != 1 {
+		t.Errorf("expected 1 value to be removed, got %d", removed)
+	}
+
+	if len(entry.Value) != 2 || entry.Value[0] != 1 || entry.Value[1] != 3 {
+		t.Errorf("expected the iterated values to stay [1 3], got %v", entry.Value)
+	}
+
+	values = mm.Get("b")
+	if len(values) != 1 || values[0] != 3 {
+		t.Errorf("expected [3], got %v", values)
+	}
+}
+
+func TestJSONRoundTrip(t *testing.T) {
+	om := NewOrderedMap[int, string]()
+
+	for _, k := range []int{10, 2, 33} {
+		om.Add(k, "v")
+	}
+
+	data, err := json.Marshal(om)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	if string(data) != `{"2":"v","10":"v","33":"v"}` {
+		t.Errorf("unexpected encoding: %s", data)
+	}
+
+	decoded := NewOrderedMap[int, string]()
+
+	err = json.Unmarshal(data, decoded)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	keys := decoded.Keys()
+	if len(keys) != 3 || keys[0] != 2 || keys[2] != 33 {
+		t.Errorf("expected [2 10 33], got %v", keys)
+	}
+}
+
+func TestJSONRoundTripControlKeys(t *testing.T) {
+	om := NewOrderedMap[string, int]()
+	om.Add("a\x01b", 1)
+	om.Add("tab\there \"quoted\"", 2)
+
+	data, err := json.Marshal(om)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	decoded := NewOrderedMap[string, int]()
+
+	err = json.Unmarshal(data, decoded)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	for _, key := range om.Keys() {
+		v, ok := decoded.Get(key)
+		if !ok || v != om.values[key] {
+			t.Errorf("expected %q to decode to %d, got %d", key, om.values[key], v)
+		}
+	}
+}
+
+func TestGobRoundTrip(t *testing.T) {
+	om := NewOrderedMap[string, int]()
+	om.Add("b", 2)
+	om.Add("a", 1)
+
+	var buf bytes.Buffer
+
+	err := gob.NewEncoder(&buf).Encode(om)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	decoded := NewOrderedMap[string, int]()
+
+	err = gob.NewDecoder(&buf).Decode(decoded)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	value, ok := decoded.Get("b")
+	if !ok || value != 2 || decoded.Size() != 2 {
+		t.Errorf("unexpected decoded map: %v", decoded.GetMap())
+	}
+}
+
+func TestSnapshotRestore(t *testing.T) {
+	om := NewOrderedMap[string, int]()
+
+	om.Add("b", 2)
+	om.Add("a", 1)
+
+	clone := om.Clone()
+	snap := om.Snapshot()
+
+	om.Add("c", 3)
+	om.Delete("a")
+
+	if clone.Size() != 2 {
+		t.Errorf("expected the clone to keep 2 entries, got %d", clone.Size())
+	}
+
+	om.RestoreFromSnapshot(snap)
+
+	keys := om.Keys()
+	if len(keys) != 2 || keys[0] != "a" || keys[1] != "b" {
+		t.Errorf("expected keys [a b] after restore, got %v", keys)
+	}
+
+	value, ok := om.Get("a")
+	if !ok || value != 1 {
+		t.Errorf("expected a=1 after restore, got %d, %t", value, ok)
+	}
+}
@@ -0,0 +1,252 @@
+package maps
+
+import (
+	"cmp"
+	"slices"
+
+	luc "github.com/PlayerR9/lib_units/common"
+)
+
+// OrderedSet is a set of unique elements kept in ascending order.
+type OrderedSet[T cmp.Ordered] struct {
+	// elems is the sorted list of elements.
+	elems []T
+}
+
+// NewOrderedSet creates a new, empty OrderedSet.
+//
+// Returns:
+//   - *OrderedSet[T]: The new set. Never nil.
+func NewOrderedSet[T cmp.Ordered]() *OrderedSet[T] {
+	os := &OrderedSet[T]{
+		elems: make([]T, 0),
+	}
+
+	return os
+}
+
+// Add adds an element to the set.
+//
+// Parameters:
+//   - elem: The element to add.
+//
+// Returns:
+//   - bool: True if the element was added, false if it was already in the set.
+func (os *OrderedSet[T]) Add(elem T) bool {
+	pos, ok := slices.BinarySearch(os.elems, elem)
+	if ok {
+		return false
+	}
+
+	os.elems = slices.Insert(os.elems, pos, elem)
+
+	return true
+}
+
+// Remove removes an element from the set.
+//
+// Parameters:
+//   - elem: The element to remove.
+//
+// Returns:
+//   - bool: True if the element was removed, false if it was not in the set.
+func (os *OrderedSet[T]) Remove(elem T) bool {
+	pos, ok := slices.BinarySearch(os.elems, elem)
+	if !ok {
+		return false
+	}
+
+	os.elems = slices.Delete(os.elems, pos, pos+1)
+
+	return true
+}
+
+// Contains checks whether an element is in the set.
+//
+// Parameters:
+//   - elem: The element to check.
+//
+// Returns:
+//   - bool: True if the element is in the set, false otherwise.
+func (os *OrderedSet[T]) Contains(elem T) bool {
+	_, ok := slices.BinarySearch(os.elems, elem)
+	return ok
+}
+
+// Size returns the number of elements in the set.
+//
+// Returns:
+//   - int: The number of elements.
+func (os *OrderedSet[T]) Size() int {
+	return len(os.elems)
+}
+
+// Slice returns the elements of the set in ascending order.
+//
+// Returns:
+//   - []T: A copy of the elements. Never nil.
+func (os *OrderedSet[T]) Slice() []T {
+	elems := make([]T, len(os.elems))
+	copy(elems, os.elems)
+
+	return elems
+}
+
+// Iterator returns an iterator over the elements of the set in ascending order.
+//
+// Returns:
+//   - common.Iterater[T]: The iterator. Never nil.
+func (os *OrderedSet[T]) Iterator() luc.Iterater[T] {
+	return luc.NewSimpleIterator(os.Slice())
+}
+
+// Union returns a new set containing the elements of both sets.
+//
+// Parameters:
+//   - other: The other set. A nil set is treated as an empty one.
+//
+// Returns:
+//   - *OrderedSet[T]: The union of the two sets. Never nil.
+func (os *OrderedSet[T]) Union(other *OrderedSet[T]) *OrderedSet[T] {
+	if other == nil {
+		return &OrderedSet[T]{elems: os.Slice()}
+	}
+
+	elems := make([]T, 0, len(os.elems)+len(other.elems))
+
+	i, j := 0, 0
+
+	for i < len(os.elems) && j < len(other.elems) {
+		switch c := cmp.Compare(os.elems[i], other.elems[j]); {
+		case c < 0:
+			elems = append(elems, os.elems[i])
+			i++
+		case c > 0:
+			elems = append(elems, other.elems[j])
+			j++
+		default:
+			elems = append(elems, os.elems[i])
+			i++
+			j++
+		}
+	}
+
+	elems = append(elems, os.elems[i:]...)
+	elems = append(elems, other.elems[j:]...)
+
+	return &OrderedSet[T]{elems: elems}
+}
+
+// Intersect returns a new set containing the elements that are in both sets.
+//
+// Parameters:
+//   - other: The other set. A nil set is treated as an empty one.
+//
+// Returns:
+//   - *OrderedSet[T]: The intersection of the two sets. Never nil.
+func (os *OrderedSet[T]) Intersect(other *OrderedSet[T]) *OrderedSet[T] {
+	if other == nil {
+		return NewOrderedSet[T]()
+	}
+
+	var elems []T
+
+	i, j := 0, 0
+
+	for i < len(os.elems) && j < len(other.elems) {
+		switch c := cmp.Compare(os.elems[i], other.elems[j]); {
+		case c < 0:
+			i++
+		case c > 0:
+			j++
+		default:
+			elems = append(elems, os.elems[i])
+			i++
+			j++
+		}
+	}
+
+	if elems == nil {
+		elems = make([]T, 0)
+	}
+
+	return &OrderedSet[T]{elems: elems}
+}
+
+// Difference returns a new set containing the elements of this set that are not
+// in the other set.
+//
+// Parameters:
+//   - other: The other set. A nil set is treated as an empty one.
+//
+// Returns:
+//   - *OrderedSet[T]: The difference of the two sets. Never nil.
+func (os *OrderedSet[T]) Difference(other *OrderedSet[T]) *OrderedSet[T] {
+	if other == nil {
+		return &OrderedSet[T]{elems: os.Slice()}
+	}
+
+	elems := make([]T, 0, len(os.elems))
+
+	i, j := 0, 0
+
+	for i < len(os.elems) {
+		if j >= len(other.elems) {
+			elems = append(elems, os.elems[i:]...)
+			break
+		}
+
+		switch c := cmp.Compare(os.elems[i], other.elems[j]); {
+		case c < 0:
+			elems = append(elems, os.elems[i])
+			i++
+		case c > 0:
+			j++
+		default:
+			i++
+			j++
+		}
+	}
+
+	return &OrderedSet[T]{elems: elems}
+}
+
+// OrderedSetBuilder is a builder of OrderedSet that sorts the elements only once,
+// when the set is built.
+type OrderedSetBuilder[T cmp.Ordered] struct {
+	// elems is the list of elements added so far.
+	elems []T
+}
+
+// Add adds elements to the builder. Duplicates are allowed.
+//
+// Parameters:
+//   - elems: The elements to add.
+func (b *OrderedSetBuilder[T]) Add(elems ...T) {
+	b.elems = append(b.elems, elems...)
+}
+
+// Build builds the OrderedSet from the added elements.
+//
+// Returns:
+//   - *OrderedSet[T]: The new set. Never nil.
+//
+// The builder is reset after the set is built.
+func (b *OrderedSetBuilder[T]) Build() *OrderedSet[T] {
+	elems := b.elems
+	b.elems = nil
+
+	slices.Sort(elems)
+	elems = slices.Compact(elems)
+
+	if elems == nil {
+		elems = make([]T, 0)
+	}
+
+	return &OrderedSet[T]{elems: elems}
+}
+
+// Reset resets the builder for reuse.
+func (b *OrderedSetBuilder[T]) Reset() {
+	b.elems = nil
+}
@@ -2,6 +2,7 @@ package MathExt
 
 import (
 	"math/big"
+	"slices"
 
 	gcers "github.com/PlayerR9/go-commons/errors"
 	gcint "github.com/PlayerR9/go-commons/ints"
@@ -131,6 +132,357 @@ func Subtract(n1, n2 []int, base int) ([]int, error) {
 	return result, nil
 }
 
+const (
+	// karatsuba_threshold is the number of digits below which Multiply uses the
+	// schoolbook algorithm instead of Karatsuba's.
+	karatsuba_threshold int = 32
+)
+
+// trim_digits removes the leading zeros of a number. Numbers are Least
+// Significant Digit (LSD) first.
+//
+// Parameters:
+//   - n: The number to trim.
+//
+// Returns:
+//   - []int: The trimmed number. Zero is represented as []int{0}.
+func trim_digits(n []int) []int {
+	if len(n) == 0 {
+		return []int{0}
+	}
+
+	i := len(n) - 1
+	for ; i > 0 && n[i] == 0; i-- {
+	}
+
+	return n[:i+1]
+}
+
+// compare_digits compares two trimmed numbers of the same base. Both numbers are
+// Least Significant Digit (LSD) first.
+//
+// Parameters:
+//   - n1: The first number.
+//   - n2: The second number.
+//
+// Returns:
+//   - int: -1 if n1 < n2, 0 if n1 == n2, and 1 if n1 > n2.
+func compare_digits(n1, n2 []int) int {
+	if len(n1) != len(n2) {
+		if len(n1) < len(n2) {
+			return -1
+		}
+
+		return 1
+	}
+
+	for i := len(n1) - 1; i >= 0; i-- {
+		if n1[i] < n2[i] {
+			return -1
+		} else if n1[i] > n2[i] {
+			return 1
+		}
+	}
+
+	return 0
+}
+
+// mul_add_small computes n * k + c where k and c are non-negative integers.
+// The number is Least Significant Digit (LSD) first.
+//
+// Parameters:
+//   - n: The number to multiply.
+//   - k: The multiplier.
+//   - c: The value to add.
+//   - base: The base of the number. Must be greater than 1.
+//
+// Returns:
+//   - []int: The trimmed result.
+func mul_add_small(n []int, k, c, base int) []int {
+	result := make([]int, 0, len(n)+1)
+	carry := c
+
+	for _, digit := range n {
+		tmp := digit*k + carry
+		result = append(result, tmp%base)
+		carry = tmp / base
+	}
+
+	for carry > 0 {
+		result = append(result, carry%base)
+		carry /= base
+	}
+
+	return trim_digits(result)
+}
+
+// schoolbook_multiply multiplies two numbers of the same base with the schoolbook
+// algorithm. Both numbers are Least Significant Digit (LSD) first.
+//
+// Parameters:
+//   - n1: The first number.
+//   - n2: The second number.
+//   - base: The base of the numbers. Must be greater than 1.
+//
+// Returns:
+//   - []int: The product. It may have leading zeros.
+func schoolbook_multiply(n1, n2 []int, base int) []int {
+	result := make([]int, len(n1)+len(n2))
+
+	for i, d1 := range n1 {
+		if d1 == 0 {
+			continue
+		}
+
+		var carry int
+
+		for j, d2 := range n2 {
+			tmp := result[i+j] + d1*d2 + carry
+			result[i+j] = tmp % base
+			carry = tmp / base
+		}
+
+		for k := i + len(n2); carry > 0; k++ {
+			tmp := result[k] + carry
+			result[k] = tmp % base
+			carry = tmp / base
+		}
+	}
+
+	return result
+}
+
+// shift_digits multiplies a number by base^m. The number is Least Significant
+// Digit (LSD) first.
+//
+// Parameters:
+//   - n: The number to shift.
+//   - m: The number of digits to shift by.
+//
+// Returns:
+//   - []int: The shifted number.
+func shift_digits(n []int, m int) []int {
+	shifted := make([]int, m, m+len(n))
+	shifted = append(shifted, n...)
+
+	return shifted
+}
+
+// split_digits splits a number into its m lowest digits and the remaining ones.
+//
+// Parameters:
+//   - n: The number to split.
+//   - m: The number of low digits.
+//
+// Returns:
+//   - []int: The low digits.
+//   - []int: The high digits. Empty if n has at most m digits.
+func split_digits(n []int, m int) ([]int, []int) {
+	if len(n) <= m {
+		return n, nil
+	}
+
+	return n[:m], n[m:]
+}
+
+// karatsuba multiplies two numbers of the same base with Karatsuba's algorithm.
+// Both numbers are Least Significant Digit (LSD) first.
+//
+// Parameters:
+//   - n1: The first number.
+//   - n2: The second number.
+//   - base: The base of the numbers. Must be greater than 1.
+//
+// Returns:
+//   - []int: The trimmed product.
+func karatsuba(n1, n2 []int, base int) []int {
+	if len(n1) < karatsuba_threshold || len(n2) < karatsuba_threshold {
+		res := schoolbook_multiply(n1, n2, base)
+
+		return trim_digits(res)
+	}
+
+	m := max(len(n1), len(n2)) / 2
+
+	low1, high1 := split_digits(n1, m)
+	low2, high2 := split_digits(n2, m)
+
+	z0 := karatsuba(low1, low2, base)
+	z2 := karatsuba(high1, high2, base)
+	z1 := karatsuba(Add(low1, high1, base), Add(low2, high2, base), base)
+
+	// z1 - z0 - z2 is never negative.
+	z1, _ = Subtract(z1, z0, base)
+	z1, _ = Subtract(z1, z2, base)
+
+	result := Add(z0, shift_digits(z1, m), base)
+	result = Add(result, shift_digits(z2, 2*m), base)
+
+	return trim_digits(result)
+}
+
+// Multiply multiplies two numbers of the same base. Both numbers are Least
+// Significant Digit (LSD) first.
+//
+// Parameters:
+//   - n1: The first number to multiply.
+//   - n2: The second number to multiply.
+//   - base: The base of the numbers.
+//
+// Returns:
+//   - []int: The product of the two numbers without leading zeros. Nil if the base
+//     is less than or equal to 0.
+//
+// Behaviors:
+//   - Empty numbers are treated as 0.
+//   - Karatsuba's algorithm is used when both numbers are large enough.
+func Multiply(n1, n2 []int, base int) []int {
+	if base <= 0 {
+		return nil
+	}
+
+	if base == 1 {
+		return make([]int, len(n1)*len(n2))
+	}
+
+	if len(n1) == 0 || len(n2) == 0 {
+		return []int{0}
+	}
+
+	return karatsuba(n1, n2, base)
+}
+
+// DivMod divides two numbers of the same base. Both numbers are Least Significant
+// Digit (LSD) first.
+//
+// Parameters:
+//   - n1: The dividend.
+//   - n2: The divisor.
+//   - base: The base of the numbers.
+//
+// Returns:
+//   - []int: The quotient without leading zeros.
+//   - []int: The remainder without leading zeros.
+//   - error: An error if the division failed.
+//
+// Errors:
+//   - *errors.ErrInvalidParameter: The base is less than or equal to 0.
+//   - *ErrDivisionByZero: The divisor is 0.
+func DivMod(n1, n2 []int, base int) ([]int, []int, error) {
+	if base <= 0 {
+		return nil, nil, gcers.NewErrInvalidParameter("base", gcint.NewErrGT(0))
+	}
+
+	if base == 1 {
+		if len(n2) == 0 {
+			return nil, nil, NewErrDivisionByZero()
+		}
+
+		q := make([]int, len(n1)/len(n2))
+		r := make([]int, len(n1)%len(n2))
+
+		return q, r, nil
+	}
+
+	dividend := trim_digits(n1)
+	divisor := trim_digits(n2)
+
+	if len(divisor) == 1 && divisor[0] == 0 {
+		return nil, nil, NewErrDivisionByZero()
+	}
+
+	if compare_digits(dividend, divisor) < 0 {
+		return []int{0}, slices.Clone(dividend), nil
+	}
+
+	quotient := make([]int, len(dividend))
+	remainder := []int{0}
+
+	for i := len(dividend) - 1; i >= 0; i-- {
+		// remainder = remainder * base + dividend[i]
+		remainder = trim_digits(append([]int{dividend[i]}, remainder...))
+
+		// Find the largest digit d such that divisor * d <= remainder.
+		low, high := 0, base-1
+
+		for low < high {
+			mid := (low + high + 1) / 2
+
+			if compare_digits(mul_add_small(divisor, mid, 0, base), remainder) <= 0 {
+				low = mid
+			} else {
+				high = mid - 1
+			}
+		}
+
+		quotient[i] = low
+
+		if low > 0 {
+			remainder, _ = Subtract(remainder, mul_add_small(divisor, low, 0, base), base)
+		}
+	}
+
+	return trim_digits(quotient), remainder, nil
+}
+
+// Rebase converts a number from one base to another. The number is Least
+// Significant Digit (LSD) first and so is the result.
+//
+// Parameters:
+//   - n: The number to convert.
+//   - from_base: The base of the number.
+//   - to_base: The base to convert to.
+//
+// Returns:
+//   - []int: The converted number without leading zeros.
+//   - error: An error if the conversion failed.
+//
+// Errors:
+//   - *errors.ErrInvalidParameter: Either base is less than or equal to 0.
+//   - *ints.ErrAt: A digit is not in the range [0, from_base-1].
+//
+// Unlike ints.BaseToBase, the number is never converted to an int; thus, numbers
+// of any size are supported unless to_base is 1.
+func Rebase(n []int, from_base, to_base int) ([]int, error) {
+	if from_base <= 0 {
+		return nil, gcint.NewErrInvalidBase("from_base")
+	} else if to_base <= 0 {
+		return nil, gcint.NewErrInvalidBase("to_base")
+	}
+
+	err := gcint.CheckDigits(n, from_base)
+	if err != nil {
+		return nil, err
+	}
+
+	if from_base == to_base {
+		return slices.Clone(n), nil
+	}
+
+	if from_base == 1 {
+		digits, _ := gcint.DecToBase(len(n), to_base)
+		return digits, nil
+	}
+
+	if to_base == 1 {
+		var value int
+
+		for i := len(n) - 1; i >= 0; i-- {
+			value = value*from_base + n[i]
+		}
+
+		return make([]int, value), nil
+	}
+
+	result := []int{0}
+
+	for i := len(n) - 1; i >= 0; i-- {
+		result = mul_add_small(result, from_base, n[i], to_base)
+	}
+
+	return result, nil
+}
+
 // IntToBigInt converts an integer to a big.Int.
 //
 // Parameters:
@@ -0,0 +1,83 @@
+package MathExt
+
+import (
+	"math/big"
+	"math/rand"
+	"testing"
+)
+
+// to_big is a helper function that converts a LSD number to a *big.Int.
+func to_big(n []int, base int) *big.Int {
+	res := new(big.Int)
+	b := big.NewInt(int64(base))
+
+	for i := len(n) - 1; i >= 0; i-- {
+		res.Mul(res, b)
+		res.Add(res, big.NewInt(int64(n[i])))
+	}
+
+	return res
+}
+
+// random_digits is a helper function that generates a random LSD number.
+func random_digits(r *rand.Rand, size, base int) []int {
+	n := make([]int, size)
+
+	for i := range n {
+		n[i] = r.Intn(base)
+	}
+
+	return n
+}
+
+func TestMultiply(t *testing.T) {
+	r := rand.New(rand.NewSource(42))
+
+	for _, size := range []int{1, 5, 40, 100} {
+		n1 := random_digits(r, size, 10)
+		n2 := random_digits(r, size+3, 10)
+
+		res := Multiply(n1, n2, 10)
+
+		expected := new(big.Int).Mul(to_big(n1, 10), to_big(n2, 10))
+		if to_big(res, 10).Cmp(expected) != 0 {
+			t.Errorf("wrong product for size %d", size)
+		}
+	}
+}
+
+func TestDivMod(t *testing.T) {
+	r := rand.New(rand.NewSource(42))
+
+	for _, size := range []int{1, 5, 40} {
+		n1 := random_digits(r, size*2, 7)
+		n2 := random_digits(r, size, 7)
+		n2[len(n2)-1] = 1
+
+		q, rem, err := DivMod(n1, n2, 7)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err.Error())
+		}
+
+		eq, er := new(big.Int).QuoRem(to_big(n1, 7), to_big(n2, 7), new(big.Int))
+		if to_big(q, 7).Cmp(eq) != 0 || to_big(rem, 7).Cmp(er) != 0 {
+			t.Errorf("wrong division for size %d", size)
+		}
+	}
+
+	_, _, err := DivMod([]int{1}, []int{0}, 10)
+	if err == nil {
+		t.Errorf("expected division by zero error")
+	}
+}
+
+func TestRebase(t *testing.T) {
+	res, err := Rebase([]int{5, 5, 2}, 10, 16)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	if to_big(res, 16).Int64() != 255 {
+		t.Errorf("expected 255, got %v", res)
+	}
+}
@@ -21,3 +21,25 @@ func NewErrSubtractionUnderflow() *ErrSubtractionUnderflow {
 	e := &ErrSubtractionUnderflow{}
 	return e
 }
+
+// ErrDivisionByZero is an error that is returned when a division by zero
+// is attempted.
+type ErrDivisionByZero struct{}
+
+// Error is a method of ErrDivisionByZero that returns the message:
+// "division by zero".
+//
+// Returns:
+//   - string: The error message.
+func (e *ErrDivisionByZero) Error() string {
+	return "division by zero"
+}
+
+// NewErrDivisionByZero creates a new ErrDivisionByZero error.
+//
+// Returns:
+//   - *ErrDivisionByZero: The new ErrDivisionByZero error.
+func NewErrDivisionByZero() *ErrDivisionByZero {
+	e := &ErrDivisionByZero{}
+	return e
+}
@@ -4,22 +4,26 @@ import (
 	"fmt"
 	"reflect"
 	"strings"
+
+	luc "github.com/PlayerR9/lib_units/common"
 	// dbg "github.com/PlayerR9/lib_units/debug"
 )
 
-// AssertIfZero panics if the element is zero.
+// AssertIfZero fails an assertion if the element is zero.
 //
 // Parameters:
 //   - elem: The element to check.
 //   - msg: The message to show if the element is zero.
 //
-// The panic message is the string msg.
+// The failure is reported with common.Assert; thus, it panics with msg unless
+// another handler is set, and does nothing in production builds.
 func AssertIfZero(elem any, msg string) {
-	value := reflect.ValueOf(elem)
-	ok := value.IsZero()
-	if ok {
-		panic(msg)
+	if !luc.AssertsEnabled() {
+		return
 	}
+
+	value := reflect.ValueOf(elem)
+	luc.Assert(!value.IsZero(), msg)
 }
 
 // TypeOf returns the type of the value as a string.
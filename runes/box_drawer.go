@@ -1,5 +1,10 @@
 package runes
 
+import (
+	gcers "github.com/PlayerR9/go-commons/errors"
+	gcch "github.com/PlayerR9/go-commons/runes"
+)
+
 // dbg "github.com/PlayerR9/lib_units/debug"
 
 var (
@@ -35,6 +40,21 @@ const (
 	BtRounded
 )
 
+// BoxAlignment is the horizontal alignment of the content of a box.
+type BoxAlignment int
+
+const (
+	// AlignLeft aligns the content to the left of the box.
+	AlignLeft BoxAlignment = iota
+
+	// AlignCenter centers the content in the box. When a line cannot be
+	// centered exactly, it leans to the left.
+	AlignCenter
+
+	// AlignRight aligns the content to the right of the box.
+	AlignRight
+)
+
 // BoxStyle is the style of the box.
 type BoxStyle struct {
 	// LineType is the type of the line.
@@ -47,6 +67,30 @@ type BoxStyle struct {
 	// Padding is the padding of the box.
 	// [Top, Right, Bottom, Left]
 	Padding [4]int
+
+	// Title is the text shown on the top border. Empty for none.
+	// Ignored if the top side is open.
+	Title string
+
+	// Alignment is the alignment of the lines of the content.
+	Alignment BoxAlignment
+
+	// OpenSides are the sides that have no border.
+	// [Top, Right, Bottom, Left]
+	OpenSides [4]bool
+
+	// MaxWidth is the maximum number of terminal columns of a line of the
+	// content, without the padding and the borders. Longer lines are wrapped.
+	// Zero for no limit.
+	MaxWidth int
+
+	// HardWrap is whether lines longer than MaxWidth are cut regardless of
+	// words instead of being broken at spaces.
+	HardWrap bool
+
+	// Graphemes is whether the content is measured by grapheme cluster
+	// instead of by character. See RuneTable.SetGraphemeMode.
+	Graphemes bool
 }
 
 // NewBoxStyle creates a new box style.
@@ -80,18 +124,45 @@ func NewBoxStyle(line_type BoxBorderType, is_heavy bool, padding [4]int) *BoxSty
 //
 // Returns:
 //   - [4]rune: The corners. [TopLeft, TopRight, BottomLeft, BottomRight]
+//
+// The corners of BtDouble are '╔', '╗', '╚' and '╝'.
 func (bs *BoxStyle) Corners() [4]rune {
 	var corners [4]rune
 
-	if bs.IsHeavy {
+	switch {
+	case bs.LineType == BtDouble:
+		corners = [4]rune{'╔', '╗', '╚', '╝'}
+	case bs.IsHeavy:
 		corners = [4]rune{'┏', '┓', '┗', '┛'}
-	} else {
+	default:
 		corners = [4]rune{'┌', '┐', '└', '┘'}
 	}
 
 	return corners
 }
 
+// Junctions gets the characters where the lines of a grid meet.
+//
+// Returns:
+//   - [5]rune: The junctions. [TopTee, BottomTee, LeftTee, RightTee, Cross]
+//
+// For example, the light junctions are '┬', '┴', '├', '┤' and '┼', and the
+// junctions of BtDouble are '╦', '╩', '╠', '╣' and '╬'.
+func (bs *BoxStyle) Junctions() [5]rune {
+	var junctions [5]rune
+
+	switch {
+	case bs.LineType == BtDouble:
+		junctions = [5]rune{'╦', '╩', '╠', '╣', '╬'}
+	case bs.IsHeavy:
+		junctions = [5]rune{'┳', '┻', '┣', '┫', '╋'}
+	default:
+		junctions = [5]rune{'┬', '┴', '├', '┤', '┼'}
+	}
+
+	return junctions
+}
+
 // TopBorder gets the top border of the box.
 //
 // It also applies to the bottom border as they are the same.
@@ -166,85 +237,105 @@ func (bs *BoxStyle) SideBorder() rune {
 	return side_border
 }
 
-// make_empty_row is a helper function to make an empty row.
+// make_side_padding is a helper function to make side padding.
 //
 // Parameters:
-//   - width: The width of the row.
-//   - side_border: The side border of the row.
+//   - width: The width of the padding.
 //
 // Returns:
-//   - []rune: The empty row.
-//
-// Assertions:
-//   - width >= 0
-//   - side_border != 0
-func make_empty_row(width int, side_border rune) []rune {
+//   - []rune: The side padding.
+func make_side_padding(width int) []rune {
 	// dbg.AssertParam("width", width >= 0, luc.NewErrGTE(0))
-	// dbg.AssertParam("side_border", side_border != 0, errors.New("side_border cannot be \\0"))
 
-	empty_row := make([]rune, 0, width)
+	side_padding := make([]rune, 0, width)
 	for i := 0; i < width; i++ {
-		empty_row = append(empty_row, ' ')
+		side_padding = append(side_padding, ' ')
 	}
 
-	empty_row = append([]rune{side_border}, empty_row...)
-	empty_row = append(empty_row, side_border)
-
-	return empty_row
+	return side_padding
 }
 
-// make_side_padding is a helper function to make side padding.
+// make_border is a helper function to make a top or bottom border, without
+// its corners.
 //
 // Parameters:
-//   - width: The width of the padding.
+//   - width: The width of the border.
+//   - border: The border character.
 //
 // Returns:
-//   - []rune: The side padding.
-func make_side_padding(width int) []rune {
-	// dbg.AssertParam("width", width >= 0, luc.NewErrGTE(0))
-
-	side_padding := make([]rune, 0, width)
+//   - []rune: The border.
+func make_border(width int, border rune) []rune {
+	row := make([]rune, 0, width)
 	for i := 0; i < width; i++ {
-		side_padding = append(side_padding, ' ')
+		row = append(row, border)
 	}
 
-	return side_padding
+	return row
 }
 
-// make_tb_border is a helper function to make a top or bottom border.
+// make_title_border is a helper function to make a top border with a title.
+//
+// Format: "─ title ───", between the corners.
 //
 // Parameters:
-//   - width: The width of the border.
+//   - width: The width of the border, without the corners.
 //   - border: The border character.
-//   - left_corner: The left corner character.
-//   - right_corner: The right corner character.
+//   - title: The title.
+//   - by_grapheme: Whether the title is measured by grapheme cluster.
 //
 // Returns:
-//   - []rune: The top or bottom border.
+//   - []rune: The top border, without the corners.
 //
 // Assertions:
-//   - width >= 0
-//   - border != 0
-//   - left_corner != 0
-//   - right_corner != 0
-func make_tb_border(width int, border, left_corner, right_corner rune) []rune {
-	// dbg.AssertParam("width", width >= 0, luc.NewErrGTE(0))
-	// dbg.AssertParam("border", border != 0, errors.New("border cannot be \\0"))
-	// dbg.AssertParam("left_corner", left_corner != 0, errors.New("left_corner cannot be \\0"))
-	// dbg.AssertParam("right_corner", right_corner != 0, errors.New("right_corner cannot be \\0"))
+//   - width >= display_width(title, by_grapheme) + 3
+func make_title_border(width int, border rune, title []rune, by_grapheme bool) []rune {
+	row := make([]rune, 0, width)
 
-	row := make([]rune, 0, width+2)
+	row = append(row, border, ' ')
+	row = append(row, title...)
+	row = append(row, ' ')
 
-	row = append(row, left_corner)
-	for i := 0; i < width; i++ {
+	for i := display_width(title, by_grapheme) + 3; i < width; i++ {
 		row = append(row, border)
 	}
 
-	row = append(row, right_corner)
-
 	return row
 }
 
+// align_rows is a helper function that aligns the rows of a table, whose
+// right edge is already aligned, by moving their trailing spaces.
+//
+// Parameters:
+//   - table: The table to align.
+//   - alignment: The alignment.
+func align_rows(table *RuneTable, alignment BoxAlignment) {
+	if alignment != AlignCenter && alignment != AlignRight {
+		return
+	}
+
+	for i, row := range table.table {
+		end := len(row)
+		for end > 0 && row[end-1] == ' ' {
+			end--
+		}
+
+		shift := len(row) - end
+		if alignment == AlignCenter {
+			shift /= 2
+		}
+
+		if shift == 0 {
+			continue
+		}
+
+		new_row := make([]rune, 0, len(row))
+		new_row = append(new_row, make_side_padding(shift)...)
+		new_row = append(new_row, row[:len(row)-shift]...)
+
+		table.table[i] = new_row
+	}
+}
+
 // DrawBox draws a box around the content.
 //
 // Format: If the content is ["Hello", "World"], the box will be:
@@ -263,7 +354,44 @@ func make_tb_border(width int, border, left_corner, right_corner rune) []rune {
 //
 // Behaviors:
 //   - If the box style is nil, the default box style will be used.
+//   - Lines wider than MaxWidth are wrapped before the box is drawn. Widths
+//     are in terminal columns; see DisplayWidth.
+//   - If the title does not fit on the top border, the box is widened.
+//   - Open sides have neither a border nor corners; the padding on those
+//     sides is kept.
 func (bs *BoxStyle) ApplyStrings(content []string) (*RuneTable, error) {
+	table, err := NewRuneTable(content)
+	if err != nil {
+		return nil, err
+	}
+
+	return bs.Apply(table)
+}
+
+// Apply draws a box around the content of a table, as ApplyStrings does.
+//
+// Parameters:
+//   - table: The content. It is modified to become the box.
+//
+// Returns:
+//   - *RuneTable: The table, in a box.
+//   - error: An error if the content could not be processed.
+//
+// Errors:
+//   - *errors.ErrInvalidParameter: If the table is nil.
+//   - error: If the title is not valid UTF-8.
+//
+// The tags of the rows of the table are kept; the rows added for the padding
+// and the borders have none.
+func (bs *BoxStyle) Apply(table *RuneTable) (*RuneTable, error) {
+	if table == nil {
+		return nil, gcers.NewErrNilParameter("table")
+	}
+
+	if bs == nil {
+		bs = DefaultBoxStyle
+	}
+
 	for i := 0; i < 4; i++ {
 		if bs.Padding[i] < 0 {
 			bs.Padding[i] = 0
@@ -275,21 +403,36 @@ func (bs *BoxStyle) ApplyStrings(content []string) (*RuneTable, error) {
 	right_padding := make_side_padding(bs.Padding[1])
 	tbb_char := bs.TopBorder()
 	corners := bs.Corners()
-	prefix := append([]rune{side_border}, left_padding...)
-	suffix := append(right_padding, side_border)
 
-	table, err := NewRuneTable(content)
+	title, err := gcch.StringToUtf8(bs.Title)
 	if err != nil {
 		return nil, err
 	}
 
+	table.SetGraphemeMode(bs.Graphemes)
+
+	if bs.HardWrap {
+		table.HardWrap(bs.MaxWidth)
+	} else {
+		table.Wrap(bs.MaxWidth)
+	}
+
 	right_edge := table.AlignRightEdge()
 
-	total_width := right_edge + bs.Padding[1] + bs.Padding[3]
-	empty_row := make_empty_row(total_width, side_border)
+	if len(title) > 0 && !bs.OpenSides[0] {
+		// The title needs one border character and a space on each side.
+		min_edge := display_width(title, bs.Graphemes) + 3 - bs.Padding[1] - bs.Padding[3]
+
+		if right_edge < min_edge {
+			table.SuffixEachRow(make_side_padding(min_edge - right_edge))
+			right_edge = min_edge
+		}
+	}
 
-	top_border := make_tb_border(total_width, tbb_char, corners[0], corners[1])
-	bottom_border := make_tb_border(total_width, tbb_char, corners[2], corners[3])
+	align_rows(table, bs.Alignment)
+
+	total_width := right_edge + bs.Padding[1] + bs.Padding[3]
+	empty_row := make_side_padding(right_edge)
 
 	for i := 0; i < bs.Padding[0]; i++ {
 		table.PrependTopRow(empty_row)
@@ -298,9 +441,41 @@ func (bs *BoxStyle) ApplyStrings(content []string) (*RuneTable, error) {
 	for i := 0; i < bs.Padding[2]; i++ {
 		table.AppendBottomRow(empty_row)
 	}
-	table.PrefixEachRow(prefix)
-	table.SuffixEachRow(suffix)
-	table.PrependTopRow(top_border)
-	table.AppendBottomRow(bottom_border)
+
+	table.PrefixEachRow(left_padding)
+	table.SuffixEachRow(right_padding)
+
+	var top_border, bottom_border []rune
+
+	if len(title) > 0 {
+		top_border = make_title_border(total_width, tbb_char, title, bs.Graphemes)
+	} else {
+		top_border = make_border(total_width, tbb_char)
+	}
+
+	bottom_border = make_border(total_width, tbb_char)
+
+	if !bs.OpenSides[3] {
+		table.PrefixEachRow([]rune{side_border})
+
+		top_border = append([]rune{corners[0]}, top_border...)
+		bottom_border = append([]rune{corners[2]}, bottom_border...)
+	}
+
+	if !bs.OpenSides[1] {
+		table.SuffixEachRow([]rune{side_border})
+
+		top_border = append(top_border, corners[1])
+		bottom_border = append(bottom_border, corners[3])
+	}
+
+	if !bs.OpenSides[0] {
+		table.PrependTopRow(top_border)
+	}
+
+	if !bs.OpenSides[2] {
+		table.AppendBottomRow(bottom_border)
+	}
+
 	return table, nil
 }
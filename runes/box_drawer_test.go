@@ -0,0 +1,122 @@
+package runes
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestApplyStrings(t *testing.T) {
+	table, err := DefaultBoxStyle.ApplyStrings([]string{"x"})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	const expected = "┌───┐\n│   │\n│ x │\n│   │\n└───┘\n"
+	if table.String() != expected {
+		t.Errorf("expected:\n%s\ngot:\n%s", expected, table.String())
+	}
+
+	bs := NewBoxStyle(BtNormal, false, [4]int{0, 1, 0, 1})
+	bs.Title = "Log"
+	bs.Alignment = AlignRight
+	bs.OpenSides[2] = true
+
+	table, err = bs.ApplyStrings([]string{"a", "bc"})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	// The title widens the content to 4 columns.
+	const titled = "┌─ Log ┐\n│    a │\n│   bc │\n"
+	if table.String() != titled {
+		t.Errorf("expected:\n%s\ngot:\n%s", titled, table.String())
+	}
+}
+
+func TestApplyStringsMaxWidth(t *testing.T) {
+	bs := NewBoxStyle(BtNormal, false, [4]int{0, 1, 0, 1})
+	bs.MaxWidth = 6
+
+	table, err := bs.ApplyStrings([]string{"漢字 are wide", "abcdefghij"})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	const expected = "┌────────┐\n│ 漢字   │\n│ are    │\n│ wide   │\n│ abcdef │\n│ ghij   │\n└────────┘\n"
+	if table.String() != expected {
+		t.Errorf("expected:\n%s\ngot:\n%s", expected, table.String())
+	}
+}
+
+func TestApplyStringsANSI(t *testing.T) {
+	bs := NewBoxStyle(BtNormal, false, [4]int{0, 1, 0, 1})
+	bs.MaxWidth = 5
+
+	table, err := bs.ApplyStrings([]string{"\x1b[31mred text\x1b[0m", "plain"})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	const expected = "┌───────┐\n" +
+		"│ \x1b[31mred\x1b[0m   │\n" +
+		"│ \x1b[31mtext\x1b[0m  │\n" +
+		"│ plain │\n" +
+		"└───────┘\n"
+
+	if table.String() != expected {
+		t.Errorf("expected:\n%q\ngot:\n%q", expected, table.String())
+	}
+}
+
+func TestGridStyleApply(t *testing.T) {
+	gs := &GridStyle{Padding: 1}
+
+	table, err := gs.Apply([][]string{{"a", "bb"}, {"ccc"}, {"wide text", "x\ny"}}, []int{0, 3})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	const expected = "┌───────────┬─────┐\n" +
+		"│ a         │ bb  │\n" +
+		"├───────────┼─────┤\n" +
+		"│ ccc       │     │\n" +
+		"├───────────┼─────┤\n" +
+		"│ wide text │ x   │\n" +
+		"│           │ y   │\n" +
+		"└───────────┴─────┘\n"
+
+	if table.String() != expected {
+		t.Errorf("expected:\n%s\ngot:\n%s", expected, table.String())
+	}
+
+	table, err = gs.Apply([][]string{{"well-known"}}, []int{5})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	const hyphenated = "┌───────┐\n" +
+		"│ well- │\n" +
+		"│ known │\n" +
+		"└───────┘\n"
+
+	if table.String() != hyphenated {
+		t.Errorf("expected:\n%s\ngot:\n%s", hyphenated, table.String())
+	}
+
+	double := &GridStyle{Lines: &BoxStyle{LineType: BtDouble}}
+
+	table, err = double.Apply([][]string{{"a", "b"}, {"c", "d"}}, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	lines := strings.Split(table.String(), "\n")
+	if lines[0] != "╔═╦═╗" || lines[2] != "╠═╬═╣" || lines[4] != "╚═╩═╝" {
+		t.Errorf("expected double corners and junctions, got:\n%s", table.String())
+	}
+
+	_, err = gs.Apply([][]string{{"a"}}, []int{-1})
+	if err == nil {
+		t.Errorf("expected an error for a negative width")
+	}
+}
@@ -0,0 +1,101 @@
+package runes
+
+import (
+	"unicode"
+)
+
+const (
+	// SoftHyphen is the soft hyphen character (U+00AD). It is invisible unless a
+	// line is broken right after it.
+	SoftHyphen rune = '\u00AD'
+)
+
+// is_hyphen checks whether the character is a hyphen after which a line may be
+// broken.
+//
+// Parameters:
+//   - char: The character to check.
+//
+// Returns:
+//   - bool: True if the character is a hyphen, false otherwise.
+func is_hyphen(char rune) bool {
+	switch char {
+	case '-', SoftHyphen, '\u2010', '\u2013':
+		return true
+	default:
+		return false
+	}
+}
+
+// is_ideographic checks whether the character is an ideographic character
+// between which lines may be broken freely.
+//
+// Parameters:
+//   - char: The character to check.
+//
+// Returns:
+//   - bool: True if the character is ideographic, false otherwise.
+func is_ideographic(char rune) bool {
+	return unicode.In(char, unicode.Han, unicode.Hiragana, unicode.Katakana)
+}
+
+// BreakOpportunities returns the positions at which the line may be broken
+// when wrapping. A position i means that the line may be broken between
+// line[i-1] and line[i].
+//
+// Parameters:
+//   - line: The line to analyze.
+//
+// Returns:
+//   - []int: The break positions in ascending order. Nil if there are none.
+//
+// This is a simplified version of the Unicode line breaking algorithm (UAX #14).
+// A line may be broken:
+//   - after a run of spaces, before the next non-space character.
+//   - after a hyphen (including the soft hyphen) that is between two letters or
+//     digits.
+//   - after a slash that is followed by a non-space character.
+//   - before and after ideographic characters (e.g., Han, Hiragana, Katakana),
+//     except before punctuation.
+//
+// A line is never broken at its start or end.
+func BreakOpportunities(line []rune) []int {
+	var positions []int
+
+	for i := 1; i < len(line); i++ {
+		prev := line[i-1]
+		curr := line[i]
+
+		var ok bool
+
+		switch {
+		case unicode.IsSpace(curr):
+			ok = false
+		case unicode.IsSpace(prev):
+			ok = true
+		case is_hyphen(prev):
+			ok = i >= 2 && is_alnum(line[i-2]) && is_alnum(curr)
+		case prev == '/':
+			ok = true
+		case is_ideographic(prev) || is_ideographic(curr):
+			ok = !unicode.IsPunct(curr)
+		}
+
+		if ok {
+			positions = append(positions, i)
+		}
+	}
+
+	return positions
+}
+
+// is_alnum checks whether the character is a letter or a digit.
+//
+// Parameters:
+//   - char: The character to check.
+//
+// Returns:
+//   - bool: True if the character is a letter or a digit, false otherwise.
+func is_alnum(char rune) bool {
+	return unicode.IsLetter(char) || unicode.IsDigit(char)
+}
@@ -0,0 +1,123 @@
+package runes
+
+import (
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/text/cases"
+	"golang.org/x/text/language"
+)
+
+// TitleCase converts the characters to title case; that is, the first letter of
+// each word is title-cased and the remaining letters are lower-cased.
+//
+// Parameters:
+//   - chars: The characters to convert.
+//
+// Returns:
+//   - []rune: The title-cased characters. Nil if chars is empty.
+//
+// Multi-rune case mappings are honored; for instance, "ß" at the start of a word
+// becomes "Ss". Thus, the result may be longer than the input.
+func TitleCase(chars []rune) []rune {
+	if len(chars) == 0 {
+		return nil
+	}
+
+	caser := cases.Title(language.Und)
+
+	str := caser.String(string(chars))
+
+	return []rune(str)
+}
+
+// SentenceCase converts the characters to sentence case; that is, the first letter
+// is title-cased and every other letter is lower-cased.
+//
+// Parameters:
+//   - chars: The characters to convert.
+//
+// Returns:
+//   - []rune: The sentence-cased characters. Nil if chars is empty.
+//
+// Multi-rune case mappings are honored. Thus, the result may be longer than the input.
+func SentenceCase(chars []rune) []rune {
+	if len(chars) == 0 {
+		return nil
+	}
+
+	caser := cases.Lower(language.Und)
+
+	lowered := []rune(caser.String(string(chars)))
+
+	idx := first_letter_index(lowered)
+	if idx == -1 {
+		return lowered
+	}
+
+	title := title_rune(lowered[idx])
+
+	result := make([]rune, 0, len(lowered)+len(title)-1)
+	result = append(result, lowered[:idx]...)
+	result = append(result, title...)
+	result = append(result, lowered[idx+1:]...)
+
+	return result
+}
+
+// CapitalizeFirst title-cases the first letter of the string and leaves the rest
+// untouched.
+//
+// Parameters:
+//   - s: The string to capitalize.
+//
+// Returns:
+//   - string: The capitalized string.
+//
+// Unlike unicode.ToUpper, multi-rune case mappings are honored (e.g., "ßa" becomes
+// "Ssa") and title-case forms are used for digraphs (e.g., "ǆ" becomes "ǅ").
+func CapitalizeFirst(s string) string {
+	for i, r := range s {
+		if !unicode.IsLetter(r) {
+			continue
+		}
+
+		size := utf8.RuneLen(r)
+
+		return s[:i] + string(title_rune(r)) + s[i+size:]
+	}
+
+	return s
+}
+
+// first_letter_index returns the index of the first letter in the characters.
+//
+// Parameters:
+//   - chars: The characters to search in.
+//
+// Returns:
+//   - int: The index of the first letter. -1 if there is no letter.
+func first_letter_index(chars []rune) int {
+	for i, c := range chars {
+		if unicode.IsLetter(c) {
+			return i
+		}
+	}
+
+	return -1
+}
+
+// title_rune returns the title-case mapping of a single rune.
+//
+// Parameters:
+//   - char: The rune to map.
+//
+// Returns:
+//   - []rune: The title-case mapping. It may contain more than one rune.
+func title_rune(char rune) []rune {
+	caser := cases.Title(language.Und, cases.NoLower)
+
+	str := caser.String(string(char))
+
+	return []rune(str)
+}
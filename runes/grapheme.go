@@ -0,0 +1,262 @@
+package runes
+
+import (
+	"unicode"
+	"unicode/utf8"
+
+	gcch "github.com/PlayerR9/go-commons/runes"
+)
+
+// grapheme_class is the class of a character as far as grapheme cluster
+// boundaries are concerned. See Unicode Standard Annex #29.
+type grapheme_class int
+
+const (
+	// gc_other is any character not in another class.
+	gc_other grapheme_class = iota
+
+	// gc_cr is the carriage return.
+	gc_cr
+
+	// gc_lf is the line feed.
+	gc_lf
+
+	// gc_control is a control or separator character.
+	gc_control
+
+	// gc_extend is a character that extends the previous one, such as a
+	// combining mark, a variation selector or an emoji modifier.
+	gc_extend
+
+	// gc_zwj is the zero width joiner.
+	gc_zwj
+
+	// gc_regional is a regional indicator, two of which make a flag.
+	gc_regional
+
+	// gc_spacing_mark is a spacing combining mark.
+	gc_spacing_mark
+
+	// gc_hangul_l is a leading Hangul jamo.
+	gc_hangul_l
+
+	// gc_hangul_v is a vowel Hangul jamo.
+	gc_hangul_v
+
+	// gc_hangul_t is a trailing Hangul jamo.
+	gc_hangul_t
+
+	// gc_hangul_lv is a Hangul syllable without a trailing jamo.
+	gc_hangul_lv
+
+	// gc_hangul_lvt is a Hangul syllable with a trailing jamo.
+	gc_hangul_lvt
+
+	// gc_pictographic is a pictographic character, such as an emoji.
+	gc_pictographic
+)
+
+// classify_grapheme returns the class of a character.
+//
+// Parameters:
+//   - char: The character.
+//
+// Returns:
+//   - grapheme_class: The class.
+//
+// Pictographic characters are approximated by the "other symbol" category and
+// the emoji planes, since the standard library has no Extended_Pictographic
+// table.
+func classify_grapheme(char rune) grapheme_class {
+	switch {
+	case char == '\r':
+		return gc_cr
+	case char == '\n':
+		return gc_lf
+	case char == 0x200D:
+		return gc_zwj
+	case char == 0x200C, char >= 0xE0020 && char <= 0xE007F, char >= 0x1F3FB && char <= 0x1F3FF:
+		return gc_extend
+	case char >= 0x1F1E6 && char <= 0x1F1FF:
+		return gc_regional
+	case unicode.In(char, unicode.Cc, unicode.Cf, unicode.Zl, unicode.Zp):
+		return gc_control
+	case unicode.In(char, unicode.Mn, unicode.Me):
+		return gc_extend
+	case unicode.Is(unicode.Mc, char):
+		return gc_spacing_mark
+	case char >= 0x1100 && char <= 0x115F, char >= 0xA960 && char <= 0xA97C:
+		return gc_hangul_l
+	case char >= 0x1160 && char <= 0x11A7, char >= 0xD7B0 && char <= 0xD7C6:
+		return gc_hangul_v
+	case char >= 0x11A8 && char <= 0x11FF, char >= 0xD7CB && char <= 0xD7FB:
+		return gc_hangul_t
+	case char >= 0xAC00 && char <= 0xD7A3:
+		if (char-0xAC00)%28 == 0 {
+			return gc_hangul_lv
+		}
+
+		return gc_hangul_lvt
+	case char >= 0x1F000 && char <= 0x1FAFF, unicode.Is(unicode.So, char):
+		return gc_pictographic
+	default:
+		return gc_other
+	}
+}
+
+// next_grapheme returns the length of the first grapheme cluster of a line.
+//
+// Parameters:
+//   - chars: The characters of the line.
+//
+// Returns:
+//   - int: The number of characters of the cluster. 0 if chars is empty.
+//
+// The rules are the ones of the extended grapheme clusters of Unicode Standard
+// Annex #29, except for prepended concatenation marks.
+func next_grapheme(chars []rune) int {
+	if len(chars) == 0 {
+		return 0
+	}
+
+	prev := classify_grapheme(chars[0])
+
+	// in_pictographic is true after a pictographic character followed by
+	// extending characters only; after_pictographic_zwj is true if, in
+	// addition, a zero width joiner followed.
+	in_pictographic := prev == gc_pictographic
+	var after_pictographic_zwj bool
+
+	var regionals int
+	if prev == gc_regional {
+		regionals = 1
+	}
+
+	i := 1
+
+	for ; i < len(chars); i++ {
+		curr := classify_grapheme(chars[i])
+
+		var joined bool
+
+		switch {
+		case prev == gc_cr && curr == gc_lf:
+			joined = true
+		case prev == gc_cr, prev == gc_lf, prev == gc_control:
+		case curr == gc_cr, curr == gc_lf, curr == gc_control:
+		case curr == gc_extend, curr == gc_zwj, curr == gc_spacing_mark:
+			joined = true
+		case prev == gc_hangul_l:
+			joined = curr == gc_hangul_l || curr == gc_hangul_v || curr == gc_hangul_lv || curr == gc_hangul_lvt
+		case (prev == gc_hangul_lv || prev == gc_hangul_v) && (curr == gc_hangul_v || curr == gc_hangul_t):
+			joined = true
+		case (prev == gc_hangul_lvt || prev == gc_hangul_t) && curr == gc_hangul_t:
+			joined = true
+		case prev == gc_zwj && curr == gc_pictographic:
+			joined = after_pictographic_zwj
+		case prev == gc_regional && curr == gc_regional:
+			joined = regionals%2 == 1
+		}
+
+		if !joined {
+			break
+		}
+
+		after_pictographic_zwj = curr == gc_zwj && in_pictographic
+
+		switch curr {
+		case gc_pictographic:
+			in_pictographic = true
+		case gc_extend:
+		default:
+			in_pictographic = false
+		}
+
+		if curr == gc_regional {
+			regionals++
+		}
+
+		prev = curr
+	}
+
+	return i
+}
+
+// Graphemes splits a string into its grapheme clusters; that is, into what
+// users perceive as characters, such as a letter with its accents, an emoji
+// sequence or a flag.
+//
+// Parameters:
+//   - s: The string to split.
+//
+// Returns:
+//   - [][]rune: The clusters, in order. Nil if s is empty.
+//   - error: An error if s is not valid UTF-8.
+//
+// Errors:
+//   - *runes.ErrInvalidUTF8Encoding: If s is not valid UTF-8.
+//
+// Example:
+//
+//	clusters, _ := Graphemes("e\u0301\U0001F44D\U0001F3FD")
+//	// [[U+0065 U+0301] [U+1F44D U+1F3FD]]
+func Graphemes(s string) ([][]rune, error) {
+	// StringToUtf8 is not used as it drops the "\r" of "\r\n", which is a
+	// cluster of its own.
+	if !utf8.ValidString(s) {
+		for i, c := range s {
+			if c != utf8.RuneError {
+				continue
+			}
+
+			_, size := utf8.DecodeRuneInString(s[i:])
+			if size == 1 {
+				return nil, gcch.NewErrInvalidUTF8Encoding(i)
+			}
+		}
+	}
+
+	chars := []rune(s)
+
+	var clusters [][]rune
+
+	for i := 0; i < len(chars); {
+		n := next_grapheme(chars[i:])
+
+		clusters = append(clusters, chars[i:i+n:i+n])
+		i += n
+	}
+
+	return clusters, nil
+}
+
+// GraphemeWidth returns the number of terminal columns a grapheme cluster
+// occupies.
+//
+// Parameters:
+//   - cluster: The characters of the cluster.
+//
+// Returns:
+//   - int: The width of the first character of the cluster, see RuneWidth,
+//     or 2 for flags and for clusters that ask for an emoji presentation.
+//
+// Unlike DisplayWidth, the characters that follow the first one, such as
+// joined emoji, do not add to the width.
+func GraphemeWidth(cluster []rune) int {
+	if len(cluster) == 0 {
+		return 0
+	}
+
+	if len(cluster) > 1 && classify_grapheme(cluster[0]) == gc_regional && classify_grapheme(cluster[1]) == gc_regional {
+		return 2
+	}
+
+	for _, char := range cluster[1:] {
+		// U+FE0F is the emoji presentation selector.
+		if char == 0xFE0F {
+			return 2
+		}
+	}
+
+	return RuneWidth(cluster[0])
+}
@@ -0,0 +1,89 @@
+package runes
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestGraphemes(t *testing.T) {
+	tests := []struct {
+		input  string
+		widths []int
+	}{
+		{"e\u0301x", []int{1, 1}},
+		{"\U0001F44D\U0001F3FD!", []int{2, 1}},
+		{"\U0001F468\u200d\U0001F469\u200d\U0001F467.", []int{2, 1}},
+		{"\U0001F1EB\U0001F1F7\U0001F1E9\U0001F1EA\U0001F1EC", []int{2, 2, 1}},
+		{"\r\na", []int{0, 1}},
+		{"\u2764\ufe0f", []int{2}},
+		{"각", []int{2}},
+	}
+
+	for _, test := range tests {
+		clusters, err := Graphemes(test.input)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err.Error())
+		}
+
+		if len(clusters) != len(test.widths) {
+			t.Errorf("%q: expected %d clusters, got %d", test.input, len(test.widths), len(clusters))
+			continue
+		}
+
+		for i, cluster := range clusters {
+			if GraphemeWidth(cluster) != test.widths[i] {
+				t.Errorf("%q: expected cluster %d to be %d wide, got %d", test.input, i, test.widths[i], GraphemeWidth(cluster))
+			}
+		}
+	}
+}
+
+func TestGraphemesNewlines(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected []string
+	}{
+		{"\r\na", []string{"\r\n", "a"}},
+		{"\r", []string{"\r"}},
+		{"a\rb", []string{"a", "\r", "b"}},
+		{"\r\r\n", []string{"\r", "\r\n"}},
+		{"\n\r", []string{"\n", "\r"}},
+	}
+
+	for _, test := range tests {
+		clusters, err := Graphemes(test.input)
+		if err != nil {
+			t.Fatalf("%q: unexpected error: %s", test.input, err.Error())
+		}
+
+		got := make([]string, 0, len(clusters))
+
+		for _, cluster := range clusters {
+			got = append(got, string(cluster))
+		}
+
+		if !slices.Equal(got, test.expected) {
+			t.Errorf("%q: expected %q, got %q", test.input, test.expected, got)
+		}
+	}
+
+	_, err := Graphemes("a\xffb")
+	if err == nil {
+		t.Errorf("expected an error for invalid UTF-8")
+	}
+}
+
+func TestApplyStringsGraphemes(t *testing.T) {
+	bs := NewBoxStyle(BtNormal, false, [4]int{0, 1, 0, 1})
+	bs.Graphemes = true
+
+	table, err := bs.ApplyStrings([]string{"\U0001F468\u200d\U0001F469\u200d\U0001F467", "e\u0301te\u0301"})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	const expected = "┌─────┐\n│ \U0001F468\u200d\U0001F469\u200d\U0001F467  │\n│ e\u0301te\u0301 │\n└─────┘\n"
+	if table.String() != expected {
+		t.Errorf("expected:\n%s\ngot:\n%s", expected, table.String())
+	}
+}
@@ -0,0 +1,253 @@
+package runes
+
+import (
+	"strings"
+
+	gcers "github.com/PlayerR9/go-commons/errors"
+	gcint "github.com/PlayerR9/go-commons/ints"
+	gcch "github.com/PlayerR9/go-commons/runes"
+)
+
+// GridStyle is the style of a grid; that is, of a table whose cells are
+// separated by lines.
+type GridStyle struct {
+	// Lines gives the characters of the lines. Only its LineType and IsHeavy
+	// are used. Nil for DefaultBoxStyle.
+	Lines *BoxStyle
+
+	// Padding is the number of spaces on each side of the content of a cell.
+	Padding int
+
+	// Alignment is the alignment of the content of the cells.
+	Alignment BoxAlignment
+}
+
+// grid_cell_lines splits the content of a cell into the lines it occupies.
+//
+// Parameters:
+//   - content: The content of the cell.
+//   - width: The width of the column. Zero for no limit.
+//
+// Returns:
+//   - [][]rune: The lines. Never empty.
+//   - error: An error if the content is not valid UTF-8.
+func grid_cell_lines(content string, width int) ([][]rune, error) {
+	var lines [][]rune
+
+	for _, line := range strings.Split(content, "\n") {
+		chars, err := gcch.StringToUtf8(line)
+		if err != nil {
+			return nil, err
+		}
+
+		if width > 0 && DisplayWidth(chars) > width {
+			lines = append(lines, WrapDisplay(chars, width)...)
+		} else {
+			lines = append(lines, chars)
+		}
+	}
+
+	return lines, nil
+}
+
+// grid_separator makes a horizontal line of a grid.
+//
+// Parameters:
+//   - widths: The widths of the columns, padding included.
+//   - border: The border character.
+//   - left: The character on the left side.
+//   - junction: The character between two columns.
+//   - right: The character on the right side.
+//
+// Returns:
+//   - []rune: The line.
+func grid_separator(widths []int, border, left, junction, right rune) []rune {
+	row := []rune{left}
+
+	for i, width := range widths {
+		if i > 0 {
+			row = append(row, junction)
+		}
+
+		row = append(row, make_border(width, border)...)
+	}
+
+	row = append(row, right)
+
+	return row
+}
+
+// Apply draws a grid whose cells hold the given strings.
+//
+// Format: If the rows are [["a", "bb"], ["ccc", "d"]], the grid will be:
+//
+//	┌─────┬────┐
+//	│ a   │ bb │
+//	├─────┼────┤
+//	│ ccc │ d  │
+//	└─────┴────┘
+//
+// Parameters:
+//   - rows: The content of the cells, row by row. Rows with fewer cells than
+//     the grid has columns are completed with empty cells.
+//   - colWidths: The widths of the columns, in terminal columns and without
+//     the padding. Missing or zero widths are those of the widest cell of the
+//     column.
+//
+// Returns:
+//   - *RuneTable: The grid.
+//   - error: An error if the content could not be processed.
+//
+// Errors:
+//   - *errors.ErrInvalidParameter: If a width is negative.
+//   - *ints.ErrAt: If a cell is not valid UTF-8. Its index is the 1-based
+//     number of the row.
+//
+// Behaviors:
+//   - If the grid style is nil, a style with the default box style and a
+//     padding of 1 is used.
+//   - Cells may span several lines: their content is split at newlines and
+//     lines wider than the column are wrapped. A row is as tall as its tallest
+//     cell.
+//   - Widths are in terminal columns; see DisplayWidth.
+//   - A grid without columns is an empty table.
+func (gs *GridStyle) Apply(rows [][]string, colWidths []int) (*RuneTable, error) {
+	if gs == nil {
+		gs = &GridStyle{
+			Padding: 1,
+		}
+	}
+
+	for _, width := range colWidths {
+		if width < 0 {
+			return nil, gcers.NewErrInvalidParameter("colWidths", gcint.NewErrGTE(0))
+		}
+	}
+
+	lines := gs.Lines
+	if lines == nil {
+		lines = DefaultBoxStyle
+	}
+
+	padding := max(gs.Padding, 0)
+
+	n_cols := len(colWidths)
+
+	for _, row := range rows {
+		n_cols = max(n_cols, len(row))
+	}
+
+	if n_cols == 0 {
+		return &RuneTable{}, nil
+	}
+
+	widths := make([]int, n_cols)
+	copy(widths, colWidths)
+
+	// cells are the lines of each cell, row by row.
+	cells := make([][][][]rune, 0, len(rows))
+
+	for i, row := range rows {
+		row_cells := make([][][]rune, n_cols)
+
+		for j := range row_cells {
+			var content string
+
+			if j < len(row) {
+				content = row[j]
+			}
+
+			cell, err := grid_cell_lines(content, widths[j])
+			if err != nil {
+				return nil, gcint.NewErrAt(i+1, "row", err)
+			}
+
+			row_cells[j] = cell
+		}
+
+		cells = append(cells, row_cells)
+	}
+
+	for j := range widths {
+		if j < len(colWidths) && colWidths[j] > 0 {
+			continue
+		}
+
+		for _, row_cells := range cells {
+			for _, line := range row_cells[j] {
+				widths[j] = max(widths[j], DisplayWidth(line))
+			}
+		}
+	}
+
+	outer := make([]int, 0, n_cols)
+
+	for _, width := range widths {
+		outer = append(outer, width+2*padding)
+	}
+
+	border := lines.TopBorder()
+	side := lines.SideBorder()
+	corners := lines.Corners()
+	junctions := lines.Junctions()
+
+	side_padding := make_side_padding(padding)
+
+	table := [][]rune{grid_separator(outer, border, corners[0], junctions[0], corners[1])}
+
+	for i, row_cells := range cells {
+		if i > 0 {
+			table = append(table, grid_separator(outer, border, junctions[2], junctions[4], junctions[3]))
+		}
+
+		var height int
+
+		for _, cell := range row_cells {
+			height = max(height, len(cell))
+		}
+
+		for k := 0; k < height; k++ {
+			line := []rune{side}
+
+			for j, cell := range row_cells {
+				if j > 0 {
+					line = append(line, side)
+				}
+
+				var content []rune
+
+				if k < len(cell) {
+					content = cell[k]
+				}
+
+				fill := max(widths[j]-DisplayWidth(content), 0)
+
+				var before int
+
+				switch gs.Alignment {
+				case AlignCenter:
+					before = fill / 2
+				case AlignRight:
+					before = fill
+				}
+
+				line = append(line, side_padding...)
+				line = append(line, make_side_padding(before)...)
+				line = append(line, content...)
+				line = append(line, make_side_padding(fill-before)...)
+				line = append(line, side_padding...)
+			}
+
+			line = append(line, side)
+			table = append(table, line)
+		}
+	}
+
+	table = append(table, grid_separator(outer, border, corners[2], junctions[1], corners[3]))
+
+	rt := &RuneTable{
+		table: table,
+	}
+
+	return rt, nil
+}
@@ -0,0 +1,197 @@
+package runes
+
+import (
+	"slices"
+)
+
+// JoinHorizontal lays tables out side by side, aligned on their top rows.
+//
+// Parameters:
+//   - tables: The tables, from left to right. Nil tables are skipped.
+//   - gap: The number of spaces between two tables. Negative gaps are set
+//     to 0.
+//
+// Returns:
+//   - *RuneTable: The joined table. Never nil.
+//
+// Format: If the tables are ["ab", "cd"] and ["x"], with a gap of 1, the
+// result is:
+//
+//	ab x
+//	cd
+//
+// Behaviors:
+//   - Every table is padded with spaces to its widest row and to the height
+//     of the tallest table, so that the columns line up; trailing spaces of
+//     the last table are kept.
+//   - The tables are not modified.
+//   - The result is in grapheme mode if any of the tables is.
+//   - A row of the result has the tags of the rows it is made of.
+func JoinHorizontal(tables []*RuneTable, gap int) *RuneTable {
+	if gap < 0 {
+		gap = 0
+	}
+
+	var height int
+	var by_grapheme bool
+
+	for _, table := range tables {
+		if table != nil {
+			height = max(height, len(table.table))
+			by_grapheme = by_grapheme || table.by_grapheme
+		}
+	}
+
+	rows := make([][]rune, height)
+	gap_padding := make_side_padding(gap)
+
+	var tags [][]string
+
+	is_first := true
+
+	for _, table := range tables {
+		if table == nil {
+			continue
+		}
+
+		width := table.RightMostEdge()
+
+		for i := range rows {
+			if !is_first {
+				rows[i] = append(rows[i], gap_padding...)
+			}
+
+			var row []rune
+			if i < len(table.table) {
+				row = table.table[i]
+			}
+
+			rows[i] = append(rows[i], row...)
+			rows[i] = append(rows[i], make_side_padding(width-table.width(row))...)
+		}
+
+		for i, row_tags := range table.tags {
+			if tags == nil {
+				tags = make([][]string, height)
+			}
+
+			tags[i] = merge_tags(tags[i], row_tags)
+		}
+
+		is_first = false
+	}
+
+	rt := &RuneTable{
+		table:       rows,
+		by_grapheme: by_grapheme,
+		tags:        tags,
+	}
+
+	return rt
+}
+
+// JoinVertical stacks tables on top of each other, aligned on their left
+// edges.
+//
+// Parameters:
+//   - tables: The tables, from top to bottom. Nil tables are skipped.
+//   - gap: The number of empty rows between two tables. Negative gaps are set
+//     to 0.
+//
+// Returns:
+//   - *RuneTable: The joined table. Never nil.
+//
+// Behaviors:
+//   - Every row is padded with spaces to the width of the widest row, so that
+//     the right edges line up.
+//   - The tables are not modified.
+//   - The result is in grapheme mode if any of the tables is.
+//   - Rows keep their tags.
+func JoinVertical(tables []*RuneTable, gap int) *RuneTable {
+	if gap < 0 {
+		gap = 0
+	}
+
+	var width int
+	var by_grapheme bool
+
+	for _, table := range tables {
+		if table != nil {
+			width = max(width, table.RightMostEdge())
+			by_grapheme = by_grapheme || table.by_grapheme
+		}
+	}
+
+	var rows [][]rune
+	var tags [][]string
+
+	is_first := true
+
+	for _, table := range tables {
+		if table == nil {
+			continue
+		}
+
+		if !is_first {
+			for i := 0; i < gap; i++ {
+				rows = append(rows, make_side_padding(width))
+			}
+		}
+
+		if table.tags != nil {
+			for len(tags) < len(rows) {
+				tags = append(tags, nil)
+			}
+
+			tags = append(tags, table.tags...)
+		}
+
+		for _, row := range table.table {
+			new_row := make([]rune, 0, width)
+			new_row = append(new_row, row...)
+			new_row = append(new_row, make_side_padding(width-table.width(row))...)
+
+			rows = append(rows, new_row)
+		}
+
+		is_first = false
+	}
+
+	if tags != nil {
+		for len(tags) < len(rows) {
+			tags = append(tags, nil)
+		}
+	}
+
+	rt := &RuneTable{
+		table:       rows,
+		by_grapheme: by_grapheme,
+		tags:        tags,
+	}
+
+	return rt
+}
+
+// merge_tags returns the union of two sorted sets of tags.
+//
+// Parameters:
+//   - a: The first set.
+//   - b: The second set.
+//
+// Returns:
+//   - []string: The union, sorted. It may share its storage with a or b.
+func merge_tags(a, b []string) []string {
+	if len(a) == 0 {
+		return b
+	} else if len(b) == 0 {
+		return a
+	}
+
+	merged := make([]string, 0, len(a)+len(b))
+	merged = append(merged, a...)
+	merged = append(merged, b...)
+
+	slices.Sort(merged)
+
+	return slices.Compact(merged)
+}
@@ -0,0 +1,28 @@
+package runes
+
+import (
+	"testing"
+)
+
+func TestJoin(t *testing.T) {
+	left, _ := NewRuneTable([]string{"ab", "c"})
+	right, _ := NewRuneTable([]string{"x", "y", "z"})
+
+	table := JoinHorizontal([]*RuneTable{left, nil, right}, 1)
+
+	const horizontal = "ab x\nc  y\n   z\n"
+	if table.String() != horizontal {
+		t.Errorf("expected %q, got %q", horizontal, table.String())
+	}
+
+	table = JoinVertical([]*RuneTable{left, right}, 1)
+
+	const vertical = "ab\nc \n  \nx \ny \nz \n"
+	if table.String() != vertical {
+		t.Errorf("expected %q, got %q", vertical, table.String())
+	}
+
+	if left.String() != "ab\nc\n" {
+		t.Errorf("expected the tables not to be modified, got %q", left.String())
+	}
+}
@@ -4,31 +4,65 @@ import (
 	"errors"
 	"fmt"
 	"strings"
-	"unicode/utf8"
 
 	// dbg "github.com/PlayerR9/lib_units/debug"
 	gcers "github.com/PlayerR9/go-commons/errors"
 	gcch "github.com/PlayerR9/go-commons/runes"
-	gcslc "github.com/PlayerR9/go-commons/slices"
+	luc "github.com/PlayerR9/lib_units/common"
 )
 
-// WordMatcher is the word matcher.
+// WordMatcher is the word matcher. It is a Trie whose words and inputs are
+// transformed by matching options. The zero value is an empty matcher that
+// compares words as they are.
 type WordMatcher struct {
-	// words is the list of words.
-	words [][]rune
+	// trie holds the words, as compared.
+	trie *Trie
+
+	// display maps the words, as compared, to the words as they were added.
+	display map[string]string
+
+	// cfg is how words and inputs are transformed before being compared.
+	cfg match_config
 }
 
 // NewWordMatcher returns a new WordMatcher.
 //
+// Parameters:
+//   - opts: How words and inputs are compared; for instance, WithFoldCase
+//     makes the matching case-insensitive.
+//
 // Returns:
 //   - *WordMatcher: The new WordMatcher. Never nil.
-func NewWordMatcher() *WordMatcher {
+//
+// Words and inputs are compared in decomposed form (NFD for NFC and NFKD for
+// NFKC) so that the input stream can be transformed one character at a time.
+// An input character may then stand for several characters of a word; for
+// instance, "ß" matches "ss" when folding case.
+func NewWordMatcher(opts ...MatchOption) *WordMatcher {
 	return &WordMatcher{
-		words: make([][]rune, 0),
+		trie:    NewTrie(),
+		display: make(map[string]string),
+		cfg:     new_match_config(opts).decomposed(),
+	}
+}
+
+// words returns the trie of the matcher, creating it if the matcher is the
+// zero value.
+//
+// Returns:
+//   - *Trie: The trie. Never nil.
+func (wm *WordMatcher) words() *Trie {
+	if wm.trie == nil {
+		wm.trie = NewTrie()
+		wm.display = make(map[string]string)
 	}
+
+	return wm.trie
 }
 
-// AddWord adds a word to the matcher. It ignores empty or duplicated words.
+// AddWord adds a word to the matcher. It ignores empty or duplicated words;
+// words are duplicates if they are the same once transformed by the options of
+// the matcher.
 //
 // Parameters:
 //   - word: The word to add.
@@ -43,234 +77,163 @@ func (wm *WordMatcher) AddWord(word string) error {
 		return nil
 	}
 
-	chars, err := gcch.StringToUtf8(word)
+	_, err := gcch.StringToUtf8(word)
 	if err != nil {
 		return err
 	}
 
-	// dbg.Assert(len(chars) > 0, "chars is empty")
-
-	var indices []int
+	key := wm.cfg.apply(word)
 
-	for i, w := range wm.words {
-		// dbg.Assert(len(w) > 0, "word is empty")
-
-		if len(w) == len(chars) && w[0] == chars[0] {
-			indices = append(indices, i)
-		}
+	ok := wm.words().AddWord(key)
+	if ok {
+		wm.display[key] = word
 	}
 
-	for i := 1; i < len(chars) && len(indices) > 0; i++ {
-		filter := func(idx int) bool {
-			return wm.words[idx][i] == chars[i]
-		}
+	return nil
+}
 
-		indices = gcslc.SliceFilter(indices, filter)
-	}
+// RemoveWord removes a word from the matcher.
+//
+// Parameters:
+//   - word: The word to remove. It is transformed by the options of the
+//     matcher.
+//
+// Returns:
+//   - bool: True if the word was removed, false if it was not in the matcher.
+func (wm *WordMatcher) RemoveWord(word string) bool {
+	key := wm.cfg.apply(word)
 
-	if len(indices) == 0 {
-		wm.words = append(wm.words, chars)
+	ok := wm.words().Remove(key)
+	if ok {
+		delete(wm.display, key)
 	}
 
-	return nil
+	return ok
 }
 
-// Match matches the input stream.
+// Match matches the input stream against the longest word that is a prefix
+// of it.
 //
 // Parameters:
 //   - is: The input stream to match.
 //
 // Returns:
-//   - string: The matched word.
+//   - string: The matched word, as it was added.
 //   - error: An error if the stream could not be matched.
 //
 // Errors:
-//   - *common.ErrAt: If the input stream is not a valid UTF-8 stream.
-func (wm *WordMatcher) Match(is CharStream) (string, error) {
-	if is == nil {
-		return "", gcers.NewErrNilParameter("is")
-	}
-
-	indices := make([]int, 0, len(wm.words))
-
-	for i := range wm.words {
-		indices = append(indices, i)
-	}
-
-	m := &matcher{
-		indices: indices,
-		pos:     0,
-		wm:      wm,
-	}
-
-	var next_count int
-
-	for {
-		char, ok := is.Peek() // Assume anything
-		if !ok {
-			word, err := m.get_sol()
-
-			size := utf8.RuneCountInString(word)
-			for i := size; i < next_count; i++ {
-				_ = is.Refuse()
-				// dbg.AssertOk(ok, "is.Refuse()")
-			}
-
-			if err != nil {
-				return "", err
-			}
-
-			return word, nil
-		}
-
-		ok = m.match(char)
-		if ok {
-			word, err := m.get_sol()
-
-			size := utf8.RuneCountInString(word)
-			for i := size; i < next_count; i++ {
-				_ = is.Refuse()
-				// dbg.AssertOk(ok, "is.Refuse()")
-			}
-
-			if err != nil {
-				return "", err
-			}
-
-			return word, nil
-		}
+//   - *common.ErrInvalidParameter: If the input stream is nil.
+//   - *common.ErrAtPosition: If no word is a prefix of the stream and the
+//     stream implements PositionTracker. The position is where the match
+//     started.
+//   - *common.ErrPanic: If matching panicked. The stream is then left in an
+//     unspecified state.
+//   - error: If no word is a prefix of the stream.
+//
+// Only the characters of the matched word are consumed.
+func (wm *WordMatcher) Match(is CharStream) (word string, err error) {
+	err = luc.Guard("WordMatcher.Match", func() error {
+		word, err = wm.match(is)
+		return err
+	})
 
-		// We can continue to match...
-		is.Next()
-		next_count++
-	}
+	return word, err
 }
 
-// get_word_at returns the word at the given index.
+// match is the body of Match.
 //
 // Parameters:
-//   - idx: The index of the word to return.
+//   - is: The input stream to match.
 //
 // Returns:
-//   - []rune: The word.
-//   - bool: True if the word was found, false otherwise.
-func (wm *WordMatcher) get_word_at(idx int) ([]rune, bool) {
-	if idx < 0 || idx >= len(wm.words) {
-		return nil, false
+//   - string: The matched word, as it was added.
+//   - error: An error if the stream could not be matched.
+func (wm *WordMatcher) match(is CharStream) (string, error) {
+	if is == nil {
+		return "", gcers.NewErrNilParameter("is")
 	}
 
-	return wm.words[idx], true
-}
-
-// matcher is a helper struct for controlling the matching process.
-type matcher struct {
-	// indices is the list of indices of the words that were matched.
-	indices []int
-
-	// pos is the current position in the stream.
-	pos int
-
-	// word is the current word being matched.
-	word strings.Builder
-
-	// wm is the WordMatcher that is being used.
-	wm *WordMatcher
-}
+	start, has_pos := position_of(is)
 
-// match is a helper function that matches the next character in the stream.
-//
-// Returns:
-//   - bool: True if the matching process cannot continue. False otherwise.
-func (m *matcher) match(char rune) bool {
-	// dbg.Assert(len(m.indices) > 0, "m.indices is empty")
+	var best string
+	var best_count int
 
-	var top int
+	count, consumed := wm.words().match(is, wm.cfg.apply_runes, func(word string, count int) {
+		best, best_count = word, count
+	})
 
-	for i := 0; i < len(m.indices); i++ {
-		idx := m.indices[i]
+	for i := best_count; i < count; i++ {
+		_ = is.Refuse()
+	}
 
-		word, _ := m.wm.get_word_at(idx)
-		// dbg.AssertOk(ok, "wm.get_word_at(%d)", idx)
+	if best_count == 0 {
+		var err error
 
-		if m.pos >= len(word) || word[m.pos] == char {
-			m.indices[top] = idx
-			top++
+		if consumed == "" {
+			err = errors.New("no matches found")
+		} else {
+			err = luc.NewLazyError(func() string {
+				return fmt.Sprintf("no matches found for %q", consumed)
+			})
 		}
-	}
 
-	if top == 0 {
-		return true
+		return "", at_position(start, has_pos, err)
 	}
 
-	m.pos++
-	m.indices = m.indices[:top]
-	m.word.WriteRune(char)
-
-	return false
+	return wm.display[best], nil
 }
 
-// get_sol is a helper function that returns the solution.
+// MatchPrefix returns every word that is a prefix of the input stream.
+//
+// Parameters:
+//   - is: The input stream.
 //
 // Returns:
-//   - string: The solution.
-//   - error: An error if the solution could not be found.
-func (m *matcher) get_sol() (string, error) {
-	var indices []int
-
-	if len(m.indices) > 0 {
-		// Try with the words that were not discarded yet.
-		for _, idx := range m.indices {
-			word, _ := m.wm.get_word_at(idx)
-			// dbg.AssertOk(ok, "wm.get_word_at(%d)", idx)
-
-			if len(word) <= m.pos {
-				indices = append(indices, idx)
-			}
-		}
-	}
-
-	if len(indices) == 0 {
-		if m.word.Len() == 0 {
-			return "", errors.New("no matches found")
-		} else {
-			return "", fmt.Errorf("no matches found for %q", m.word.String())
-		}
+//   - []string: The words, as they were added, shortest first. Nil if none.
+//   - error: An error if the stream is nil.
+//
+// Errors:
+//   - *common.ErrInvalidParameter: If the input stream is nil.
+//
+// The stream is left where it was; that is, no character is consumed.
+func (wm *WordMatcher) MatchPrefix(is CharStream) ([]string, error) {
+	if is == nil {
+		return nil, gcers.NewErrNilParameter("is")
 	}
 
-	// Find the longest matching word.
-
-	var max_len int
-	var max_idx []int
+	var words []string
 
-	for _, idx := range indices {
-		word, _ := m.wm.get_word_at(idx)
-		// dbg.AssertOk(ok, "wm.get_word_at(%d)", idx)
+	count, _ := wm.words().match(is, wm.cfg.apply_runes, func(word string, count int) {
+		words = append(words, wm.display[word])
+	})
 
-		if len(max_idx) == 0 || len(word) > max_len {
-			max_len = len(word)
-			max_idx = []int{idx}
-		} else if len(word) == max_len {
-			max_idx = append(max_idx, idx)
-		}
+	for i := 0; i < count; i++ {
+		_ = is.Refuse()
 	}
 
-	if len(max_idx) > 1 {
-		values := make([]string, 0, len(max_idx))
-
-		for _, idx := range max_idx {
-			word, _ := m.wm.get_word_at(idx)
-			// dbg.AssertOk(ok, "wm.get_word_at(%d)", idx)
-
-			values = append(values, string(word))
-		}
+	return words, nil
+}
 
-		return "", fmt.Errorf("multiple matches found for %q: %s", m.word.String(), strings.Join(values, ", "))
-	}
+// HasPrefix returns every word that starts with the given prefix.
+//
+// Parameters:
+//   - prefix: The prefix. It is transformed by the options of the matcher.
+//
+// Returns:
+//   - []string: The words, as they were added, in lexicographic order of
+//     their compared form. Nil if none.
+//   - bool: True if at least one word starts with the prefix.
+//
+// The empty prefix returns every word.
+func (wm *WordMatcher) HasPrefix(prefix string) ([]string, bool) {
+	var words []string
 
-	word, _ := m.wm.get_word_at(max_idx[0])
-	// dbg.AssertOk(ok, "wm.get_word_at(%d)", max_idx[0])
+	wm.words().Walk(wm.cfg.apply(prefix), func(word string) bool {
+		words = append(words, wm.display[word])
+		return true
+	})
 
-	return string(word), nil
+	return words, len(words) > 0
 }
 
 // MultiMatcher kinda works like WordMatcher but, unlike WordMatcher, it only matches a specific set of characters.
@@ -285,6 +248,11 @@ func (m *matcher) get_sol() (string, error) {
 //
 // Errors:
 //   - *common.ErrInvalidParameter: If the input stream is nil or the input characters are empty.
+//   - *common.ErrAtPosition: If a character does not match and the stream implements
+//     PositionTracker. The position is the one of the offending character.
+//   - error: If a character does not match.
+//
+// On failure, the characters matched so far are refused.
 func MultiMatcher(chars []rune, stream CharStream) (string, error) {
 	if stream == nil {
 		return "", gcers.NewErrNilParameter("stream")
@@ -296,25 +264,35 @@ func MultiMatcher(chars []rune, stream CharStream) (string, error) {
 	var size int
 
 	for _, c := range chars {
+		pos, has_pos := position_of(stream)
+
 		char, ok := stream.Peek()
 		if !ok {
-			for size > 0 {
+			for ; size > 0; size-- {
 				_ = stream.Refuse()
 				// dbg.Assert(ok, "stream.Refuse()")
 			}
 
-			return "", fmt.Errorf("expected '%c', got nothing instead", c)
+			err := luc.NewLazyError(func() string {
+				return fmt.Sprintf("expected '%c', got nothing instead", c)
+			})
+
+			return "", at_position(pos, has_pos, err)
 		}
 
 		builder.WriteRune(char)
 
 		if char != c {
-			for size > 0 {
+			for ; size > 0; size-- {
 				_ = stream.Refuse()
 				// dbg.Assert(ok, "stream.Refuse()")
 			}
 
-			return "", fmt.Errorf("expected '%c', got '%c' instead", c, char)
+			err := luc.NewLazyError(func() string {
+				return fmt.Sprintf("expected '%c', got '%c' instead", c, char)
+			})
+
+			return "", at_position(pos, has_pos, err)
 		}
 
 		stream.Next() // Consume the peeked char
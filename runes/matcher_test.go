@@ -1,6 +1,10 @@
 package runes
 
-import "testing"
+import (
+	"testing"
+
+	"golang.org/x/text/unicode/norm"
+)
 
 func TestDuplicates(t *testing.T) {
 	wm := NewWordMatcher()
@@ -68,3 +72,214 @@ func TestMiddleMatch(t *testing.T) {
 		t.Errorf("expected word to be 'foo', got '%s'", word)
 	}
 }
+
+func TestFoldCaseMatch(t *testing.T) {
+	wm := NewWordMatcher(WithFoldCase())
+
+	for _, word := range []string{"Select", "SELECT", "From"} {
+		err := wm.AddWord(word)
+		if err != nil {
+			t.Errorf("error adding word: %s", err.Error())
+		}
+	}
+
+	is := NewStream([]rune("sElEcT *"))
+
+	word, err := wm.Match(is)
+	if err != nil {
+		t.Fatalf("error matching word: %s", err.Error())
+	}
+
+	if word != "Select" {
+		t.Errorf("expected word to be 'Select', got '%s'", word)
+	}
+
+	lt, err := NewLevenshteinTable("Hello", "World")
+	if err != nil {
+		t.Fatalf("error creating table: %s", err.Error())
+	}
+
+	lt.SetMatchOptions(WithFoldCase())
+
+	ranked, err := lt.ClosestN([]rune("HELLO"), 1)
+	if err != nil {
+		t.Fatalf("error ranking words: %s", err.Error())
+	}
+
+	if ranked[0].Word != "Hello" || ranked[0].Distance != 0 {
+		t.Errorf("expected Hello at distance 0, got %v", ranked[0])
+	}
+}
+
+func TestFoldCaseExpansion(t *testing.T) {
+	wm := NewWordMatcher(WithFoldCase())
+
+	err := wm.AddWord("Straße")
+	if err != nil {
+		t.Fatalf("error adding word: %s", err.Error())
+	}
+
+	for _, input := range []string{"straße!", "STRASSE!"} {
+		is := NewStream([]rune(input))
+
+		word, err := wm.Match(is)
+		if err != nil {
+			t.Fatalf("error matching %q: %s", input, err.Error())
+		}
+
+		if word != "Straße" {
+			t.Errorf("expected word to be 'Straße', got '%s'", word)
+		}
+
+		char, _ := is.Peek()
+		if char != '!' {
+			t.Errorf("expected the match of %q to stop before '!', got %q", input, char)
+		}
+	}
+}
+
+func TestNormalizedMatch(t *testing.T) {
+	wm := NewWordMatcher(WithNormalization(norm.NFC))
+
+	err := wm.AddWord("caf\u00e9")
+	if err != nil {
+		t.Fatalf("error adding word: %s", err.Error())
+	}
+
+	for _, input := range []string{"caf\u00e9", "cafe\u0301"} {
+		word, err := wm.Match(NewStream([]rune(input)))
+		if err != nil {
+			t.Fatalf("error matching %q: %s", input, err.Error())
+		}
+
+		if word != "caf\u00e9" {
+			t.Errorf("expected word to be %q, got %q", "caf\u00e9", word)
+		}
+	}
+}
+
+func TestMatchPrefix(t *testing.T) {
+	wm := NewWordMatcher()
+
+	for _, word := range []string{"foo", "bar", "f", "foobar", "fob"} {
+		err := wm.AddWord(word)
+		if err != nil {
+			t.Errorf("error adding word: %s", err.Error())
+		}
+	}
+
+	is := NewStream([]rune("foobaz"))
+
+	words, err := wm.MatchPrefix(is)
+	if err != nil {
+		t.Fatalf("error matching prefixes: %s", err.Error())
+	}
+
+	if len(words) != 2 || words[0] != "f" || words[1] != "foo" {
+		t.Errorf("expected [f foo], got %v", words)
+	}
+
+	if is.Pos() != 0 {
+		t.Errorf("expected the stream not to move, got position %d", is.Pos())
+	}
+
+	words, ok := wm.HasPrefix("fo")
+	if !ok || len(words) != 3 || words[0] != "fob" || words[1] != "foo" || words[2] != "foobar" {
+		t.Errorf("expected [fob foo foobar], got %v", words)
+	}
+
+	_, ok = wm.HasPrefix("x")
+	if ok {
+		t.Errorf("expected no word to start with x")
+	}
+}
+
+func TestLevenshteinZeroValue(t *testing.T) {
+	var lt LavenshteinTable
+
+	err := lt.AddWord("hello")
+	if err != nil {
+		t.Fatalf("error adding word: %s", err.Error())
+	}
+
+	word, err := lt.Closest([]rune("helo"))
+	if err != nil {
+		t.Fatalf("error finding closest word: %s", err.Error())
+	}
+
+	if word != "hello" {
+		t.Errorf("expected word to be 'hello', got '%s'", word)
+	}
+
+	lt.SetMaxDistance(0)
+
+	_, err = lt.Closest([]rune("helo"))
+	if err == nil {
+		t.Errorf("expected no word within distance 0")
+	}
+
+	lt.SetMaxDistance(-1)
+
+	_, err = lt.Closest([]rune("helo"))
+	if err != nil {
+		t.Errorf("expected no cutoff, got %s", err.Error())
+	}
+}
+
+func TestWordMatcherZeroValue(t *testing.T) {
+	var empty WordMatcher
+
+	_, err := empty.Match(NewStream([]rune("foo")))
+	if err == nil {
+		t.Errorf("expected no match in an empty matcher")
+	}
+
+	var wm WordMatcher
+
+	err = wm.AddWord("foo")
+	if err != nil {
+		t.Fatalf("error adding word: %s", err.Error())
+	}
+
+	word, err := wm.Match(NewStream([]rune("foobar")))
+	if err != nil {
+		t.Fatalf("error matching word: %s", err.Error())
+	}
+
+	if word != "foo" {
+		t.Errorf("expected word to be 'foo', got '%s'", word)
+	}
+}
+
+func BenchmarkMultiMatcherMismatch(b *testing.B) {
+	chars := []rune("abc")
+	stream := NewStream([]rune("abd"))
+
+	b.ReportAllocs()
+
+	for i := 0; i < b.N; i++ {
+		_, err := MultiMatcher(chars, stream)
+		if err == nil {
+			b.Fatalf("expected a mismatch")
+		}
+	}
+}
+
+func BenchmarkWordMatcherMatchMismatch(b *testing.B) {
+	wm := NewWordMatcher()
+
+	for _, word := range []string{"foo", "bar"} {
+		_ = wm.AddWord(word)
+	}
+
+	stream := NewStream([]rune("baz"))
+
+	b.ReportAllocs()
+
+	for i := 0; i < b.N; i++ {
+		_, err := wm.Match(stream)
+		if err == nil {
+			b.Fatalf("expected a mismatch")
+		}
+	}
+}
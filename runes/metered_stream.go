@@ -0,0 +1,157 @@
+package runes
+
+// MeteredStream is a CharStream that wraps another CharStream and keeps track of
+// how many characters were consumed, accepted and refused.
+type MeteredStream struct {
+	// stream is the wrapped stream.
+	stream CharStream
+
+	// length is the total number of characters of the stream. -1 if unknown.
+	length int
+
+	// pos is the current position in the stream.
+	pos int
+
+	// last_accept is the position of the last Accept operation.
+	last_accept int
+
+	// consumed is the total number of characters consumed by Next.
+	consumed int
+
+	// accepted is the total number of characters accepted.
+	accepted int
+
+	// refused is the total number of characters refused.
+	refused int
+}
+
+// IsDone implements the CharStream interface.
+func (ms *MeteredStream) IsDone() bool {
+	return ms.stream.IsDone()
+}
+
+// Next implements the CharStream interface.
+func (ms *MeteredStream) Next() (rune, bool) {
+	char, ok := ms.stream.Next()
+	if !ok {
+		return char, false
+	}
+
+	ms.pos++
+	ms.consumed++
+
+	return char, true
+}
+
+// Peek implements the CharStream interface.
+func (ms *MeteredStream) Peek() (rune, bool) {
+	return ms.stream.Peek()
+}
+
+// Refuse implements the CharStream interface.
+func (ms *MeteredStream) Refuse() bool {
+	ok := ms.stream.Refuse()
+	if !ok {
+		return false
+	}
+
+	ms.pos--
+	ms.refused++
+
+	return true
+}
+
+// RefuseMany implements the CharStream interface.
+func (ms *MeteredStream) RefuseMany() {
+	ms.stream.RefuseMany()
+
+	if ms.pos > ms.last_accept {
+		ms.refused += ms.pos - ms.last_accept
+	}
+
+	ms.pos = ms.last_accept
+}
+
+// Accept implements the CharStream interface.
+func (ms *MeteredStream) Accept() {
+	ms.stream.Accept()
+
+	if ms.pos > ms.last_accept {
+		ms.accepted += ms.pos - ms.last_accept
+	}
+
+	ms.last_accept = ms.pos
+}
+
+// NewMeteredStream creates a new MeteredStream.
+//
+// Parameters:
+//   - stream: The stream to wrap.
+//   - length: The total number of characters of the stream. Negative if unknown.
+//
+// Returns:
+//   - *MeteredStream: The new stream. Nil if stream is nil.
+//
+// If the length is negative and the stream is a *Stream, its length is used.
+func NewMeteredStream(stream CharStream, length int) *MeteredStream {
+	if stream == nil {
+		return nil
+	}
+
+	if length < 0 {
+		s, ok := stream.(*Stream)
+		if ok {
+			length = len(s.chars)
+		} else {
+			length = -1
+		}
+	}
+
+	ms := &MeteredStream{
+		stream: stream,
+		length: length,
+	}
+
+	return ms
+}
+
+// Consumed returns the total number of characters consumed by Next, including
+// the ones that were later refused.
+//
+// Returns:
+//   - int: The number of consumed characters.
+func (ms *MeteredStream) Consumed() int {
+	return ms.consumed
+}
+
+// Accepted returns the total number of characters accepted.
+//
+// Returns:
+//   - int: The number of accepted characters.
+func (ms *MeteredStream) Accepted() int {
+	return ms.accepted
+}
+
+// Refused returns the total number of characters refused.
+//
+// Returns:
+//   - int: The number of refused characters.
+func (ms *MeteredStream) Refused() int {
+	return ms.refused
+}
+
+// Progress returns the fraction of the stream that has been read so far.
+//
+// Returns:
+//   - float64: A value between 0 and 1. -1 if the length of the stream is unknown.
+//
+// An empty stream is always fully read.
+func (ms *MeteredStream) Progress() float64 {
+	if ms.length < 0 {
+		return -1
+	} else if ms.length == 0 {
+		return 1
+	}
+
+	return float64(ms.pos) / float64(ms.length)
+}
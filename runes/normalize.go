@@ -0,0 +1,163 @@
+package runes
+
+import (
+	"unicode/utf8"
+
+	"golang.org/x/text/cases"
+	"golang.org/x/text/unicode/norm"
+)
+
+// match_config is the configuration of how words are compared by a
+// LavenshteinTable or a WordMatcher.
+type match_config struct {
+	// fold_case is true if case differences are ignored.
+	fold_case bool
+
+	// form is the Unicode normalization form applied before comparing.
+	form norm.Form
+
+	// normalize is true if form is applied.
+	normalize bool
+}
+
+// MatchOption is an option of LavenshteinTable.SetMatchOptions and
+// NewWordMatcher.
+type MatchOption func(*match_config)
+
+// WithFoldCase makes the comparison of words case-insensitive, using Unicode
+// case folding; thus, "Hello", "hello" and "HELLO" are the same word.
+//
+// Returns:
+//   - MatchOption: The option.
+func WithFoldCase() MatchOption {
+	return func(mc *match_config) {
+		mc.fold_case = true
+	}
+}
+
+// WithNormalization normalizes words to the given Unicode form before
+// comparing them; for instance, with norm.NFC, "é" written as "e" followed by
+// a combining accent is the same as the precomposed "é", and with norm.NFKC,
+// the full-width "Ａ" is the same as "A".
+//
+// Parameters:
+//   - form: The normalization form.
+//
+// Returns:
+//   - MatchOption: The option.
+func WithNormalization(form norm.Form) MatchOption {
+	return func(mc *match_config) {
+		mc.form = form
+		mc.normalize = true
+	}
+}
+
+// new_match_config creates a configuration from options.
+//
+// Parameters:
+//   - opts: The options.
+//
+// Returns:
+//   - match_config: The configuration.
+func new_match_config(opts []MatchOption) match_config {
+	var mc match_config
+
+	for _, opt := range opts {
+		if opt != nil {
+			opt(&mc)
+		}
+	}
+
+	return mc
+}
+
+// is_identity checks whether the configuration leaves words unchanged.
+//
+// Returns:
+//   - bool: True if words are compared as they are.
+func (mc match_config) is_identity() bool {
+	return !mc.fold_case && !mc.normalize
+}
+
+// apply transforms a word into the form used for comparisons.
+//
+// Parameters:
+//   - word: The word.
+//
+// Returns:
+//   - string: The transformed word.
+func (mc match_config) apply(word string) string {
+	if mc.normalize {
+		word = mc.form.String(word)
+	}
+
+	if mc.fold_case {
+		word = cases.Fold().String(word)
+
+		if mc.normalize {
+			// Folding may denormalize the text; for instance, it can decompose
+			// some characters.
+			word = mc.form.String(word)
+		}
+	}
+
+	return word
+}
+
+// decomposed returns the configuration with composed normalization forms
+// replaced by their decomposed counterparts; that is, NFD for NFC and NFKD for
+// NFKC. Decomposed forms can be applied one character at a time, whereas
+// composed forms merge characters.
+//
+// Returns:
+//   - match_config: The configuration.
+func (mc match_config) decomposed() match_config {
+	switch mc.form {
+	case norm.NFC:
+		mc.form = norm.NFD
+	case norm.NFKC:
+		mc.form = norm.NFKD
+	}
+
+	return mc
+}
+
+// apply_rune transforms a single character of an input into the form used for
+// comparisons.
+//
+// Parameters:
+//   - char: The character.
+//
+// Returns:
+//   - rune: The transformed character. It is unchanged if its transformed
+//     form is not a single character.
+func (mc match_config) apply_rune(char rune) rune {
+	if mc.is_identity() {
+		return char
+	}
+
+	str := mc.apply(string(char))
+
+	r, size := utf8.DecodeRuneInString(str)
+	if size != len(str) || r == utf8.RuneError {
+		return char
+	}
+
+	return r
+}
+
+// apply_runes is like apply_rune but keeps transformed forms made of several
+// characters; for instance, "ß" becomes "ss" when folding case.
+//
+// Parameters:
+//   - char: The character.
+//
+// Returns:
+//   - []rune: The transformed characters. Empty if the character is removed.
+func (mc match_config) apply_runes(char rune) []rune {
+	if mc.is_identity() {
+		return []rune{char}
+	}
+
+	return []rune(mc.apply(string(char)))
+}
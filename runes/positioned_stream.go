@@ -0,0 +1,208 @@
+package runes
+
+import (
+	"unicode/utf8"
+
+	luc "github.com/PlayerR9/lib_units/common"
+)
+
+// PositionTracker is implemented by character streams that know the position
+// of their next character. WordMatcher and MultiMatcher use it to report where
+// a match failed.
+type PositionTracker interface {
+	// Position returns the position of the next character.
+	//
+	// Returns:
+	//   - common.Position: The position.
+	Position() luc.Position
+}
+
+// DefaultPositionHistorySize is the number of characters a PositionedStream
+// can refuse when no size is given.
+const DefaultPositionHistorySize int = 4096
+
+// PositionedStream is a CharStream that wraps another CharStream and keeps
+// track of the byte offset, line and column of its next character.
+//
+// The positions of the characters consumed since the last Accept are kept in
+// a ring buffer so that they can be restored when the characters are refused.
+// If more characters than the size of the buffer are consumed without an
+// Accept, the positions of the oldest ones are forgotten and these characters
+// can only be refused all at once with RefuseMany.
+type PositionedStream struct {
+	// stream is the wrapped stream.
+	stream CharStream
+
+	// pos is the position of the next character.
+	pos luc.Position
+
+	// mark is the position of the next character at the last Accept.
+	mark luc.Position
+
+	// history are the positions of the last characters consumed since the last
+	// Accept, oldest first.
+	history *luc.RingBuffer[luc.Position]
+}
+
+// NewPositionedStream creates a new PositionedStream that starts at line 1,
+// column 1.
+//
+// Parameters:
+//   - stream: The stream to wrap. It must not have been consumed yet.
+//   - file: The name of the source, used in positions. May be empty.
+//   - historySize: The maximum number of characters that can be refused one
+//     by one since the last Accept. If not positive,
+//     DefaultPositionHistorySize is used.
+//
+// Returns:
+//   - *PositionedStream: The new stream. Nil if stream is nil.
+func NewPositionedStream(stream CharStream, file string, historySize int) *PositionedStream {
+	if stream == nil {
+		return nil
+	}
+
+	if historySize <= 0 {
+		historySize = DefaultPositionHistorySize
+	}
+
+	pos := luc.NewPosition(file, 1, 1, 0)
+
+	ps := &PositionedStream{
+		stream:  stream,
+		pos:     pos,
+		mark:    pos,
+		history: luc.NewRingBuffer[luc.Position](historySize),
+	}
+
+	return ps
+}
+
+// Pos returns the line and column of the next character.
+//
+// Returns:
+//   - int: The 1-based line.
+//   - int: The 1-based column, in characters.
+func (ps *PositionedStream) Pos() (line, col int) {
+	return ps.pos.Line, ps.pos.Col
+}
+
+// Offset returns the byte offset of the next character.
+//
+// Returns:
+//   - int: The 0-based offset, assuming the characters are encoded in UTF-8.
+func (ps *PositionedStream) Offset() int {
+	return ps.pos.Offset
+}
+
+// Position implements the PositionTracker interface.
+func (ps *PositionedStream) Position() luc.Position {
+	return ps.pos
+}
+
+// IsDone implements the CharStream interface.
+func (ps *PositionedStream) IsDone() bool {
+	return ps.stream.IsDone()
+}
+
+// Next implements the CharStream interface.
+//
+// A '\n' moves the position to the start of the next line.
+func (ps *PositionedStream) Next() (rune, bool) {
+	char, ok := ps.stream.Next()
+	if !ok {
+		return char, false
+	}
+
+	_, _ = ps.history.Push(ps.pos)
+
+	size := utf8.RuneLen(char)
+	if size < 0 {
+		size = 1
+	}
+
+	ps.pos.Offset += size
+
+	if char == '\n' {
+		ps.pos.Line++
+		ps.pos.Col = 1
+	} else {
+		ps.pos.Col++
+	}
+
+	return char, true
+}
+
+// Peek implements the CharStream interface.
+func (ps *PositionedStream) Peek() (rune, bool) {
+	return ps.stream.Peek()
+}
+
+// Refuse implements the CharStream interface.
+//
+// Characters consumed before the last Accept, or whose position was
+// forgotten because the history was full, cannot be refused.
+func (ps *PositionedStream) Refuse() bool {
+	if ps.history.Len() == 0 {
+		return false
+	}
+
+	ok := ps.stream.Refuse()
+	if !ok {
+		return false
+	}
+
+	ps.pos, _ = ps.history.PopBack()
+
+	return true
+}
+
+// RefuseMany implements the CharStream interface.
+func (ps *PositionedStream) RefuseMany() {
+	ps.stream.RefuseMany()
+
+	ps.pos = ps.mark
+	ps.history.Reset()
+}
+
+// Accept implements the CharStream interface.
+func (ps *PositionedStream) Accept() {
+	ps.stream.Accept()
+
+	ps.mark = ps.pos
+	ps.history.Reset()
+}
+
+// at_position wraps an error with the position of a stream, if it tracks
+// positions.
+//
+// Parameters:
+//   - pos: The position, as returned by position_of.
+//   - ok: Whether the position is known.
+//   - err: The error to wrap.
+//
+// Returns:
+//   - error: The wrapped error, or err itself if the position is unknown.
+func at_position(pos luc.Position, ok bool, err error) error {
+	if !ok {
+		return err
+	}
+
+	return luc.NewErrAtPosition(pos, err)
+}
+
+// position_of returns the position of the next character of a stream.
+//
+// Parameters:
+//   - is: The stream.
+//
+// Returns:
+//   - common.Position: The position.
+//   - bool: True if the stream tracks positions, false otherwise.
+func position_of(is CharStream) (luc.Position, bool) {
+	tracker, ok := is.(PositionTracker)
+	if !ok {
+		return luc.Position{}, false
+	}
+
+	return tracker.Position(), true
+}
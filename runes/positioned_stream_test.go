@@ -0,0 +1,76 @@
+package runes
+
+import (
+	"errors"
+	"testing"
+
+	luc "github.com/PlayerR9/lib_units/common"
+)
+
+func TestPositionedStream(t *testing.T) {
+	ps := NewPositionedStream(NewStream([]rune("ab\nçd")), "input.txt", 0)
+
+	for range "ab\nç" {
+		ps.Next()
+	}
+
+	line, col := ps.Pos()
+	if line != 2 || col != 2 || ps.Offset() != 5 {
+		t.Errorf("expected 2:2 at offset 5, got %d:%d at offset %d", line, col, ps.Offset())
+	}
+
+	ps.Refuse()
+
+	line, col = ps.Pos()
+	if line != 2 || col != 1 || ps.Offset() != 3 {
+		t.Errorf("expected 2:1 at offset 3, got %d:%d at offset %d", line, col, ps.Offset())
+	}
+
+	ps.RefuseMany()
+
+	_, err := MultiMatcher([]rune("abc"), ps)
+
+	var at *luc.ErrAtPosition
+
+	if !errors.As(err, &at) || at.Pos.String() != "input.txt:1:3" {
+		t.Errorf("expected an error at input.txt:1:3, got %v", err)
+	}
+
+	line, col = ps.Pos()
+	if line != 1 || col != 1 {
+		t.Errorf("expected the stream to be back at 1:1, got %d:%d", line, col)
+	}
+}
+
+func TestPositionedStreamHistory(t *testing.T) {
+	ps := NewPositionedStream(NewStream([]rune("a\nbcd")), "", 2)
+
+	for range "a\nbc" {
+		ps.Next()
+	}
+
+	if !ps.Refuse() || !ps.Refuse() {
+		t.Fatalf("expected the last 2 characters to be refusable")
+	}
+
+	line, col := ps.Pos()
+	if line != 2 || col != 1 {
+		t.Errorf("expected 2:1, got %d:%d", line, col)
+	}
+
+	if ps.Refuse() {
+		t.Errorf("expected the older characters to be forgotten")
+	}
+
+	ps.RefuseMany()
+
+	line, col = ps.Pos()
+	if line != 1 || col != 1 || ps.Offset() != 0 {
+		t.Errorf("expected 1:1 at offset 0, got %d:%d at offset %d", line, col, ps.Offset())
+	}
+
+	char, _ := ps.Next()
+	if char != 'a' {
+		t.Errorf("expected 'a' after RefuseMany, got %q", char)
+	}
+}
@@ -0,0 +1,160 @@
+package runes
+
+import (
+	"bufio"
+	"errors"
+	"io"
+	"unicode/utf8"
+
+	luc "github.com/PlayerR9/lib_units/common"
+)
+
+// DefaultReaderStreamSize is the number of characters a ReaderStream retains
+// when no size is given.
+const DefaultReaderStreamSize int = 4096
+
+// ReaderStream is a character stream that decodes UTF-8 from an io.Reader as
+// it is consumed, so that the whole input never needs to be in memory.
+//
+// The characters read since the last Accept are kept in a ring buffer so that
+// they can be refused. If more characters than the size of the buffer are read
+// without an Accept, the oldest ones are forgotten and can no longer be
+// refused.
+type ReaderStream struct {
+	// r is the source of the characters.
+	r *bufio.Reader
+
+	// buf holds the characters read since the last Accept, including the one
+	// that was peeked, if any.
+	buf *luc.RingBuffer[rune]
+
+	// pos is the index, in buf, of the next character.
+	pos int
+
+	// err is the error that stopped the reading. Nil while there is more to
+	// read.
+	err error
+}
+
+// NewReaderStream creates a new ReaderStream.
+//
+// Parameters:
+//   - r: The source of the characters.
+//   - bufSize: The maximum number of characters that can be refused since the
+//     last Accept. If not positive, DefaultReaderStreamSize is used.
+//
+// Returns:
+//   - *ReaderStream: The new stream. Nil if r is nil.
+//
+// Invalid UTF-8 sequences are read as utf8.RuneError, one byte at a time.
+func NewReaderStream(r io.Reader, bufSize int) *ReaderStream {
+	if r == nil {
+		return nil
+	}
+
+	if bufSize <= 0 {
+		bufSize = DefaultReaderStreamSize
+	}
+
+	// One extra slot holds the peeked character.
+	rs := &ReaderStream{
+		r:   bufio.NewReader(r),
+		buf: luc.NewRingBuffer[rune](bufSize + 1),
+	}
+
+	return rs
+}
+
+// fill makes sure that the character at pos is in the buffer.
+//
+// Returns:
+//   - bool: True if there is a character at pos, false if the reader is
+//     exhausted.
+func (rs *ReaderStream) fill() bool {
+	if rs.pos < rs.buf.Len() {
+		return true
+	} else if rs.err != nil {
+		return false
+	}
+
+	char, _, err := rs.r.ReadRune()
+	if err != nil {
+		rs.err = err
+		return false
+	}
+
+	_, evicted := rs.buf.Push(char)
+	if evicted {
+		rs.pos--
+	}
+
+	return true
+}
+
+// Err returns the error that stopped the reading, if it is not io.EOF.
+//
+// Returns:
+//   - error: The error. Nil if the reader is not exhausted or ended normally.
+func (rs *ReaderStream) Err() error {
+	if errors.Is(rs.err, io.EOF) {
+		return nil
+	}
+
+	return rs.err
+}
+
+// IsDone implements the CharStream interface.
+func (rs *ReaderStream) IsDone() bool {
+	return !rs.fill()
+}
+
+// Next implements the CharStream interface.
+func (rs *ReaderStream) Next() (rune, bool) {
+	if !rs.fill() {
+		return utf8.RuneError, false
+	}
+
+	char, _ := rs.buf.At(rs.pos)
+	rs.pos++
+
+	return char, true
+}
+
+// Peek implements the CharStream interface.
+func (rs *ReaderStream) Peek() (rune, bool) {
+	if !rs.fill() {
+		return utf8.RuneError, false
+	}
+
+	char, _ := rs.buf.At(rs.pos)
+
+	return char, true
+}
+
+// Refuse implements the CharStream interface.
+//
+// Characters read before the last Accept, or forgotten because the buffer
+// was full, cannot be refused.
+func (rs *ReaderStream) Refuse() bool {
+	if rs.pos == 0 {
+		return false
+	}
+
+	rs.pos--
+
+	return true
+}
+
+// RefuseMany implements the CharStream interface.
+func (rs *ReaderStream) RefuseMany() {
+	rs.pos = 0
+}
+
+// Accept implements the CharStream interface.
+//
+// The characters read so far are forgotten, which frees the buffer.
+func (rs *ReaderStream) Accept() {
+	for ; rs.pos > 0; rs.pos-- {
+		_, _ = rs.buf.PopFront()
+	}
+}
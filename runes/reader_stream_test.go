@@ -0,0 +1,57 @@
+package runes
+
+import (
+	"strings"
+	"testing"
+	"testing/iotest"
+)
+
+func TestReaderStream(t *testing.T) {
+	rs := NewReaderStream(iotest.OneByteReader(strings.NewReader("héllo wörld")), 4)
+
+	for _, want := range "hél" {
+		got, ok := rs.Next()
+		if !ok || got != want {
+			t.Fatalf("expected %q, got %q", want, got)
+		}
+	}
+
+	rs.RefuseMany()
+
+	char, ok := rs.Peek()
+	if !ok || char != 'h' {
+		t.Fatalf("expected h after RefuseMany, got %q", char)
+	}
+
+	for range "héllo" {
+		rs.Next()
+	}
+
+	rs.Accept()
+
+	if rs.Refuse() {
+		t.Errorf("expected no character to refuse after Accept")
+	}
+
+	wm := NewWordMatcher()
+
+	for _, word := range []string{" w", " wör", " wörld!"} {
+		_ = wm.AddWord(word)
+	}
+
+	word, err := wm.Match(rs)
+	if err != nil || word != " wör" {
+		t.Fatalf("expected \" wör\", got %q (%v)", word, err)
+	}
+
+	var rest []rune
+
+	for !rs.IsDone() {
+		char, _ := rs.Next()
+		rest = append(rest, char)
+	}
+
+	if string(rest) != "ld" || rs.Err() != nil {
+		t.Errorf("expected rest \"ld\", got %q (%v)", string(rest), rs.Err())
+	}
+}
@@ -0,0 +1,299 @@
+package runes
+
+import (
+	"bufio"
+	"errors"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"io"
+	"strconv"
+
+	gcers "github.com/PlayerR9/go-commons/errors"
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/basicfont"
+	"golang.org/x/image/math/fixed"
+)
+
+// RenderFormat is the output format of Render.
+type RenderFormat int
+
+const (
+	// RenderText renders the table as plain text, one line per row.
+	RenderText RenderFormat = iota
+
+	// RenderANSI renders the table as a block of ANSI-colored text where every
+	// row is padded to the same width, so that the result looks like a solid
+	// panel when pasted in a terminal or a screenshot.
+	RenderANSI
+
+	// RenderPNG renders the table as a PNG image using a fixed 7x13 bitmap font.
+	RenderPNG
+)
+
+// String implements the fmt.Stringer interface.
+func (f RenderFormat) String() string {
+	switch f {
+	case RenderText:
+		return "text"
+	case RenderANSI:
+		return "ansi"
+	case RenderPNG:
+		return "png"
+	default:
+		return "RenderFormat(" + strconv.Itoa(int(f)) + ")"
+	}
+}
+
+const (
+	// ansi_panel is the SGR sequence that sets the colors of an ANSI panel.
+	ansi_panel string = "\x1b[37;40m"
+
+	// ansi_reset is the SGR sequence that resets all the attributes.
+	ansi_reset string = "\x1b[0m"
+
+	// png_margin is the margin, in pixels, around a PNG rendering.
+	png_margin int = 4
+)
+
+var (
+	// png_background is the background color of a PNG rendering.
+	png_background color.Color
+
+	// png_foreground is the foreground color of a PNG rendering.
+	png_foreground color.Color
+
+	// box_arms maps the box-drawing characters to the weight of their arms.
+	// [Up, Right, Down, Left, Dashed]; a weight of 1 is light, 2 is heavy
+	// and 3 is double.
+	box_arms map[rune][5]uint8
+)
+
+func init() {
+	png_background = color.RGBA{R: 0x1e, G: 0x1e, B: 0x1e, A: 0xff}
+	png_foreground = color.RGBA{R: 0xdc, G: 0xdc, B: 0xdc, A: 0xff}
+
+	box_arms = map[rune][5]uint8{
+		'─': {0, 1, 0, 1, 0}, '━': {0, 2, 0, 2, 0},
+		'│': {1, 0, 1, 0, 0}, '┃': {2, 0, 2, 0, 0},
+		'┄': {0, 1, 0, 1, 1}, '┅': {0, 2, 0, 2, 1},
+		'┈': {0, 1, 0, 1, 1}, '┉': {0, 2, 0, 2, 1},
+		'┆': {1, 0, 1, 0, 1}, '┇': {2, 0, 2, 0, 1},
+		'┊': {1, 0, 1, 0, 1}, '┋': {2, 0, 2, 0, 1},
+		'┌': {0, 1, 1, 0, 0}, '┐': {0, 0, 1, 1, 0},
+		'└': {1, 1, 0, 0, 0}, '┘': {1, 0, 0, 1, 0},
+		'╭': {0, 1, 1, 0, 0}, '╮': {0, 0, 1, 1, 0},
+		'╰': {1, 1, 0, 0, 0}, '╯': {1, 0, 0, 1, 0},
+		'┏': {0, 2, 2, 0, 0}, '┓': {0, 0, 2, 2, 0},
+		'┗': {2, 2, 0, 0, 0}, '┛': {2, 0, 0, 2, 0},
+		'├': {1, 1, 1, 0, 0}, '┤': {1, 0, 1, 1, 0},
+		'┬': {0, 1, 1, 1, 0}, '┴': {1, 1, 0, 1, 0},
+		'┼': {1, 1, 1, 1, 0},
+		'┣': {2, 2, 2, 0, 0}, '┫': {2, 0, 2, 2, 0},
+		'┳': {0, 2, 2, 2, 0}, '┻': {2, 2, 0, 2, 0},
+		'╋': {2, 2, 2, 2, 0},
+		'═': {0, 3, 0, 3, 0}, '║': {3, 0, 3, 0, 0},
+		'╔': {0, 3, 3, 0, 0}, '╗': {0, 0, 3, 3, 0},
+		'╚': {3, 3, 0, 0, 0}, '╝': {3, 0, 0, 3, 0},
+		'╠': {3, 3, 3, 0, 0}, '╣': {3, 0, 3, 3, 0},
+		'╦': {0, 3, 3, 3, 0}, '╩': {3, 3, 0, 3, 0},
+		'╬': {3, 3, 3, 3, 0},
+	}
+}
+
+// Render writes the table to the writer in the given format.
+//
+// Parameters:
+//   - rt: The table to render.
+//   - format: The output format.
+//   - w: The writer to write to.
+//
+// Returns:
+//   - error: An error if the table could not be rendered.
+//
+// Errors:
+//   - *errors.ErrInvalidParameter: If rt or w is nil, or if the format is
+//     unknown.
+//   - any error returned by the writer.
+//
+// The table is not modified; rows of different lengths are padded with spaces
+// in the ANSI and PNG formats.
+func Render(rt *RuneTable, format RenderFormat, w io.Writer) error {
+	if rt == nil {
+		return gcers.NewErrNilParameter("rt")
+	} else if w == nil {
+		return gcers.NewErrNilParameter("w")
+	}
+
+	switch format {
+	case RenderText:
+		_, err := io.WriteString(w, rt.String())
+		return err
+	case RenderANSI:
+		return render_ansi(rt, w)
+	case RenderPNG:
+		img := render_image(rt)
+
+		return png.Encode(w, img)
+	default:
+		return gcers.NewErrInvalidParameter("format", errors.New("unknown format "+format.String()))
+	}
+}
+
+// render_ansi is a helper function that renders the table as an ANSI panel.
+//
+// Parameters:
+//   - rt: The table to render.
+//   - w: The writer to write to.
+//
+// Returns:
+//   - error: Any error returned by the writer.
+//
+// Assertions:
+//   - rt != nil
+//   - w != nil
+func render_ansi(rt *RuneTable, w io.Writer) error {
+	edge := rt.RightMostEdge()
+
+	bw := bufio.NewWriter(w)
+
+	for _, row := range rt.table {
+		bw.WriteString(ansi_panel)
+		bw.WriteString(string(row))
+
+		for i := len(row); i < edge; i++ {
+			bw.WriteRune(' ')
+		}
+
+		bw.WriteString(ansi_reset)
+		bw.WriteRune('\n')
+	}
+
+	return bw.Flush()
+}
+
+// render_image is a helper function that draws the table on an image.
+//
+// Parameters:
+//   - rt: The table to draw.
+//
+// Returns:
+//   - *image.RGBA: The image. Never nil.
+//
+// Assertions:
+//   - rt != nil
+func render_image(rt *RuneTable) *image.RGBA {
+	face := basicfont.Face7x13
+
+	cell_w := face.Advance
+	cell_h := face.Height
+
+	width := rt.RightMostEdge()*cell_w + 2*png_margin
+	height := len(rt.table)*cell_h + 2*png_margin
+
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	draw.Draw(img, img.Bounds(), image.NewUniform(png_background), image.Point{}, draw.Src)
+
+	drawer := &font.Drawer{
+		Dst:  img,
+		Src:  image.NewUniform(png_foreground),
+		Face: face,
+	}
+
+	for y, row := range rt.table {
+		y0 := png_margin + y*cell_h
+
+		for x, char := range row {
+			if char == ' ' {
+				continue
+			}
+
+			x0 := png_margin + x*cell_w
+
+			arms, ok := box_arms[char]
+			if ok {
+				draw_box_rune(img, image.Rect(x0, y0, x0+cell_w, y0+cell_h), arms)
+				continue
+			}
+
+			drawer.Dot = fixed.P(x0, y0+face.Ascent)
+			drawer.DrawString(string(char))
+		}
+	}
+
+	return img
+}
+
+// draw_box_rune is a helper function that draws a box-drawing character as
+// line segments, since the bitmap font does not have glyphs for them.
+//
+// Parameters:
+//   - img: The image to draw on.
+//   - cell: The bounds of the character cell.
+//   - arms: The arms of the character as in box_arms.
+//
+// Assertions:
+//   - img != nil
+func draw_box_rune(img *image.RGBA, cell image.Rectangle, arms [5]uint8) {
+	cx := cell.Min.X + cell.Dx()/2
+	cy := cell.Min.Y + cell.Dy()/2
+
+	is_dashed := arms[4] != 0
+
+	// offsets returns the offsets of the parallel strokes of an arm.
+	offsets := func(weight uint8) []int {
+		switch weight {
+		case 1:
+			return []int{0}
+		case 2:
+			return []int{0, 1}
+		case 3:
+			return []int{-1, 1}
+		default:
+			return nil
+		}
+	}
+
+	plot := func(x, y, pos int) {
+		if is_dashed && pos%3 == 2 {
+			return
+		}
+
+		img.Set(x, y, png_foreground)
+	}
+
+	// Up and down.
+	for _, dir := range [2]int{0, 2} {
+		var from, to int
+
+		if dir == 0 {
+			from, to = cell.Min.Y, cy+1
+		} else {
+			from, to = cy, cell.Max.Y
+		}
+
+		for _, off := range offsets(arms[dir]) {
+			for y := from; y < to; y++ {
+				plot(cx+off, y, y-cell.Min.Y)
+			}
+		}
+	}
+
+	// Right and left.
+	for _, dir := range [2]int{1, 3} {
+		var from, to int
+
+		if dir == 3 {
+			from, to = cell.Min.X, cx+1
+		} else {
+			from, to = cx, cell.Max.X
+		}
+
+		for _, off := range offsets(arms[dir]) {
+			for x := from; x < to; x++ {
+				plot(x, cy+off, x-cell.Min.X)
+			}
+		}
+	}
+}
@@ -1,8 +1,10 @@
 package runes
 
 import (
+	"slices"
 	"strings"
 
+	gcers "github.com/PlayerR9/go-commons/errors"
 	gcint "github.com/PlayerR9/go-commons/ints"
 	gcch "github.com/PlayerR9/go-commons/runes"
 )
@@ -11,6 +13,13 @@ import (
 type RuneTable struct {
 	// table is the table of runes.
 	table [][]rune
+
+	// by_grapheme is whether widths are measured by grapheme cluster.
+	by_grapheme bool
+
+	// tags are the sorted tags of each row. Nil if no row was ever tagged;
+	// otherwise, it has one entry per row.
+	tags [][]string
 }
 
 // String implements the fmt.Stringer interface.
@@ -52,20 +61,42 @@ func NewRuneTable(lines []string) (*RuneTable, error) {
 	return rt, nil
 }
 
+// SetGraphemeMode sets whether the widths of the rows are measured by grapheme
+// cluster instead of by character.
+//
+// Parameters:
+//   - enabled: True to measure grapheme clusters, false to measure characters.
+//
+// In grapheme mode, joined emoji and flags count as a single wide character
+// and clusters are never cut by ClampWidth, Wrap or HardWrap. Cell indices
+// still count characters.
+func (rt *RuneTable) SetGraphemeMode(enabled bool) {
+	rt.by_grapheme = enabled
+}
+
+// width returns the number of terminal columns of a row.
+//
+// Parameters:
+//   - row: The row.
+//
+// Returns:
+//   - int: The width of the row, according to the mode of the table.
+func (rt *RuneTable) width(row []rune) int {
+	return display_width(row, rt.by_grapheme)
+}
+
 // RightMostEdge gets the right most edge of the content.
 //
 // Parameters:
 //   - content: The content.
 //
 // Returns:
-//   - int: The right most edge.
+//   - int: The right most edge, in terminal columns. See DisplayWidth.
 func (rt *RuneTable) RightMostEdge() int {
 	var longest_line int
 
 	for _, row := range rt.table {
-		if len(row) > longest_line {
-			longest_line = len(row)
-		}
+		longest_line = max(longest_line, rt.width(row))
 	}
 
 	return longest_line
@@ -74,14 +105,14 @@ func (rt *RuneTable) RightMostEdge() int {
 // AlignRightEdge aligns the right edge of the table.
 //
 // Returns:
-//   - int: The right most edge.
+//   - int: The right most edge, in terminal columns.
 func (rt *RuneTable) AlignRightEdge() int {
 	edge := rt.RightMostEdge()
 
 	for i := 0; i < len(rt.table); i++ {
 		curr_row := rt.table[i]
 
-		padding := edge - len(curr_row)
+		padding := edge - rt.width(curr_row)
 
 		padding_right := make([]rune, 0, padding)
 		for i := 0; i < padding; i++ {
@@ -100,6 +131,10 @@ func (rt *RuneTable) AlignRightEdge() int {
 //   - row: The row to prepend.
 func (rt *RuneTable) PrependTopRow(row []rune) {
 	rt.table = append([][]rune{row}, rt.table...)
+
+	if rt.tags != nil {
+		rt.tags = append([][]string{nil}, rt.tags...)
+	}
 }
 
 // AppendBottomRow appends a row to the bottom of the table.
@@ -108,6 +143,10 @@ func (rt *RuneTable) PrependTopRow(row []rune) {
 //   - row: The row to append.
 func (rt *RuneTable) AppendBottomRow(row []rune) {
 	rt.table = append(rt.table, row)
+
+	if rt.tags != nil {
+		rt.tags = append(rt.tags, nil)
+	}
 }
 
 // PrefixEachRow prefixes each row with the given prefix.
@@ -131,3 +170,626 @@ func (rt *RuneTable) SuffixEachRow(suffix []rune) {
 		rt.table[i] = new_row
 	}
 }
+
+// ClampWidth truncates every row wider than the given number of terminal
+// columns, ending it with "…".
+//
+// Parameters:
+//   - maxWidth: The maximum number of columns of a row. If not positive,
+//     nothing is done.
+//
+// Returns:
+//   - int: The number of rows that were truncated.
+//
+// Call it on the content before drawing a box around it so that narrow
+// terminals cut cells instead of wrapping the whole layout.
+func (rt *RuneTable) ClampWidth(maxWidth int) int {
+	if maxWidth <= 0 {
+		return 0
+	}
+
+	var count int
+
+	for i, row := range rt.table {
+		if rt.width(row) <= maxWidth {
+			continue
+		}
+
+		rt.table[i] = truncate_display(row, maxWidth, []rune{'…'}, rt.by_grapheme)
+		count++
+	}
+
+	return count
+}
+
+// wrap replaces every row with the lines a wrapping function splits it into.
+//
+// Parameters:
+//   - f: The wrapping function.
+//
+// Returns:
+//   - int: The number of rows that were split.
+func (rt *RuneTable) wrap(f func(row []rune) [][]rune) int {
+	var count int
+	var tags [][]string
+
+	table := make([][]rune, 0, len(rt.table))
+
+	for i, row := range rt.table {
+		lines := f(row)
+		if len(lines) > 1 {
+			count++
+		}
+
+		table = append(table, lines...)
+
+		if rt.tags == nil {
+			continue
+		}
+
+		// Every part of a row keeps its tags.
+		for range lines {
+			tags = append(tags, rt.tags[i])
+		}
+	}
+
+	rt.table = table
+
+	if rt.tags != nil {
+		rt.tags = tags
+	}
+
+	return count
+}
+
+// Wrap breaks, at spaces, every row wider than the given number of terminal
+// columns into several rows.
+//
+// Parameters:
+//   - width: The maximum number of columns of a row. If not positive,
+//     nothing is done.
+//
+// Returns:
+//   - int: The number of rows that were broken.
+//
+// See WrapDisplay for how rows are broken.
+func (rt *RuneTable) Wrap(width int) int {
+	if width <= 0 {
+		return 0
+	}
+
+	return rt.wrap(func(row []rune) [][]rune {
+		return wrap_display(row, width, rt.by_grapheme)
+	})
+}
+
+// HardWrap cuts every row wider than the given number of terminal columns
+// into several rows, regardless of words.
+//
+// Parameters:
+//   - width: The maximum number of columns of a row. If not positive,
+//     nothing is done.
+//
+// Returns:
+//   - int: The number of rows that were cut.
+func (rt *RuneTable) HardWrap(width int) int {
+	if width <= 0 {
+		return 0
+	}
+
+	return rt.wrap(func(row []rune) [][]rune {
+		return hard_wrap_display(row, width, rt.by_grapheme)
+	})
+}
+
+// Height returns the number of rows of the table.
+//
+// Returns:
+//   - int: The number of rows.
+func (rt *RuneTable) Height() int {
+	return len(rt.table)
+}
+
+// Cell returns the character at the given cell.
+//
+// Parameters:
+//   - row: The row of the cell.
+//   - col: The column of the cell.
+//
+// Returns:
+//   - rune: The character. A space if the cell is past the end of its row.
+//   - bool: False if the cell is outside of the table, true otherwise.
+func (rt *RuneTable) Cell(row, col int) (rune, bool) {
+	if row < 0 || row >= len(rt.table) || col < 0 {
+		return ' ', false
+	}
+
+	if col >= len(rt.table[row]) {
+		return ' ', true
+	}
+
+	return rt.table[row][col], true
+}
+
+// grow makes sure that the given cell exists, adding empty rows and padding
+// the row with spaces as needed.
+//
+// Parameters:
+//   - row: The row of the cell.
+//   - col: The column of the cell. -1 only makes sure that the row exists.
+//
+// Assertions:
+//   - row >= 0 && col >= -1
+func (rt *RuneTable) grow(row, col int) {
+	for len(rt.table) <= row {
+		rt.table = append(rt.table, nil)
+
+		if rt.tags != nil {
+			rt.tags = append(rt.tags, nil)
+		}
+	}
+
+	for len(rt.table[row]) <= col {
+		rt.table[row] = append(rt.table[row], ' ')
+	}
+}
+
+// SetCell sets the character at the given cell.
+//
+// Parameters:
+//   - row: The row of the cell.
+//   - col: The column of the cell.
+//   - r: The character to set.
+//
+// Returns:
+//   - error: An error if the cell is invalid.
+//
+// Errors:
+//   - *errors.ErrInvalidParameter: If row or col is negative.
+//
+// If the cell is outside of the table, the table grows with empty rows and
+// the row is padded with spaces.
+func (rt *RuneTable) SetCell(row, col int, r rune) error {
+	if row < 0 {
+		return gcers.NewErrInvalidParameter("row", gcint.NewErrGTE(0))
+	} else if col < 0 {
+		return gcers.NewErrInvalidParameter("col", gcint.NewErrGTE(0))
+	}
+
+	rt.grow(row, col)
+
+	rt.table[row][col] = r
+
+	return nil
+}
+
+// InsertColumn inserts a character at the given column of every row.
+//
+// Parameters:
+//   - col: The column at which to insert.
+//   - r: The character to insert.
+//
+// Returns:
+//   - error: An error if the column is invalid.
+//
+// Errors:
+//   - *errors.ErrInvalidParameter: If col is negative.
+//
+// Rows shorter than col are padded with spaces first.
+func (rt *RuneTable) InsertColumn(col int, r rune) error {
+	if col < 0 {
+		return gcers.NewErrInvalidParameter("col", gcint.NewErrGTE(0))
+	}
+
+	for i, row := range rt.table {
+		for len(row) < col {
+			row = append(row, ' ')
+		}
+
+		row = append(row, 0)
+		copy(row[col+1:], row[col:])
+		row[col] = r
+
+		rt.table[i] = row
+	}
+
+	return nil
+}
+
+// DeleteRow removes a row from the table.
+//
+// Parameters:
+//   - row: The row to remove.
+//
+// Returns:
+//   - error: An error if the row is invalid.
+//
+// Errors:
+//   - *errors.ErrInvalidParameter: If row is out of bounds.
+func (rt *RuneTable) DeleteRow(row int) error {
+	if row < 0 || row >= len(rt.table) {
+		return gcers.NewErrInvalidParameter("row", gcint.NewErrOutOfBounds(row, 0, len(rt.table)))
+	}
+
+	rt.table = append(rt.table[:row], rt.table[row+1:]...)
+
+	if rt.tags != nil {
+		rt.tags = append(rt.tags[:row], rt.tags[row+1:]...)
+	}
+
+	return nil
+}
+
+// Merge writes another table over this one, with its top left corner at the
+// given cell.
+//
+// Parameters:
+//   - other: The table to write.
+//   - atRow: The row of the top left corner.
+//   - atCol: The column of the top left corner.
+//
+// Returns:
+//   - error: An error if the parameters are invalid.
+//
+// Errors:
+//   - *errors.ErrInvalidParameter: If other is nil or atRow or atCol is
+//     negative.
+//
+// Example:
+//
+//	// Nest a box inside another one.
+//	err := outer.Merge(inner, 1, 2)
+//
+// The table grows as SetCell does. Only the characters of the rows of other
+// are written; that is, the cells past the end of its rows are left as they
+// are. The tags of the rows of other are added to the rows they are written
+// over.
+func (rt *RuneTable) Merge(other *RuneTable, atRow, atCol int) error {
+	if other == nil {
+		return gcers.NewErrNilParameter("other")
+	} else if atRow < 0 {
+		return gcers.NewErrInvalidParameter("atRow", gcint.NewErrGTE(0))
+	} else if atCol < 0 {
+		return gcers.NewErrInvalidParameter("atCol", gcint.NewErrGTE(0))
+	}
+
+	// Copy first in case other is rt.
+	rows := make([][]rune, 0, len(other.table))
+
+	for _, row := range other.table {
+		rows = append(rows, append([]rune(nil), row...))
+	}
+
+	for i, row := range rows {
+		if len(row) == 0 {
+			rt.grow(atRow+i, -1)
+			continue
+		}
+
+		rt.grow(atRow+i, atCol+len(row)-1)
+
+		copy(rt.table[atRow+i][atCol:], row)
+	}
+
+	for i, tags := range other.tags {
+		for _, tag := range tags {
+			_ = rt.TagRow(atRow+i, tag)
+		}
+	}
+
+	return nil
+}
+
+// SubTable returns a view of a rectangular region of the table.
+//
+// Parameters:
+//   - r0: The first row of the region.
+//   - c0: The first column of the region.
+//   - r1: The row after the last row of the region.
+//   - c1: The column after the last column of the region.
+//
+// Returns:
+//   - *RuneTableView: The view. Nil if the region is invalid.
+//   - error: An error if the region is invalid.
+//
+// Errors:
+//   - *errors.ErrInvalidParameter: If the region is not within the rows of the
+//     table or if its columns are invalid.
+//
+// Example:
+//
+//	// Draw a box inside the cells of another one.
+//	view, _ := outer.SubTable(1, 2, 4, 10)
+//	err := view.Merge(inner, 0, 0)
+func (rt *RuneTable) SubTable(r0, c0, r1, c1 int) (*RuneTableView, error) {
+	if r0 < 0 || r0 > len(rt.table) {
+		reason := gcint.NewErrOutOfBounds(r0, 0, len(rt.table))
+		reason.UpperInclusive = true
+
+		return nil, gcers.NewErrInvalidParameter("r0", reason)
+	} else if r1 < r0 || r1 > len(rt.table) {
+		reason := gcint.NewErrOutOfBounds(r1, r0, len(rt.table))
+		reason.UpperInclusive = true
+
+		return nil, gcers.NewErrInvalidParameter("r1", reason)
+	} else if c0 < 0 {
+		return nil, gcers.NewErrInvalidParameter("c0", gcint.NewErrGTE(0))
+	} else if c1 < c0 {
+		return nil, gcers.NewErrInvalidParameter("c1", gcint.NewErrGTE(c0))
+	}
+
+	view := &RuneTableView{
+		table:  rt,
+		row:    r0,
+		col:    c0,
+		height: r1 - r0,
+		width:  c1 - c0,
+	}
+
+	return view, nil
+}
+
+// RuneTableView is a rectangular region of a RuneTable, as returned by
+// SubTable. Reading the view reads the table and writing it writes the table.
+//
+// The region is fixed in the coordinates of the table; thus, inserting or
+// deleting rows or columns of the table shifts its contents under the view.
+type RuneTableView struct {
+	// table is the viewed table.
+	table *RuneTable
+
+	// row is the first row of the region in the table.
+	row int
+
+	// col is the first column of the region in the table.
+	col int
+
+	// height is the number of rows of the region.
+	height int
+
+	// width is the number of columns of the region.
+	width int
+}
+
+// Height returns the number of rows of the view.
+//
+// Returns:
+//   - int: The number of rows.
+func (v *RuneTableView) Height() int {
+	return v.height
+}
+
+// Width returns the number of columns of the view.
+//
+// Returns:
+//   - int: The number of columns.
+func (v *RuneTableView) Width() int {
+	return v.width
+}
+
+// Cell returns the character at the given cell of the view.
+//
+// Parameters:
+//   - row: The row of the cell, relative to the view.
+//   - col: The column of the cell, relative to the view.
+//
+// Returns:
+//   - rune: The character. A space if the cell is past the end of its row in
+//     the table.
+//   - bool: False if the cell is outside of the view, true otherwise.
+func (v *RuneTableView) Cell(row, col int) (rune, bool) {
+	if row < 0 || row >= v.height || col < 0 || col >= v.width {
+		return ' ', false
+	}
+
+	r, _ := v.table.Cell(v.row+row, v.col+col)
+
+	return r, true
+}
+
+// SetCell sets the character at the given cell of the view, in the table.
+//
+// Parameters:
+//   - row: The row of the cell, relative to the view.
+//   - col: The column of the cell, relative to the view.
+//   - r: The character to set.
+//
+// Returns:
+//   - error: An error if the cell is outside of the view.
+//
+// Errors:
+//   - *errors.ErrInvalidParameter: If row or col is out of bounds.
+//
+// The row of the table is padded with spaces as SetCell does.
+func (v *RuneTableView) SetCell(row, col int, r rune) error {
+	if row < 0 || row >= v.height {
+		return gcers.NewErrInvalidParameter("row", gcint.NewErrOutOfBounds(row, 0, v.height))
+	} else if col < 0 || col >= v.width {
+		return gcers.NewErrInvalidParameter("col", gcint.NewErrOutOfBounds(col, 0, v.width))
+	}
+
+	return v.table.SetCell(v.row+row, v.col+col, r)
+}
+
+// Merge writes a table over the view, with its top left corner at the given
+// cell of the view, as RuneTable.Merge does.
+//
+// Parameters:
+//   - other: The table to write.
+//   - atRow: The row of the top left corner, relative to the view.
+//   - atCol: The column of the top left corner, relative to the view.
+//
+// Returns:
+//   - error: An error if the parameters are invalid.
+//
+// Errors:
+//   - *errors.ErrInvalidParameter: If other is nil or atRow or atCol is
+//     negative.
+//
+// The characters that fall outside of the view are not written, and neither
+// are tags.
+func (v *RuneTableView) Merge(other *RuneTable, atRow, atCol int) error {
+	if other == nil {
+		return gcers.NewErrNilParameter("other")
+	} else if atRow < 0 {
+		return gcers.NewErrInvalidParameter("atRow", gcint.NewErrGTE(0))
+	} else if atCol < 0 {
+		return gcers.NewErrInvalidParameter("atCol", gcint.NewErrGTE(0))
+	}
+
+	// Copy first in case other is the viewed table.
+	rows := make([][]rune, 0, len(other.table))
+
+	for _, row := range other.table {
+		rows = append(rows, append([]rune(nil), row...))
+	}
+
+	for i, row := range rows {
+		for j, r := range row {
+			if atRow+i >= v.height || atCol+j >= v.width {
+				break
+			}
+
+			_ = v.SetCell(atRow+i, atCol+j, r)
+		}
+	}
+
+	return nil
+}
+
+// Copy returns a copy of the region of the view as a new table.
+//
+// Returns:
+//   - *RuneTable: The copy, whose rows all have Width characters. Never nil.
+//
+// Cells past the end of their row are copied as spaces and rows keep their
+// tags. Modifying the copy does not affect the viewed table.
+func (v *RuneTableView) Copy() *RuneTable {
+	table := make([][]rune, 0, v.height)
+
+	for i := 0; i < v.height; i++ {
+		row := make([]rune, 0, v.width)
+
+		for j := 0; j < v.width; j++ {
+			r, _ := v.Cell(i, j)
+			row = append(row, r)
+		}
+
+		table = append(table, row)
+	}
+
+	rt := &RuneTable{
+		table:       table,
+		by_grapheme: v.table.by_grapheme,
+	}
+
+	if v.table.tags != nil {
+		rt.tags = make([][]string, 0, v.height)
+
+		for i := 0; i < v.height; i++ {
+			var tags []string
+
+			if v.row+i < len(v.table.tags) {
+				tags = slices.Clone(v.table.tags[v.row+i])
+			}
+
+			rt.tags = append(rt.tags, tags)
+		}
+	}
+
+	return rt
+}
+
+// String implements the fmt.Stringer interface.
+func (v *RuneTableView) String() string {
+	return v.Copy().String()
+}
+
+// TagRow adds a tag to a row, so that the row can be found with RowsWithTag
+// after the table is laid out.
+//
+// Parameters:
+//   - row: The row to tag.
+//   - tag: The tag.
+//
+// Returns:
+//   - error: An error if the row is invalid.
+//
+// Errors:
+//   - *errors.ErrInvalidParameter: If row is out of bounds.
+//
+// Tags follow their rows when rows are added or removed around them, when the
+// table is boxed or joined, and when rows are wrapped, in which case every
+// part of the row keeps the tag. Adding a tag twice has no effect.
+func (rt *RuneTable) TagRow(row int, tag string) error {
+	if row < 0 || row >= len(rt.table) {
+		return gcers.NewErrInvalidParameter("row", gcint.NewErrOutOfBounds(row, 0, len(rt.table)))
+	}
+
+	if rt.tags == nil {
+		rt.tags = make([][]string, len(rt.table))
+	}
+
+	pos, ok := slices.BinarySearch(rt.tags[row], tag)
+	if !ok {
+		rt.tags[row] = slices.Insert(slices.Clip(rt.tags[row]), pos, tag)
+	}
+
+	return nil
+}
+
+// UntagRow removes a tag from a row.
+//
+// Parameters:
+//   - row: The row.
+//   - tag: The tag to remove.
+//
+// Returns:
+//   - bool: True if the row had the tag, false otherwise.
+func (rt *RuneTable) UntagRow(row int, tag string) bool {
+	if row < 0 || row >= len(rt.tags) {
+		return false
+	}
+
+	pos, ok := slices.BinarySearch(rt.tags[row], tag)
+	if ok {
+		rt.tags[row] = slices.Delete(slices.Clone(rt.tags[row]), pos, pos+1)
+	}
+
+	return ok
+}
+
+// RowTags returns the tags of a row.
+//
+// Parameters:
+//   - row: The row.
+//
+// Returns:
+//   - []string: The tags, sorted. Nil if the row has none or does not exist.
+func (rt *RuneTable) RowTags(row int) []string {
+	if row < 0 || row >= len(rt.tags) || len(rt.tags[row]) == 0 {
+		return nil
+	}
+
+	return slices.Clone(rt.tags[row])
+}
+
+// RowsWithTag returns the rows that have a tag.
+//
+// Parameters:
+//   - tag: The tag.
+//
+// Returns:
+//   - []int: The indices of the rows, in increasing order. Nil if none.
+func (rt *RuneTable) RowsWithTag(tag string) []int {
+	var rows []int
+
+	for i, tags := range rt.tags {
+		_, ok := slices.BinarySearch(tags, tag)
+		if ok {
+			rows = append(rows, i)
+		}
+	}
+
+	return rows
+}
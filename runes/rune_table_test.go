@@ -0,0 +1,100 @@
+package runes
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestRuneTableRegions(t *testing.T) {
+	outer, err := NewRuneTable([]string{"+----+", "|    |", "|    |", "+----+"})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	inner, err := NewRuneTable([]string{"ab", "cd"})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	err = outer.Merge(inner, 1, 2)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	const expected = "+----+\n| ab |\n| cd |\n+----+\n"
+	if outer.String() != expected {
+		t.Fatalf("expected %q, got %q", expected, outer.String())
+	}
+
+	sub, err := outer.SubTable(1, 1, 3, 7)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	} else if sub.String() != " ab | \n cd | \n" {
+		t.Errorf("unexpected sub-table %q", sub.String())
+	}
+
+	copied := sub.Copy()
+
+	nested, _ := NewRuneTable([]string{"XY", "Z"})
+
+	err = sub.Merge(nested, 1, 4)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	if sub.SetCell(0, 6, '?') == nil {
+		t.Errorf("expected an error when setting a cell outside of the view")
+	}
+
+	const merged = "+----+\n| ab |\n| cd XY\n+----+\n"
+	if outer.String() != merged {
+		t.Fatalf("expected %q, got %q", merged, outer.String())
+	} else if copied.String() != " ab | \n cd | \n" {
+		t.Errorf("copy of the sub-table changed to %q", copied.String())
+	}
+
+	_ = outer.DeleteRow(2)
+	_ = outer.InsertColumn(1, '#')
+	_ = outer.SetCell(3, 2, '!')
+
+	const edited = "+#----+\n|# ab |\n+#----+\n  !\n"
+	if outer.String() != edited {
+		t.Errorf("expected %q, got %q", edited, outer.String())
+	}
+
+	if outer.DeleteRow(4) == nil {
+		t.Errorf("expected an error when deleting a row out of bounds")
+	}
+}
+
+func TestRowTags(t *testing.T) {
+	table, _ := NewRuneTable([]string{"title", "a long line", "end"})
+
+	_ = table.TagRow(1, "body")
+	_ = table.TagRow(2, "footer")
+
+	bs := NewBoxStyle(BtNormal, false, [4]int{0, 1, 0, 1})
+	bs.MaxWidth = 8
+
+	boxed, err := bs.Apply(table)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	other, _ := NewRuneTable([]string{"x", "y"})
+	_ = other.TagRow(0, "side")
+
+	joined := JoinVertical([]*RuneTable{boxed, other}, 0)
+
+	if rows := joined.RowsWithTag("body"); !slices.Equal(rows, []int{2, 3}) {
+		t.Errorf("expected the body to be on rows [2 3], got %v", rows)
+	}
+
+	if rows := joined.RowsWithTag("footer"); !slices.Equal(rows, []int{4}) {
+		t.Errorf("expected the footer to be on row 4, got %v", rows)
+	}
+
+	if rows := joined.RowsWithTag("side"); !slices.Equal(rows, []int{6}) {
+		t.Errorf("expected the side to be on row 6, got %v", rows)
+	}
+}
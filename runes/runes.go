@@ -1,6 +1,8 @@
 package runes
 
 import (
+	"slices"
+
 	gcers "github.com/PlayerR9/go-commons/errors"
 	gcint "github.com/PlayerR9/go-commons/ints"
 	gcch "github.com/PlayerR9/go-commons/runes"
@@ -14,6 +16,32 @@ type LavenshteinTable struct {
 
 	// word_length_list is the list of word lengths.
 	word_length_list []int
+
+	// display_list is the list of words as they were added.
+	display_list []string
+
+	// cfg is how words are transformed before being compared.
+	cfg match_config
+
+	// ins_cost, del_cost and sub_cost are the costs of inserting, deleting and
+	// substituting a rune. Zero values mean the default cost of 1.
+	ins_cost, del_cost, sub_cost int
+
+	// max_distance is the maximum distance of a match. Only used if has_max
+	// is true.
+	max_distance int
+
+	// has_max is true if there is a cutoff. The zero value has none.
+	has_max bool
+}
+
+// RankedWord is a word of a LevenshteinTable with its distance to a target.
+type RankedWord struct {
+	// Word is the word.
+	Word string
+
+	// Distance is the Levenshtein distance of the word to the target.
+	Distance int
 }
 
 // NewLevenshteinTable creates a new Levenshtein table
@@ -57,12 +85,140 @@ func (lt *LavenshteinTable) AddWord(word string) error {
 		return err
 	}
 
+	if !lt.cfg.is_identity() {
+		chars = []rune(lt.cfg.apply(word))
+	}
+
 	lt.word_list = append(lt.word_list, chars)
 	lt.word_length_list = append(lt.word_length_list, len(chars))
+	lt.display_list = append(lt.display_list, word)
+
+	return nil
+}
+
+// SetMatchOptions sets how words and targets are transformed before their
+// distance is computed; for instance, WithFoldCase makes "Hello" and "hello"
+// the same word. Words already in the table are transformed again.
+//
+// Parameters:
+//   - opts: The options. None means that words are compared as they are.
+//
+// The words returned by Closest and ClosestN are always the words as they were
+// added.
+func (lt *LavenshteinTable) SetMatchOptions(opts ...MatchOption) {
+	lt.cfg = new_match_config(opts)
+
+	for i, word := range lt.display_list {
+		chars := []rune(lt.cfg.apply(word))
+
+		lt.word_list[i] = chars
+		lt.word_length_list[i] = len(chars)
+	}
+}
+
+// SetCosts sets the costs of the edit operations used to compute distances.
+// By default, every operation costs 1.
+//
+// Parameters:
+//   - insertion: The cost of inserting a rune into the target.
+//   - deletion: The cost of deleting a rune from the target.
+//   - substitution: The cost of replacing a rune of the target.
+//
+// Returns:
+//   - error: An error if any of the costs is not positive.
+//
+// Errors:
+//   - *common.ErrInvalidParameter: If a cost is not positive.
+func (lt *LavenshteinTable) SetCosts(insertion, deletion, substitution int) error {
+	if insertion <= 0 {
+		return gcers.NewErrInvalidParameter("insertion", gcint.NewErrGT(0))
+	} else if deletion <= 0 {
+		return gcers.NewErrInvalidParameter("deletion", gcint.NewErrGT(0))
+	} else if substitution <= 0 {
+		return gcers.NewErrInvalidParameter("substitution", gcint.NewErrGT(0))
+	}
+
+	lt.ins_cost = insertion
+	lt.del_cost = deletion
+	lt.sub_cost = substitution
 
 	return nil
 }
 
+// SetMaxDistance sets the maximum distance of a word to the target for it to
+// be returned by Closest and ClosestN.
+//
+// Parameters:
+//   - max: The maximum distance, inclusive. If negative, there is no cutoff,
+//     which is the default.
+func (lt *LavenshteinTable) SetMaxDistance(max int) {
+	lt.max_distance = max
+	lt.has_max = max >= 0
+}
+
+// costs returns the costs of the edit operations.
+//
+// Returns:
+//   - int: The cost of an insertion.
+//   - int: The cost of a deletion.
+//   - int: The cost of a substitution.
+func (lt *LavenshteinTable) costs() (int, int, int) {
+	ins, del, sub := lt.ins_cost, lt.del_cost, lt.sub_cost
+
+	if ins == 0 {
+		ins = 1
+	}
+
+	if del == 0 {
+		del = 1
+	}
+
+	if sub == 0 {
+		sub = 1
+	}
+
+	return ins, del, sub
+}
+
+// rank computes the distance of every word within the cutoff to a target.
+//
+// Parameters:
+//   - target: The target. Must not be empty.
+//
+// Returns:
+//   - []RankedWord: The words, ordered by increasing distance; words at the
+//     same distance keep the order in which they were added.
+func (lt *LavenshteinTable) rank(target []rune) []RankedWord {
+	ins, del, sub := lt.costs()
+
+	if !lt.cfg.is_identity() {
+		target = []rune(lt.cfg.apply(string(target)))
+	}
+
+	target_len := len(target)
+
+	var ranked []RankedWord
+
+	for i, word := range lt.word_list {
+		d := levenshtein_distance(target, target_len, word, lt.word_length_list[i], ins, del, sub)
+
+		if lt.has_max && d > lt.max_distance {
+			continue
+		}
+
+		ranked = append(ranked, RankedWord{
+			Word:     lt.display_list[i],
+			Distance: d,
+		})
+	}
+
+	slices.SortStableFunc(ranked, func(a, b RankedWord) int {
+		return a.Distance - b.Distance
+	})
+
+	return ranked
+}
+
 // Closest gets the closest word to a target.
 //
 // Parameters:
@@ -74,33 +230,52 @@ func (lt *LavenshteinTable) AddWord(word string) error {
 //
 // Errors:
 //   - *common.ErrInvalidParameter: If the target is empty.
-//   - *ErrNoClosestWordFound: If no closest word is found.
+//   - *ErrNoClosestWordFound: If no word is within the maximum distance, or if
+//     the table is empty.
 func (lt *LavenshteinTable) Closest(target []rune) (string, error) {
 	if len(target) == 0 {
 		return "", gcers.NewErrInvalidParameter("target", gcers.NewErrEmpty("slice of runes"))
 	}
 
-	target_len := len(target)
-
-	closest_idx := -1
-	var min int
+	ranked := lt.rank(target)
+	if len(ranked) == 0 {
+		return "", NewErrNoClosestWordFound()
+	}
 
-	for i, word := range lt.word_list {
-		d := levenshtein_distance(target, target_len, word, lt.word_length_list[i])
+	return ranked[0].Word, nil
+}
 
-		if closest_idx == -1 || d < min {
-			min = d
-			closest_idx = i
-		}
+// ClosestN gets the n closest words to a target, with their distances.
+//
+// Parameters:
+//   - target: The target.
+//   - n: The maximum number of words to return. If not positive, every word
+//     within the maximum distance is returned.
+//
+// Returns:
+//   - []RankedWord: The words, closest first. Words at the same distance keep
+//     the order in which they were added.
+//   - error: The error if any occurs.
+//
+// Errors:
+//   - *common.ErrInvalidParameter: If the target is empty.
+//   - *ErrNoClosestWordFound: If no word is within the maximum distance, or if
+//     the table is empty.
+func (lt *LavenshteinTable) ClosestN(target []rune, n int) ([]RankedWord, error) {
+	if len(target) == 0 {
+		return nil, gcers.NewErrInvalidParameter("target", gcers.NewErrEmpty("slice of runes"))
 	}
 
-	if closest_idx == -1 {
-		return "", NewErrNoClosestWordFound()
+	ranked := lt.rank(target)
+	if len(ranked) == 0 {
+		return nil, NewErrNoClosestWordFound()
 	}
 
-	word := lt.word_list[closest_idx]
+	if n > 0 && len(ranked) > n {
+		ranked = ranked[:n]
+	}
 
-	return string(word), nil
+	return ranked, nil
 }
 
 // levenshteinDistance calculates the Levenshtein distance between two strings.
@@ -110,10 +285,13 @@ func (lt *LavenshteinTable) Closest(target []rune) (string, error) {
 //   - target_len: The target length.
 //   - other: The other.
 //   - other_len: The other length.
+//   - ins: The cost of an insertion.
+//   - del: The cost of a deletion.
+//   - sub: The cost of a substitution.
 //
 // Returns:
 //   - int: The Levenshtein distance.
-func levenshtein_distance(target []rune, target_len int, other []rune, other_len int) int {
+func levenshtein_distance(target []rune, target_len int, other []rune, other_len int, ins, del, sub int) int {
 	matrix := make([][]int, 0, target_len+1)
 
 	for i := 0; i <= target_len; i++ {
@@ -124,10 +302,10 @@ func levenshtein_distance(target []rune, target_len int, other []rune, other_len
 
 	// Initialize the matrix
 	for i := 0; i <= target_len; i++ {
-		matrix[i][0] = i
+		matrix[i][0] = i * del
 	}
 	for j := 0; j <= other_len; j++ {
-		matrix[0][j] = j
+		matrix[0][j] = j * ins
 	}
 
 	// Compute the distances
@@ -136,9 +314,9 @@ func levenshtein_distance(target []rune, target_len int, other []rune, other_len
 			if target[i-1] == other[j-1] {
 				matrix[i][j] = matrix[i-1][j-1] // No operation needed
 			} else {
-				deletion := matrix[i-1][j] + 1
-				insertion := matrix[i][j-1] + 1
-				substitution := matrix[i-1][j-1] + 1
+				deletion := matrix[i-1][j] + del
+				insertion := matrix[i][j-1] + ins
+				substitution := matrix[i-1][j-1] + sub
 
 				min_first := luc.Min(deletion, insertion)
 				min_second := luc.Min(min_first, substitution)
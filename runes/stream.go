@@ -2,6 +2,9 @@ package runes
 
 import (
 	"unicode/utf8"
+
+	gcers "github.com/PlayerR9/go-commons/errors"
+	gcint "github.com/PlayerR9/go-commons/ints"
 )
 
 // CharStream is an interface for a character stream.
@@ -41,6 +44,38 @@ type CharStream interface {
 	Accept()
 }
 
+// SeekableStream is a character stream that can move backwards and jump to
+// arbitrary positions.
+type SeekableStream interface {
+	CharStream
+
+	// Prev moves the position back by one and returns the character at the new
+	// position.
+	//
+	// Returns:
+	//   - rune: The previous character. utf8.RuneError if the stream is at its
+	//     start.
+	//   - bool: True if the position moved. False otherwise.
+	Prev() (rune, bool)
+
+	// SeekTo moves the position to the given index.
+	//
+	// Parameters:
+	//   - pos: The new position, between 0 and the length of the stream
+	//     (inclusive).
+	//
+	// Returns:
+	//   - error: An error if the position is out of range.
+	SeekTo(pos int) error
+
+	// Pos returns the current position; that is, the index of the character
+	// that Next returns.
+	//
+	// Returns:
+	//   - int: The current position.
+	Pos() int
+}
+
 // IsDone implements the CharStream interface.
 func (s *Stream) IsDone() bool {
 	return s.pos >= len(s.chars)
@@ -87,6 +122,53 @@ func (s *Stream) Accept() {
 	s.last_accept = s.pos
 }
 
+// Prev implements the SeekableStream interface.
+//
+// If the position moves before the last accepted position, the latter moves
+// back with it.
+func (s *Stream) Prev() (rune, bool) {
+	if s.pos == 0 {
+		return utf8.RuneError, false
+	}
+
+	s.pos--
+
+	if s.last_accept > s.pos {
+		s.last_accept = s.pos
+	}
+
+	return s.chars[s.pos], true
+}
+
+// SeekTo implements the SeekableStream interface.
+//
+// Errors:
+//   - *errors.ErrInvalidParameter: If pos is out of range.
+//
+// If the position moves before the last accepted position, the latter moves
+// back with it.
+func (s *Stream) SeekTo(pos int) error {
+	if pos < 0 || pos > len(s.chars) {
+		reason := gcint.NewErrOutOfBounds(pos, 0, len(s.chars))
+		reason.UpperInclusive = true
+
+		return gcers.NewErrInvalidParameter("pos", reason)
+	}
+
+	s.pos = pos
+
+	if s.last_accept > s.pos {
+		s.last_accept = s.pos
+	}
+
+	return nil
+}
+
+// Pos implements the SeekableStream interface.
+func (s *Stream) Pos() int {
+	return s.pos
+}
+
 // Stream is a character stream.
 type Stream struct {
 	// chars is the character stream.
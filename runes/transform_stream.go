@@ -0,0 +1,232 @@
+package runes
+
+import (
+	"unicode"
+	"unicode/utf8"
+)
+
+// RuneMapper is a function that transforms or filters the characters of a
+// stream.
+//
+// Parameters:
+//   - char: The character.
+//
+// Returns:
+//   - rune: The transformed character.
+//   - bool: False if the character must be skipped, true otherwise.
+type RuneMapper func(char rune) (rune, bool)
+
+var (
+	// fold_config is the configuration used by FoldRune.
+	fold_config match_config
+)
+
+func init() {
+	fold_config = new_match_config([]MatchOption{WithFoldCase()})
+}
+
+// FoldRune is a RuneMapper that folds the case of characters, as WithFoldCase
+// does.
+//
+// Parameters:
+//   - char: The character.
+//
+// Returns:
+//   - rune: The folded character.
+//   - bool: Always true.
+func FoldRune(char rune) (rune, bool) {
+	return fold_config.apply_rune(char), true
+}
+
+// SkipSpace is a RuneMapper that skips white space, as defined by
+// unicode.IsSpace.
+//
+// Parameters:
+//   - char: The character.
+//
+// Returns:
+//   - rune: The character.
+//   - bool: False if the character is white space, true otherwise.
+func SkipSpace(char rune) (rune, bool) {
+	return char, !unicode.IsSpace(char)
+}
+
+// ReplaceRune returns a RuneMapper that replaces a character with another.
+//
+// Parameters:
+//   - from: The character to replace.
+//   - to: The replacement.
+//
+// Returns:
+//   - RuneMapper: The mapper. Never nil.
+//
+// Example:
+//
+//	// Replace tabs with spaces.
+//	stream := TransformStream(cs, ReplaceRune('\t', ' '))
+func ReplaceRune(from, to rune) RuneMapper {
+	return func(char rune) (rune, bool) {
+		if char == from {
+			return to, true
+		}
+
+		return char, true
+	}
+}
+
+// TransformedStream is a CharStream that applies RuneMappers to the
+// characters of another CharStream as they are read.
+type TransformedStream struct {
+	// stream is the wrapped stream.
+	stream CharStream
+
+	// fns are the mappers, applied in order.
+	fns []RuneMapper
+
+	// counts are, for each character read since the last Accept, the number
+	// of characters of the wrapped stream it took, including the skipped
+	// ones.
+	counts []int
+}
+
+// TransformStream wraps a CharStream so that its characters are transformed
+// and filtered lazily, without copying the underlying data.
+//
+// Parameters:
+//   - cs: The stream to wrap.
+//   - fns: The mappers, applied in order to each character. Nil mappers are
+//     ignored.
+//
+// Returns:
+//   - *TransformedStream: The new stream. Nil if cs is nil.
+//
+// Example:
+//
+//	stream := TransformStream(cs, SkipSpace, FoldRune)
+//	word, err := matcher.Match(stream)
+//
+// A character is skipped as soon as a mapper rejects it; the following mappers
+// are not called.
+func TransformStream(cs CharStream, fns ...RuneMapper) *TransformedStream {
+	if cs == nil {
+		return nil
+	}
+
+	var mappers []RuneMapper
+
+	for _, fn := range fns {
+		if fn != nil {
+			mappers = append(mappers, fn)
+		}
+	}
+
+	ts := &TransformedStream{
+		stream: cs,
+		fns:    mappers,
+	}
+
+	return ts
+}
+
+// apply applies the mappers to a character.
+//
+// Parameters:
+//   - char: The character.
+//
+// Returns:
+//   - rune: The transformed character.
+//   - bool: False if the character must be skipped, true otherwise.
+func (ts *TransformedStream) apply(char rune) (rune, bool) {
+	for _, fn := range ts.fns {
+		var ok bool
+
+		char, ok = fn(char)
+		if !ok {
+			return char, false
+		}
+	}
+
+	return char, true
+}
+
+// IsDone implements the CharStream interface.
+//
+// A stream whose remaining characters are all skipped is done.
+func (ts *TransformedStream) IsDone() bool {
+	_, ok := ts.Peek()
+	return !ok
+}
+
+// Next implements the CharStream interface.
+func (ts *TransformedStream) Next() (rune, bool) {
+	var count int
+
+	for {
+		char, ok := ts.stream.Next()
+		if !ok {
+			// Give back the skipped characters so that they are not lost.
+			for ; count > 0; count-- {
+				_ = ts.stream.Refuse()
+			}
+
+			return utf8.RuneError, false
+		}
+
+		count++
+
+		char, ok = ts.apply(char)
+		if ok {
+			ts.counts = append(ts.counts, count)
+
+			return char, true
+		}
+	}
+}
+
+// Peek implements the CharStream interface.
+func (ts *TransformedStream) Peek() (rune, bool) {
+	char, ok := ts.Next()
+	if !ok {
+		return char, false
+	}
+
+	_ = ts.Refuse()
+
+	return char, true
+}
+
+// Refuse implements the CharStream interface.
+//
+// The characters skipped before the refused character are refused as well.
+func (ts *TransformedStream) Refuse() bool {
+	if len(ts.counts) == 0 {
+		return false
+	}
+
+	last := len(ts.counts) - 1
+
+	for i := 0; i < ts.counts[last]; i++ {
+		ok := ts.stream.Refuse()
+		if !ok {
+			return false
+		}
+	}
+
+	ts.counts = ts.counts[:last]
+
+	return true
+}
+
+// RefuseMany implements the CharStream interface.
+func (ts *TransformedStream) RefuseMany() {
+	ts.stream.RefuseMany()
+
+	ts.counts = ts.counts[:0]
+}
+
+// Accept implements the CharStream interface.
+func (ts *TransformedStream) Accept() {
+	ts.stream.Accept()
+
+	ts.counts = ts.counts[:0]
+}
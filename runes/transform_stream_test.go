@@ -0,0 +1,50 @@
+package runes
+
+import (
+	"testing"
+)
+
+func TestTransformStream(t *testing.T) {
+	wm := NewWordMatcher()
+
+	for _, word := range []string{"if", "int"} {
+		err := wm.AddWord(word)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err.Error())
+		}
+	}
+
+	stream := NewStream([]rune(" I\tN T x"))
+	ts := TransformStream(stream, SkipSpace, FoldRune)
+
+	word, err := wm.Match(ts)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	} else if word != "int" {
+		t.Fatalf("expected %q, got %q", "int", word)
+	}
+
+	char, ok := ts.Next()
+	if !ok || char != 'x' {
+		t.Errorf("expected 'x', got %q", char)
+	}
+
+	ts.RefuseMany()
+
+	char, _ = ts.Peek()
+	if char != 'i' {
+		t.Errorf("expected 'i' after RefuseMany, got %q", char)
+	}
+
+	_, _ = ts.Next()
+	_, _ = ts.Next()
+
+	if !ts.Refuse() {
+		t.Fatalf("expected Refuse to succeed")
+	}
+
+	char, _ = ts.Next()
+	if char != 'n' {
+		t.Errorf("expected 'n' after Refuse, got %q", char)
+	}
+}
@@ -0,0 +1,316 @@
+package runes
+
+import (
+	"slices"
+	"strings"
+)
+
+// trie_node is a node of a Trie.
+type trie_node struct {
+	// children are the nodes that follow this one, by character.
+	children map[rune]*trie_node
+
+	// word is the word that ends at this node, if is_end is true.
+	word string
+
+	// is_end is true if a word ends at this node.
+	is_end bool
+}
+
+// collect calls a function on every word at or below the node, in
+// lexicographic order of their characters.
+//
+// Parameters:
+//   - f: The function. Returning false stops the traversal.
+//
+// Returns:
+//   - bool: False if the traversal was stopped, true otherwise.
+func (n *trie_node) collect(f func(word string) bool) bool {
+	if n.is_end && !f(n.word) {
+		return false
+	}
+
+	keys := make([]rune, 0, len(n.children))
+
+	for c := range n.children {
+		keys = append(keys, c)
+	}
+
+	slices.Sort(keys)
+
+	for _, c := range keys {
+		if !n.children[c].collect(f) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Trie is a set of words stored as a tree of characters, so that matching a
+// stream against it takes time proportional to the length of the match
+// rather than to the number of words.
+type Trie struct {
+	// root is the root of the tree. It never ends a word.
+	root *trie_node
+
+	// size is the number of words in the trie.
+	size int
+}
+
+// NewTrie creates a new, empty Trie.
+//
+// Returns:
+//   - *Trie: The new trie. Never nil.
+func NewTrie() *Trie {
+	return &Trie{
+		root: &trie_node{},
+	}
+}
+
+// Size returns the number of words in the trie.
+//
+// Returns:
+//   - int: The number of words.
+func (t *Trie) Size() int {
+	return t.size
+}
+
+// AddWord adds a word to the trie.
+//
+// Parameters:
+//   - word: The word to add.
+//
+// Returns:
+//   - bool: True if the word was added, false if it is empty or already
+//     present.
+func (t *Trie) AddWord(word string) bool {
+	if word == "" {
+		return false
+	}
+
+	curr := t.root
+
+	for _, c := range word {
+		next, ok := curr.children[c]
+		if !ok {
+			if curr.children == nil {
+				curr.children = make(map[rune]*trie_node)
+			}
+
+			next = &trie_node{}
+			curr.children[c] = next
+		}
+
+		curr = next
+	}
+
+	if curr.is_end {
+		return false
+	}
+
+	curr.word = word
+	curr.is_end = true
+	t.size++
+
+	return true
+}
+
+// find returns the node reached by following the characters of a string.
+//
+// Parameters:
+//   - str: The string to follow.
+//
+// Returns:
+//   - *trie_node: The node. Nil if the string leads nowhere.
+func (t *Trie) find(str string) *trie_node {
+	curr := t.root
+
+	for _, c := range str {
+		curr = curr.children[c]
+		if curr == nil {
+			return nil
+		}
+	}
+
+	return curr
+}
+
+// Contains checks whether a word is in the trie.
+//
+// Parameters:
+//   - word: The word to check.
+//
+// Returns:
+//   - bool: True if the word is in the trie, false otherwise.
+func (t *Trie) Contains(word string) bool {
+	node := t.find(word)
+
+	return node != nil && node.is_end
+}
+
+// Remove removes a word from the trie. The branches that no longer lead to a
+// word are pruned.
+//
+// Parameters:
+//   - word: The word to remove.
+//
+// Returns:
+//   - bool: True if the word was removed, false if it was not in the trie.
+func (t *Trie) Remove(word string) bool {
+	if word == "" {
+		return false
+	}
+
+	chars := []rune(word)
+	path := make([]*trie_node, 0, len(chars)+1)
+
+	curr := t.root
+	path = append(path, curr)
+
+	for _, c := range chars {
+		curr = curr.children[c]
+		if curr == nil {
+			return false
+		}
+
+		path = append(path, curr)
+	}
+
+	if !curr.is_end {
+		return false
+	}
+
+	curr.is_end = false
+	curr.word = ""
+	t.size--
+
+	for i := len(chars); i > 0; i-- {
+		node := path[i]
+		if node.is_end || len(node.children) > 0 {
+			break
+		}
+
+		delete(path[i-1].children, chars[i-1])
+	}
+
+	return true
+}
+
+// Walk calls a function on every word that starts with a prefix, in
+// lexicographic order of their characters.
+//
+// Parameters:
+//   - prefix: The prefix. The empty prefix walks every word.
+//   - f: The function. Returning false stops the walk. Does nothing if nil.
+func (t *Trie) Walk(prefix string, f func(word string) bool) {
+	if f == nil {
+		return
+	}
+
+	node := t.find(prefix)
+	if node == nil {
+		return
+	}
+
+	node.collect(f)
+}
+
+// match follows the input stream down the trie for as long as some word can
+// still match.
+//
+// Parameters:
+//   - is: The input stream.
+//   - transform: The function applied to each character before it is
+//     compared; a character may become several, all of which must match for
+//     it to be consumed. Nil means none.
+//   - f: The function called on each node that ends a word, with the number
+//     of characters consumed to reach it.
+//
+// Returns:
+//   - int: The number of characters consumed.
+//   - string: The characters consumed, after transform.
+//
+// Assertions:
+//   - is != nil
+func (t *Trie) match(is CharStream, transform func(rune) []rune, f func(word string, count int)) (int, string) {
+	var builder strings.Builder
+
+	curr := t.root
+	var count int
+
+	for {
+		char, ok := is.Peek()
+		if !ok {
+			break
+		}
+
+		chars := []rune{char}
+
+		if transform != nil {
+			chars = transform(char)
+		}
+
+		if len(chars) == 0 {
+			break
+		}
+
+		next := curr
+
+		for _, c := range chars {
+			next = next.children[c]
+			if next == nil {
+				break
+			}
+		}
+
+		if next == nil {
+			break
+		}
+
+		is.Next()
+		count++
+
+		for _, c := range chars {
+			builder.WriteRune(c)
+		}
+
+		curr = next
+
+		if curr.is_end {
+			f(curr.word, count)
+		}
+	}
+
+	return count, builder.String()
+}
+
+// Match consumes the longest word of the trie that is a prefix of the input
+// stream.
+//
+// Parameters:
+//   - is: The input stream. If nil, nothing is matched.
+//
+// Returns:
+//   - string: The matched word. Empty if none.
+//   - bool: True if a word was matched, false otherwise.
+//
+// Only the characters of the matched word are consumed.
+func (t *Trie) Match(is CharStream) (string, bool) {
+	if is == nil {
+		return "", false
+	}
+
+	var best string
+	var best_count int
+
+	count, _ := t.match(is, nil, func(word string, count int) {
+		best, best_count = word, count
+	})
+
+	for i := best_count; i < count; i++ {
+		_ = is.Refuse()
+	}
+
+	return best, best_count > 0
+}
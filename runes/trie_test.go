@@ -0,0 +1,147 @@
+package runes
+
+import (
+	"strconv"
+	"testing"
+)
+
+func TestTrie(t *testing.T) {
+	trie := NewTrie()
+
+	for _, word := range []string{"foo", "foobar", "bar"} {
+		if !trie.AddWord(word) {
+			t.Errorf("expected %q to be added", word)
+		}
+	}
+
+	if trie.AddWord("foo") {
+		t.Errorf("expected duplicate word not to be added")
+	}
+
+	is := NewStream([]rune("foobaz"))
+
+	word, ok := trie.Match(is)
+	if !ok || word != "foo" || is.Pos() != 3 {
+		t.Errorf("expected foo at position 3, got %q, %t at %d", word, ok, is.Pos())
+	}
+
+	if !trie.Remove("foo") || trie.Contains("foo") || !trie.Contains("foobar") {
+		t.Errorf("expected only foo to be removed")
+	}
+
+	if trie.Remove("foo") {
+		t.Errorf("expected removing a missing word to fail")
+	}
+
+	var words []string
+
+	trie.Walk("", func(word string) bool {
+		words = append(words, word)
+		return true
+	})
+
+	if len(words) != 2 || words[0] != "bar" || words[1] != "foobar" || trie.Size() != 2 {
+		t.Errorf("expected [bar foobar], got %v", words)
+	}
+
+	trie.Remove("foobar")
+
+	if len(trie.root.children) != 1 {
+		t.Errorf("expected the foobar branch to be pruned")
+	}
+}
+
+// keywords returns n distinct keywords sharing long prefixes, as in a large
+// dictionary.
+func keywords(n int) []string {
+	words := make([]string, 0, n)
+
+	for i := 0; i < n; i++ {
+		words = append(words, "keyword_"+strconv.Itoa(i))
+	}
+
+	return words
+}
+
+// linear_match is the previous WordMatcher strategy, kept as a baseline: it
+// filters every word at each character of the input.
+func linear_match(words [][]rune, input []rune) int {
+	indices := make([]int, 0, len(words))
+
+	for i := range words {
+		indices = append(indices, i)
+	}
+
+	best := -1
+
+	for pos := 0; pos < len(input) && len(indices) > 0; pos++ {
+		top := 0
+
+		for _, idx := range indices {
+			word := words[idx]
+
+			if pos < len(word) && word[pos] == input[pos] {
+				indices[top] = idx
+				top++
+
+				if pos+1 == len(word) {
+					best = idx
+				}
+			}
+		}
+
+		indices = indices[:top]
+	}
+
+	return best
+}
+
+func BenchmarkLinearMatch(b *testing.B) {
+	words := keywords(5000)
+
+	list := make([][]rune, 0, len(words))
+
+	for _, word := range words {
+		list = append(list, []rune(word))
+	}
+
+	input := []rune("keyword_4999 rest")
+
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		linear_match(list, input)
+	}
+}
+
+func BenchmarkTrieMatch(b *testing.B) {
+	trie := NewTrie()
+
+	for _, word := range keywords(5000) {
+		trie.AddWord(word)
+	}
+
+	input := []rune("keyword_4999 rest")
+
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		trie.Match(NewStream(input))
+	}
+}
+
+func BenchmarkWordMatcherMatch(b *testing.B) {
+	wm := NewWordMatcher()
+
+	for _, word := range keywords(5000) {
+		_ = wm.AddWord(word)
+	}
+
+	input := []rune("keyword_4999 rest")
+
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		_, _ = wm.Match(NewStream(input))
+	}
+}
@@ -0,0 +1,511 @@
+package runes
+
+import (
+	"unicode"
+
+	"golang.org/x/text/width"
+)
+
+// RuneWidth returns the number of terminal columns a character occupies.
+//
+// Parameters:
+//   - char: The character.
+//
+// Returns:
+//   - int: 0 for control characters, combining marks and other zero-width
+//     characters; 2 for East Asian wide and fullwidth characters; 1 otherwise.
+func RuneWidth(char rune) int {
+	switch {
+	case char < 0x20, char >= 0x7f && char < 0xa0:
+		return 0
+	case unicode.In(char, unicode.Mn, unicode.Me, unicode.Cf):
+		return 0
+	}
+
+	switch width.LookupRune(char).Kind() {
+	case width.EastAsianWide, width.EastAsianFullwidth:
+		return 2
+	default:
+		return 1
+	}
+}
+
+// ansi_sequence_len returns the length of the ANSI CSI escape sequence, such
+// as "\x1b[31m", at the start of a line.
+//
+// Parameters:
+//   - chars: The characters.
+//
+// Returns:
+//   - int: The number of characters of the sequence. 0 if chars does not start
+//     with a complete sequence.
+func ansi_sequence_len(chars []rune) int {
+	if len(chars) < 2 || chars[0] != '\x1b' || chars[1] != '[' {
+		return 0
+	}
+
+	i := 2
+
+	for i < len(chars) && chars[i] >= 0x30 && chars[i] <= 0x3f {
+		i++
+	}
+
+	for i < len(chars) && chars[i] >= 0x20 && chars[i] <= 0x2f {
+		i++
+	}
+
+	if i < len(chars) && chars[i] >= 0x40 && chars[i] <= 0x7e {
+		return i + 1
+	}
+
+	return 0
+}
+
+// DisplayWidth returns the number of terminal columns a line occupies.
+//
+// Parameters:
+//   - line: The characters of the line.
+//
+// Returns:
+//   - int: The sum of the widths of the characters. See RuneWidth.
+//
+// ANSI escape sequences, such as the ones written by strings.Style, occupy
+// no column.
+func DisplayWidth(line []rune) int {
+	return display_width(line, false)
+}
+
+// next_cell returns the length and the width of the first unit of a line; that
+// is, of its first ANSI escape sequence, grapheme cluster or character.
+//
+// Parameters:
+//   - chars: The characters of the line.
+//   - by_grapheme: Whether units are grapheme clusters instead of characters.
+//
+// Returns:
+//   - int: The number of characters of the unit.
+//   - int: The number of columns of the unit.
+//
+// Assertions:
+//   - len(chars) > 0
+func next_cell(chars []rune, by_grapheme bool) (int, int) {
+	n := ansi_sequence_len(chars)
+	if n > 0 {
+		return n, 0
+	}
+
+	if !by_grapheme {
+		return 1, RuneWidth(chars[0])
+	}
+
+	n = next_grapheme(chars)
+
+	return n, GraphemeWidth(chars[:n])
+}
+
+// display_width is like DisplayWidth but can measure grapheme clusters
+// instead of characters.
+//
+// Parameters:
+//   - line: The characters of the line.
+//   - by_grapheme: Whether to measure grapheme clusters. See GraphemeWidth.
+//
+// Returns:
+//   - int: The number of columns.
+func display_width(line []rune, by_grapheme bool) int {
+	var total int
+
+	for i := 0; i < len(line); {
+		n, w := next_cell(line[i:], by_grapheme)
+
+		total += w
+		i += n
+	}
+
+	return total
+}
+
+// TruncateDisplay shortens a line so that it fits in the given number of
+// terminal columns, marking the cut with an ellipsis.
+//
+// Parameters:
+//   - line: The characters of the line.
+//   - maxWidth: The maximum number of columns.
+//   - ellipsis: The characters that replace the removed ones, such as "…".
+//     May be empty.
+//
+// Returns:
+//   - []rune: The line itself if it fits; otherwise, a new slice with its
+//     longest prefix that fits together with the ellipsis. Nil if maxWidth is
+//     not positive.
+//
+// Behaviors:
+//   - A wide character that would straddle the limit is removed whole.
+//   - If the ellipsis alone is wider than maxWidth, it is itself truncated.
+//   - ANSI escape sequences of the removed characters are kept after the
+//     ellipsis, so that styles are still reset.
+func TruncateDisplay(line []rune, maxWidth int, ellipsis []rune) []rune {
+	return truncate_display(line, maxWidth, ellipsis, false)
+}
+
+// truncate_display is like TruncateDisplay but can measure grapheme clusters
+// instead of characters, in which case clusters are removed whole.
+//
+// Parameters:
+//   - line: The characters of the line.
+//   - maxWidth: The maximum number of columns.
+//   - ellipsis: The characters that replace the removed ones.
+//   - by_grapheme: Whether to measure grapheme clusters.
+//
+// Returns:
+//   - []rune: The truncated line.
+func truncate_display(line []rune, maxWidth int, ellipsis []rune, by_grapheme bool) []rune {
+	if maxWidth <= 0 {
+		return nil
+	}
+
+	if display_width(line, by_grapheme) <= maxWidth {
+		return line
+	}
+
+	ellipsis_width := display_width(ellipsis, by_grapheme)
+	if ellipsis_width > maxWidth {
+		return truncate_display(ellipsis, maxWidth, nil, by_grapheme)
+	}
+
+	limit := maxWidth - ellipsis_width
+
+	result := make([]rune, 0, limit+len(ellipsis))
+
+	var curr int
+	var i int
+
+	for i < len(line) {
+		n, w := next_cell(line[i:], by_grapheme)
+		if curr+w > limit {
+			break
+		}
+
+		result = append(result, line[i:i+n]...)
+		curr += w
+		i += n
+	}
+
+	result = append(result, ellipsis...)
+
+	for i < len(line) {
+		n := ansi_sequence_len(line[i:])
+		if n > 0 {
+			result = append(result, line[i:i+n]...)
+			i += n
+		} else {
+			i++
+		}
+	}
+
+	return result
+}
+
+// is_sgr_reset checks whether an ANSI escape sequence resets every style.
+//
+// Parameters:
+//   - seq: The sequence.
+//
+// Returns:
+//   - bool: True if the sequence is "\x1b[0m" or "\x1b[m", false otherwise.
+func is_sgr_reset(seq []rune) bool {
+	params := string(seq[2 : len(seq)-1])
+
+	return seq[len(seq)-1] == 'm' && (params == "" || params == "0")
+}
+
+// carry_styles makes every line of a wrapped line self-contained: the styles
+// that are active at the end of a line are reset there and set again at the
+// start of the next one, so that they do not leak into what surrounds the
+// lines, such as the borders of a box.
+//
+// Parameters:
+//   - lines: The lines. They are modified in place.
+func carry_styles(lines [][]rune) {
+	var active []rune
+
+	for i, line := range lines {
+		var out []rune
+
+		out = append(out, active...)
+		out = append(out, line...)
+
+		for j := 0; j < len(line); {
+			n := ansi_sequence_len(line[j:])
+			if n == 0 {
+				j++
+				continue
+			}
+
+			seq := line[j : j+n]
+
+			if is_sgr_reset(seq) {
+				active = nil
+			} else if seq[n-1] == 'm' {
+				active = append(active, seq...)
+			}
+
+			j += n
+		}
+
+		if len(active) > 0 && i < len(lines)-1 {
+			out = append(out, []rune("\x1b[0m")...)
+		}
+
+		lines[i] = out
+	}
+}
+
+// has_escape checks whether a line contains an escape character.
+//
+// Parameters:
+//   - line: The line.
+//
+// Returns:
+//   - bool: True if the line contains '\x1b', false otherwise.
+func has_escape(line []rune) bool {
+	for _, char := range line {
+		if char == '\x1b' {
+			return true
+		}
+	}
+
+	return false
+}
+
+// HardWrapDisplay cuts a line into lines that fit in the given number of
+// terminal columns, regardless of words.
+//
+// Parameters:
+//   - line: The characters of the line.
+//   - maxWidth: The maximum number of columns of a line.
+//
+// Returns:
+//   - [][]rune: The lines. Only the line itself if it fits or if maxWidth is
+//     not positive.
+//
+// Behaviors:
+//   - A character wider than maxWidth is put on a line of its own.
+//   - ANSI styles that span several lines are reset at the end of each line
+//     and set again at the start of the next one.
+func HardWrapDisplay(line []rune, maxWidth int) [][]rune {
+	return hard_wrap_display(line, maxWidth, false)
+}
+
+// hard_wrap_display is like HardWrapDisplay but can measure grapheme clusters
+// instead of characters, in which case clusters are never cut.
+//
+// Parameters:
+//   - line: The characters of the line.
+//   - maxWidth: The maximum number of columns of a line.
+//   - by_grapheme: Whether to measure grapheme clusters.
+//
+// Returns:
+//   - [][]rune: The lines.
+func hard_wrap_display(line []rune, maxWidth int, by_grapheme bool) [][]rune {
+	if maxWidth <= 0 || display_width(line, by_grapheme) <= maxWidth {
+		return [][]rune{line}
+	}
+
+	lines := hard_wrap(line, maxWidth, by_grapheme)
+
+	if has_escape(line) {
+		carry_styles(lines)
+	}
+
+	return lines
+}
+
+// hard_wrap cuts a line into lines that fit in the given number of terminal
+// columns, without carrying the styles over.
+//
+// Parameters:
+//   - line: The characters of the line.
+//   - maxWidth: The maximum number of columns of a line.
+//   - by_grapheme: Whether to measure grapheme clusters.
+//
+// Returns:
+//   - [][]rune: The lines. They share their storage with line.
+//
+// Assertions:
+//   - maxWidth > 0
+func hard_wrap(line []rune, maxWidth int, by_grapheme bool) [][]rune {
+	var lines [][]rune
+	var start, curr int
+
+	for i := 0; i < len(line); {
+		n, w := next_cell(line[i:], by_grapheme)
+
+		if curr+w > maxWidth && curr > 0 {
+			// The capacity is limited so that growing a line never overwrites
+			// the next one.
+			lines = append(lines, line[start:i:i])
+			start, curr = i, 0
+		}
+
+		curr += w
+		i += n
+	}
+
+	lines = append(lines, line[start:len(line):len(line)])
+
+	return lines
+}
+
+// WrapDisplay breaks a line at its break opportunities into lines that fit in
+// the given number of terminal columns.
+//
+// Parameters:
+//   - line: The characters of the line.
+//   - maxWidth: The maximum number of columns of a line.
+//
+// Returns:
+//   - [][]rune: The lines. Only the line itself if it fits or if maxWidth is
+//     not positive.
+//
+// Behaviors:
+//   - The line is broken where BreakOpportunities allows it; that is, after
+//     spaces and hyphens, and between ideographic characters.
+//   - The spaces at which the line is broken are removed, as are trailing
+//     spaces; the indentation of the first line is kept if it fits.
+//   - Segments between two break opportunities that are wider than maxWidth
+//     are cut as HardWrapDisplay does.
+//   - ANSI styles that span several lines are reset at the end of each line
+//     and set again at the start of the next one.
+func WrapDisplay(line []rune, maxWidth int) [][]rune {
+	return wrap_display(line, maxWidth, false)
+}
+
+// trailing_spaces returns the index at which the trailing spaces of a segment
+// start.
+//
+// Parameters:
+//   - segment: The segment.
+//
+// Returns:
+//   - int: The index of the first trailing space. len(segment) if there are
+//     none.
+func trailing_spaces(segment []rune) int {
+	end := len(segment)
+
+	for end > 0 && unicode.IsSpace(segment[end-1]) {
+		end--
+	}
+
+	return end
+}
+
+// trim_left_spaces removes the leading spaces of a segment.
+//
+// Parameters:
+//   - segment: The segment.
+//
+// Returns:
+//   - []rune: The segment without its leading spaces.
+func trim_left_spaces(segment []rune) []rune {
+	for len(segment) > 0 && unicode.IsSpace(segment[0]) {
+		segment = segment[1:]
+	}
+
+	return segment
+}
+
+// wrap_display is like WrapDisplay but can measure grapheme clusters instead
+// of characters.
+//
+// Parameters:
+//   - line: The characters of the line.
+//   - maxWidth: The maximum number of columns of a line.
+//   - by_grapheme: Whether to measure grapheme clusters.
+//
+// Returns:
+//   - [][]rune: The lines.
+func wrap_display(line []rune, maxWidth int, by_grapheme bool) [][]rune {
+	if maxWidth <= 0 || display_width(line, by_grapheme) <= maxWidth {
+		return [][]rune{line}
+	}
+
+	breaks := append(BreakOpportunities(line), len(line))
+
+	// The indentation is part of the first segment.
+	indent := len(line) - len(trim_left_spaces(line))
+
+	for breaks[0] <= indent && breaks[0] < len(line) {
+		breaks = breaks[1:]
+	}
+
+	var lines [][]rune
+	var curr []rune
+	var curr_width int
+
+	// spaces are the trailing spaces of curr, which are only kept if more
+	// characters follow on the same line.
+	var spaces []rune
+
+	var start int
+
+	for _, end := range breaks {
+		segment := line[start:end]
+		start = end
+
+		idx := trailing_spaces(segment)
+		body := segment[:idx]
+
+		if len(body) == 0 {
+			continue
+		}
+
+		body_width := display_width(body, by_grapheme)
+
+		if len(curr) > 0 {
+			spaces_width := display_width(spaces, by_grapheme)
+
+			if curr_width+spaces_width+body_width <= maxWidth {
+				curr = append(curr, spaces...)
+				curr = append(curr, body...)
+				curr_width += spaces_width + body_width
+				spaces = segment[idx:]
+
+				continue
+			}
+
+			lines = append(lines, curr)
+			curr, curr_width = nil, 0
+		}
+
+		spaces = segment[idx:]
+
+		if body_width <= maxWidth {
+			curr = append(curr, body...)
+			curr_width = body_width
+
+			continue
+		}
+
+		// Only the first segment may start with spaces: its indentation.
+		body = trim_left_spaces(body)
+
+		chunks := hard_wrap(body, maxWidth, by_grapheme)
+		last := chunks[len(chunks)-1]
+
+		lines = append(lines, chunks[:len(chunks)-1]...)
+
+		curr = append(curr, last...)
+		curr_width = display_width(last, by_grapheme)
+	}
+
+	if len(curr) > 0 || len(lines) == 0 {
+		lines = append(lines, curr)
+	}
+
+	if has_escape(line) {
+		carry_styles(lines)
+	}
+
+	return lines
+}
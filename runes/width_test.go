@@ -0,0 +1,36 @@
+package runes
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestWrapDisplay(t *testing.T) {
+	tests := []struct {
+		line     string
+		maxWidth int
+		expected []string
+	}{
+		{"hello world", 20, []string{"hello world"}},
+		{"hello big world", 9, []string{"hello big", "world"}},
+		{"  indented text here", 12, []string{"  indented", "text here"}},
+		{"well-known fact", 8, []string{"well-", "known", "fact"}},
+		{"日本語のテキスト", 6, []string{"日本語", "のテキ", "スト"}},
+		{"abcdefghij xy", 4, []string{"abcd", "efgh", "ij", "xy"}},
+		{"trailing   ", 5, []string{"trail", "ing"}},
+	}
+
+	for _, test := range tests {
+		lines := WrapDisplay([]rune(test.line), test.maxWidth)
+
+		got := make([]string, 0, len(lines))
+
+		for _, line := range lines {
+			got = append(got, string(line))
+		}
+
+		if !slices.Equal(got, test.expected) {
+			t.Errorf("WrapDisplay(%q, %d) = %q, want %q", test.line, test.maxWidth, got, test.expected)
+		}
+	}
+}
@@ -0,0 +1,167 @@
+package slices
+
+import (
+	"errors"
+
+	luc "github.com/PlayerR9/lib_units/common"
+)
+
+// Chunk splits a slice into consecutive chunks of the same size.
+//
+// Parameters:
+//   - S: slice of elements.
+//   - size: the number of elements of each chunk.
+//
+// Returns:
+//   - [][]T: the chunks, in order. Nil if S is empty or size <= 0.
+//
+// Example:
+//
+//	Chunk([]int{1, 2, 3, 4, 5}, 2) // [[1 2] [3 4] [5]]
+//
+// Behaviors:
+//   - The last chunk is shorter if len(S) is not a multiple of size.
+//   - The chunks share the memory of S, but appending to one of them does not
+//     overwrite the next one.
+func Chunk[T any](S []T, size int) [][]T {
+	if len(S) == 0 || size <= 0 {
+		return nil
+	}
+
+	chunks := make([][]T, 0, (len(S)+size-1)/size)
+
+	for start := 0; start < len(S); start += size {
+		end := min(start+size, len(S))
+
+		chunks = append(chunks, S[start:end:end])
+	}
+
+	return chunks
+}
+
+// Window returns the sliding windows of a slice.
+//
+// Parameters:
+//   - S: slice of elements.
+//   - size: the number of elements of each window.
+//   - step: the distance between the starts of two consecutive windows.
+//
+// Returns:
+//   - [][]T: the windows, in order. Nil if S is shorter than size, or if size
+//     or step is not positive.
+//
+// Example:
+//
+//	Window([]int{1, 2, 3, 4, 5}, 3, 1) // [[1 2 3] [2 3 4] [3 4 5]]
+//	Window([]int{1, 2, 3, 4, 5}, 2, 2) // [[1 2] [3 4]]
+//
+// Behaviors:
+//   - Only whole windows are returned; trailing elements that do not fill a
+//     window are dropped.
+//   - The windows share the memory of S, but appending to one of them does not
+//     overwrite the elements after it.
+func Window[T any](S []T, size, step int) [][]T {
+	if size <= 0 || step <= 0 || len(S) < size {
+		return nil
+	}
+
+	windows := make([][]T, 0, (len(S)-size)/step+1)
+
+	for start := 0; start+size <= len(S); start += step {
+		end := start + size
+
+		windows = append(windows, S[start:end:end])
+	}
+
+	return windows
+}
+
+// ChunkIterator is an iterator that lazily groups the elements of another
+// iterator into chunks of the same size.
+type ChunkIterator[T any] struct {
+	// src is the wrapped iterator.
+	src luc.Iterater[T]
+
+	// size is the number of elements of each chunk.
+	size int
+
+	// done is true once src is exhausted.
+	done bool
+}
+
+// Consume implements the common.Iterater interface.
+//
+// Errors:
+//   - *common.ErrExhaustedIter: If there are no more elements.
+//   - any error returned by the wrapped iterator. In that case, the elements
+//     of the chunk read so far are returned with it.
+//
+// The last chunk is shorter if the number of elements is not a multiple of the
+// size.
+func (ci *ChunkIterator[T]) Consume() ([]T, error) {
+	if ci.done {
+		return nil, luc.NewErrExhaustedIter()
+	}
+
+	chunk := make([]T, 0, ci.size)
+
+	for len(chunk) < ci.size {
+		elem, err := ci.src.Consume()
+		if errors.Is(err, luc.ExhaustedIter) {
+			ci.done = true
+			break
+		} else if err != nil {
+			return chunk, err
+		}
+
+		chunk = append(chunk, elem)
+	}
+
+	if len(chunk) == 0 {
+		return nil, luc.NewErrExhaustedIter()
+	}
+
+	return chunk, nil
+}
+
+// Restart implements the common.Iterater interface.
+func (ci *ChunkIterator[T]) Restart() {
+	ci.src.Restart()
+
+	ci.done = false
+}
+
+// NewChunkIterator creates a new ChunkIterator.
+//
+// Parameters:
+//   - src: The iterator whose elements are grouped.
+//   - size: The number of elements of each chunk.
+//
+// Returns:
+//   - *ChunkIterator[T]: The new iterator. Nil if src is nil or size <= 0.
+//
+// Example:
+//
+//	chunks := NewChunkIterator(items, 64)
+//
+//	for {
+//		batch, err := chunks.Consume()
+//		if err != nil {
+//			break
+//		}
+//
+//		results, _ := helpers.EvaluateSimpleHelpers(batch, eval)
+//		// ...
+//	}
+func NewChunkIterator[T any](src luc.Iterater[T], size int) *ChunkIterator[T] {
+	if src == nil || size <= 0 {
+		return nil
+	}
+
+	ci := &ChunkIterator[T]{
+		src:  src,
+		size: size,
+	}
+
+	return ci
+}
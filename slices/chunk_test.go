@@ -0,0 +1,58 @@
+package slices
+
+import (
+	"fmt"
+	"testing"
+
+	luc "github.com/PlayerR9/lib_units/common"
+)
+
+func TestChunkWindow(t *testing.T) {
+	S := []int{1, 2, 3, 4, 5}
+
+	if got := fmt.Sprint(Chunk(S, 2)); got != "[[1 2] [3 4] [5]]" {
+		t.Errorf("expected [[1 2] [3 4] [5]], got %s", got)
+	}
+
+	if got := fmt.Sprint(Window(S, 3, 1)); got != "[[1 2 3] [2 3 4] [3 4 5]]" {
+		t.Errorf("expected [[1 2 3] [2 3 4] [3 4 5]], got %s", got)
+	}
+
+	if got := fmt.Sprint(Window(S, 2, 2)); got != "[[1 2] [3 4]]" {
+		t.Errorf("expected [[1 2] [3 4]], got %s", got)
+	}
+
+	chunks := Chunk(S, 2)
+	_ = append(chunks[0], 9)
+
+	if S[2] != 3 {
+		t.Errorf("expected appending to a chunk not to overwrite the next one")
+	}
+
+	if Chunk(S, 0) != nil || Window(S, 6, 1) != nil {
+		t.Errorf("expected nil results for invalid sizes")
+	}
+}
+
+func TestChunkIterator(t *testing.T) {
+	iter := NewChunkIterator[int](luc.NewSimpleIterator([]int{0, 1, 2, 3, 4}), 2)
+
+	for pass := 0; pass < 2; pass++ {
+		var chunks [][]int
+
+		for {
+			chunk, err := iter.Consume()
+			if err != nil {
+				break
+			}
+
+			chunks = append(chunks, chunk)
+		}
+
+		if got := fmt.Sprint(chunks); got != "[[0 1] [2 3] [4]]" {
+			t.Errorf("expected [[0 1] [2 3] [4]], got %s", got)
+		}
+
+		iter.Restart()
+	}
+}
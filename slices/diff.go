@@ -0,0 +1,368 @@
+package slices
+
+import (
+	"errors"
+	"strconv"
+
+	gcers "github.com/PlayerR9/go-commons/errors"
+	gcint "github.com/PlayerR9/go-commons/ints"
+)
+
+// EditOp is the kind of an edit.
+type EditOp int
+
+const (
+	// EditKeep keeps an element of the old slice.
+	EditKeep EditOp = iota
+
+	// EditInsert inserts an element of the new slice.
+	EditInsert
+
+	// EditDelete deletes an element of the old slice.
+	EditDelete
+)
+
+// String implements the fmt.Stringer interface.
+func (op EditOp) String() string {
+	switch op {
+	case EditKeep:
+		return "keep"
+	case EditInsert:
+		return "insert"
+	case EditDelete:
+		return "delete"
+	default:
+		return "EditOp(" + strconv.Itoa(int(op)) + ")"
+	}
+}
+
+// Edit is one step of a script that turns a slice into another.
+type Edit[T any] struct {
+	// Op is the kind of the edit.
+	Op EditOp
+
+	// OldIndex is the index of the element in the old slice. For insertions,
+	// it is the index of the old element before which the element is inserted.
+	OldIndex int
+
+	// NewIndex is the index of the element in the new slice. For deletions,
+	// it is the index of the new element before which the element was.
+	NewIndex int
+
+	// Elem is the element concerned by the edit.
+	Elem T
+}
+
+// Diff computes a shortest edit script that turns one slice into another,
+// using Myers' algorithm.
+//
+// Parameters:
+//   - from: The old slice.
+//   - to: The new slice.
+//
+// Returns:
+//   - []Edit[T]: The edits, one per element, in order. Nil if both slices are
+//     empty.
+//
+// Behaviors:
+//   - The number of insertions and deletions is minimal.
+//   - Patch(from, Diff(from, to)) yields to.
+func Diff[T comparable](from, to []T) []Edit[T] {
+	return DiffFunc(from, to, func(a, b T) bool {
+		return a == b
+	})
+}
+
+// DiffFunc is the same as Diff but uses the given function to compare the
+// elements.
+//
+// Parameters:
+//   - from: The old slice.
+//   - to: The new slice.
+//   - eq: the equality function.
+//
+// Returns:
+//   - []Edit[T]: The edits, one per element, in order. Nil if both slices are
+//     empty or if eq is nil.
+//
+// Kept elements are taken from the old slice. It takes O((N+M)·D) time and
+// O(N+M) memory, where D is the number of insertions and deletions.
+func DiffFunc[T any](from, to []T, eq EqualsFunc[T]) []Edit[T] {
+	if eq == nil {
+		return nil
+	}
+
+	n, m := len(from), len(to)
+	if n == 0 && m == 0 {
+		return nil
+	}
+
+	size := n + m + 3
+
+	d := &differ[T]{
+		from:     from,
+		to:       to,
+		eq:       eq,
+		forward:  make([]int, size),
+		backward: make([]int, size),
+		edits:    make([]Edit[T], 0, n+m),
+	}
+
+	d.diff(0, n, 0, m)
+
+	return d.edits
+}
+
+// differ is the state of DiffFunc. The forward and backward buffers are
+// shared by every call of bisect.
+type differ[T any] struct {
+	from, to          []T
+	eq                EqualsFunc[T]
+	forward, backward []int
+	edits             []Edit[T]
+}
+
+// add appends an edit.
+//
+// Parameters:
+//   - op: The kind of the edit.
+//   - x: The index in the old slice.
+//   - y: The index in the new slice.
+func (d *differ[T]) add(op EditOp, x, y int) {
+	edit := Edit[T]{Op: op, OldIndex: x, NewIndex: y}
+
+	if op == EditInsert {
+		edit.Elem = d.to[y]
+	} else {
+		edit.Elem = d.from[x]
+	}
+
+	d.edits = append(d.edits, edit)
+}
+
+// diff appends the edits that turn from[x0:x1] into to[y0:y1].
+func (d *differ[T]) diff(x0, x1, y0, y1 int) {
+	for x0 < x1 && y0 < y1 && d.eq(d.from[x0], d.to[y0]) {
+		d.add(EditKeep, x0, y0)
+
+		x0++
+		y0++
+	}
+
+	var suffix int
+
+	for x0 < x1 && y0 < y1 && d.eq(d.from[x1-1], d.to[y1-1]) {
+		x1--
+		y1--
+		suffix++
+	}
+
+	switch {
+	case x0 == x1:
+		for y := y0; y < y1; y++ {
+			d.add(EditInsert, x0, y)
+		}
+	case y0 == y1:
+		for x := x0; x < x1; x++ {
+			d.add(EditDelete, x, y0)
+		}
+	default:
+		x, y, ok := d.bisect(x0, x1, y0, y1)
+
+		if ok {
+			d.diff(x0, x, y0, y)
+			d.diff(x, x1, y, y1)
+		} else {
+			for x := x0; x < x1; x++ {
+				d.add(EditDelete, x, y0)
+			}
+
+			for y := y0; y < y1; y++ {
+				d.add(EditInsert, x1, y)
+			}
+		}
+	}
+
+	for i := 0; i < suffix; i++ {
+		d.add(EditKeep, x1+i, y1+i)
+	}
+}
+
+// bisect finds a point through which a shortest edit script of from[x0:x1]
+// into to[y0:y1] goes, by running Myers' algorithm from both ends until the
+// two paths meet.
+//
+// Returns:
+//   - int: The index of the point in the old slice.
+//   - int: The index of the point in the new slice.
+//   - bool: False if the paths never met.
+func (d *differ[T]) bisect(x0, x1, y0, y1 int) (int, int, bool) {
+	n, m := x1-x0, y1-y0
+
+	max_d := (n + m + 1) / 2
+	offset := max_d
+	length := 2*max_d + 2
+
+	// forward[offset+k] is the furthest x reached on the diagonal k from the
+	// start; backward[offset+k] is the same from the end, in reverse.
+	forward := d.forward[:length]
+	backward := d.backward[:length]
+
+	for i := range forward {
+		forward[i] = -1
+		backward[i] = -1
+	}
+
+	forward[offset+1] = 0
+	backward[offset+1] = 0
+
+	delta := n - m
+	odd := delta%2 != 0
+
+	// The diagonals that left the grid are skipped.
+	var f_start, f_end, b_start, b_end int
+
+	for step := 0; step < max_d; step++ {
+		for k := -step + f_start; k < step+1-f_end; k += 2 {
+			var x int
+
+			if k == -step || (k != step && forward[offset+k-1] < forward[offset+k+1]) {
+				x = forward[offset+k+1]
+			} else {
+				x = forward[offset+k-1] + 1
+			}
+
+			y := x - k
+
+			for x < n && y < m && d.eq(d.from[x0+x], d.to[y0+y]) {
+				x++
+				y++
+			}
+
+			forward[offset+k] = x
+
+			if x > n {
+				f_end += 2
+			} else if y > m {
+				f_start += 2
+			} else if odd {
+				idx := offset + delta - k
+
+				if idx >= 0 && idx < length && backward[idx] != -1 && x >= n-backward[idx] {
+					return x0 + x, y0 + y, true
+				}
+			}
+		}
+
+		for k := -step + b_start; k < step+1-b_end; k += 2 {
+			var x int
+
+			if k == -step || (k != step && backward[offset+k-1] < backward[offset+k+1]) {
+				x = backward[offset+k+1]
+			} else {
+				x = backward[offset+k-1] + 1
+			}
+
+			y := x - k
+
+			for x < n && y < m && d.eq(d.from[x1-x-1], d.to[y1-y-1]) {
+				x++
+				y++
+			}
+
+			backward[offset+k] = x
+
+			if x > n {
+				b_end += 2
+			} else if y > m {
+				b_start += 2
+			} else if !odd {
+				idx := offset + delta - k
+
+				if idx >= 0 && idx < length && forward[idx] != -1 {
+					fx := forward[idx]
+					fy := fx - (idx - offset)
+
+					if fx >= n-x {
+						return x0 + fx, y0 + fy, true
+					}
+				}
+			}
+		}
+	}
+
+	return 0, 0, false
+}
+
+// Patch applies an edit script to a slice.
+//
+// Parameters:
+//   - from: The slice to patch.
+//   - edits: The edits, as returned by Diff.
+//
+// Returns:
+//   - []T: The patched slice.
+//   - error: An error if the edits do not apply to the slice.
+//
+// Errors:
+//   - *ints.ErrAt: If an edit keeps or deletes an element that is not the
+//     next one of the slice, if its operation is unknown, or if the edits do
+//     not consume the whole slice (in which case the index is len(edits)+1).
+//     Indices are 1-based.
+//
+// The slice is not modified.
+func Patch[T comparable](from []T, edits []Edit[T]) ([]T, error) {
+	return PatchFunc(from, edits, func(a, b T) bool {
+		return a == b
+	})
+}
+
+// PatchFunc is the same as Patch but uses the given function to compare the
+// elements of the slice with those of the edits.
+//
+// Parameters:
+//   - from: The slice to patch.
+//   - edits: The edits, as returned by DiffFunc.
+//   - eq: the equality function.
+//
+// Returns:
+//   - []T: The patched slice.
+//   - error: An error if the edits do not apply to the slice.
+//
+// Errors:
+//   - *errors.ErrInvalidParameter: If eq is nil.
+//   - *ints.ErrAt: As in Patch.
+func PatchFunc[T any](from []T, edits []Edit[T], eq EqualsFunc[T]) ([]T, error) {
+	if eq == nil {
+		return nil, gcers.NewErrNilParameter("eq")
+	}
+
+	result := make([]T, 0, len(from))
+
+	i := 0
+
+	for j, edit := range edits {
+		switch edit.Op {
+		case EditKeep, EditDelete:
+			if i >= len(from) || edit.OldIndex != i || !eq(from[i], edit.Elem) {
+				return nil, gcint.NewErrAt(j+1, "edit", errors.New("element does not match the slice"))
+			}
+
+			if edit.Op == EditKeep {
+				result = append(result, from[i])
+			}
+
+			i++
+		case EditInsert:
+			result = append(result, edit.Elem)
+		default:
+			return nil, gcint.NewErrAt(j+1, "edit", errors.New("unknown operation "+edit.Op.String()))
+		}
+	}
+
+	if i != len(from) {
+		return nil, gcint.NewErrAt(len(edits)+1, "edit", errors.New("edits do not cover the whole slice"))
+	}
+
+	return result, nil
+}
@@ -0,0 +1,179 @@
+package slices
+
+import (
+	"errors"
+	"math/rand"
+	"runtime"
+	"slices"
+	"strings"
+	"testing"
+
+	gcint "github.com/PlayerR9/go-commons/ints"
+)
+
+func TestDiffPatch(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+
+	for i := 0; i < 200; i++ {
+		from := make([]int, r.Intn(12))
+		for j := range from {
+			from[j] = r.Intn(4)
+		}
+
+		to := make([]int, r.Intn(12))
+		for j := range to {
+			to[j] = r.Intn(4)
+		}
+
+		edits := Diff(from, to)
+
+		got, err := Patch(from, edits)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err.Error())
+		}
+
+		if !slices.Equal(got, to) {
+			t.Fatalf("patch(%v, diff(%v, %v)) = %v", from, from, to, got)
+		}
+	}
+}
+
+func TestDiffMinimal(t *testing.T) {
+	edits := Diff([]string{"a", "b", "c", "a", "b", "b", "a"}, []string{"c", "b", "a", "b", "a", "c"})
+
+	var changes int
+
+	for _, edit := range edits {
+		if edit.Op != EditKeep {
+			changes++
+		}
+	}
+
+	if changes != 5 {
+		t.Errorf("expected 5 changes, got %d", changes)
+	}
+}
+
+func TestDiffFunc(t *testing.T) {
+	from := []string{"Package", "main", "func"}
+	to := []string{"package", "MAIN", "var", "func"}
+
+	eq := func(a, b string) bool { return strings.EqualFold(a, b) }
+
+	var inserts int
+
+	for _, edit := range DiffFunc(from, to, eq) {
+		if edit.Op == EditDelete {
+			t.Errorf("unexpected deletion of %q", edit.Elem)
+		} else if edit.Op == EditInsert {
+			inserts++
+		}
+	}
+
+	if inserts != 1 {
+		t.Errorf("expected 1 insertion, got %d", inserts)
+	}
+
+	got, err := PatchFunc(from, DiffFunc(from, to, eq), eq)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	if !slices.EqualFunc(got, to, eq) {
+		t.Errorf("expected %v, got %v", to, got)
+	}
+}
+
+func TestDiffShortest(t *testing.T) {
+	r := rand.New(rand.NewSource(2))
+
+	for i := 0; i < 500; i++ {
+		from := make([]int, r.Intn(20))
+		for j := range from {
+			from[j] = r.Intn(3)
+		}
+
+		to := make([]int, r.Intn(20))
+		for j := range to {
+			to[j] = r.Intn(3)
+		}
+
+		// lcs[a][b] is the length of the longest common subsequence of
+		// from[a:] and to[b:].
+		lcs := make([][]int, len(from)+1)
+		for a := range lcs {
+			lcs[a] = make([]int, len(to)+1)
+		}
+
+		for a := len(from) - 1; a >= 0; a-- {
+			for b := len(to) - 1; b >= 0; b-- {
+				if from[a] == to[b] {
+					lcs[a][b] = lcs[a+1][b+1] + 1
+				} else {
+					lcs[a][b] = max(lcs[a+1][b], lcs[a][b+1])
+				}
+			}
+		}
+
+		var changes int
+
+		for _, edit := range Diff(from, to) {
+			if edit.Op != EditKeep {
+				changes++
+			}
+		}
+
+		want := len(from) + len(to) - 2*lcs[0][0]
+		if changes != want {
+			t.Fatalf("diff(%v, %v): expected %d changes, got %d", from, to, want, changes)
+		}
+	}
+}
+
+func TestDiffMemory(t *testing.T) {
+	from := make([]int, 4000)
+	to := make([]int, 4000)
+
+	for i := range from {
+		from[i] = i
+		to[i] = -i - 1
+	}
+
+	var before, after runtime.MemStats
+
+	runtime.ReadMemStats(&before)
+	edits := Diff(from, to)
+	runtime.ReadMemStats(&after)
+
+	if len(edits) != 8000 {
+		t.Fatalf("expected 8000 edits, got %d", len(edits))
+	}
+
+	// The edits take 256 KB; the rest must not grow with the number of edits.
+	if alloc := after.TotalAlloc - before.TotalAlloc; alloc > 1<<20 {
+		t.Errorf("expected at most 1 MB to be allocated, got %d bytes", alloc)
+	}
+}
+
+func TestPatchErrors(t *testing.T) {
+	from := []int{1, 2, 3}
+
+	tests := []struct {
+		edits []Edit[int]
+		idx   int
+	}{
+		{[]Edit[int]{{Op: EditKeep, OldIndex: 0, Elem: 1}, {Op: EditDelete, OldIndex: 1, Elem: 5}}, 2},
+		{[]Edit[int]{{Op: EditOp(7)}}, 1},
+		{[]Edit[int]{{Op: EditKeep, OldIndex: 0, Elem: 1}}, 2},
+	}
+
+	for _, test := range tests {
+		_, err := Patch(from, test.edits)
+
+		var at *gcint.ErrAt
+
+		if !errors.As(err, &at) || at.Idx != test.idx {
+			t.Errorf("expected an error at edit %d, got %v", test.idx, err)
+		}
+	}
+}
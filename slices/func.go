@@ -0,0 +1,125 @@
+package slices
+
+// EqualsFunc is a function that checks whether two elements are equal.
+//
+// Parameters:
+//   - a: The first element.
+//   - b: The second element.
+//
+// Returns:
+//   - bool: True if the elements are equal, false otherwise.
+type EqualsFunc[T any] func(a, b T) bool
+
+// FindFunc is the same as Find but uses the given function to compare the
+// elements.
+//
+// Parameters:
+//   - S: slice of elements.
+//   - elem: element to find.
+//   - eq: the equality function.
+//
+// Returns:
+//   - int: index of the first occurrence of the element or -1 if not found.
+//
+// If eq is nil, -1 is returned.
+func FindFunc[T any](S []T, elem T, eq EqualsFunc[T]) int {
+	if len(S) == 0 || eq == nil {
+		return -1
+	}
+
+	for i, e := range S {
+		ok := eq(e, elem)
+		if ok {
+			return i
+		}
+	}
+
+	return -1
+}
+
+// UniquefyFunc is the same as Uniquefy but uses the given function to compare
+// the elements.
+//
+// Parameters:
+//   - S: slice of elements.
+//   - eq: the equality function.
+//   - prioritizeFirst: If true, the first occurrence of an element is kept.
+//     If false, the last occurrence of an element is kept.
+//
+// Returns:
+//   - []T: slice of elements with duplicates removed.
+//
+// Behavior:
+//   - The function preserves the order of the first occurrences of the elements.
+//   - The original slice is not modified.
+//   - If eq is nil, S is returned as is.
+func UniquefyFunc[T any](S []T, eq EqualsFunc[T], prioritizeFirst bool) []T {
+	if len(S) < 2 || eq == nil {
+		return S
+	}
+
+	unique := make([]T, 0, len(S))
+
+	for _, e := range S {
+		pos := FindFunc(unique, e, eq)
+
+		if pos == -1 {
+			unique = append(unique, e)
+		} else if !prioritizeFirst {
+			unique[pos] = e
+		}
+	}
+
+	return unique
+}
+
+// MergeUniqueFunc is the same as MergeUnique but uses the given function to
+// compare the elements.
+//
+// Parameters:
+//   - S1: first slice of elements.
+//   - S2: second slice of elements.
+//   - eq: the equality function.
+//
+// Returns:
+//   - []T: slice of elements with duplicates removed.
+//
+// Behaviors:
+//   - The function does preserve the order of the elements in the slices.
+//   - When duplicates occur, the first occurrence is kept.
+//   - If eq is nil, the slices are concatenated as is.
+func MergeUniqueFunc[T any](S1, S2 []T, eq EqualsFunc[T]) []T {
+	merged := make([]T, 0, len(S1)+len(S2))
+
+	merged = append(merged, S1...)
+	merged = append(merged, S2...)
+
+	return UniquefyFunc(merged, eq, true)
+}
+
+// IndexOfDuplicateFunc is the same as IndexOfDuplicate but uses the given
+// function to compare the elements.
+//
+// Parameters:
+//   - S: slice of elements.
+//   - eq: the equality function.
+//
+// Returns:
+//   - int: index of the first element that duplicates an earlier one or -1 if
+//     there are no duplicates.
+//
+// If eq is nil, -1 is returned.
+func IndexOfDuplicateFunc[T any](S []T, eq EqualsFunc[T]) int {
+	if len(S) < 2 || eq == nil {
+		return -1
+	}
+
+	for j := 1; j < len(S); j++ {
+		pos := FindFunc(S[:j], S[j], eq)
+		if pos != -1 {
+			return j
+		}
+	}
+
+	return -1
+}
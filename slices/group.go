@@ -0,0 +1,98 @@
+package slices
+
+import (
+	"cmp"
+
+	"github.com/PlayerR9/lib_units/maps"
+)
+
+// GroupBy groups the elements of a slice by key.
+//
+// Parameters:
+//   - S: slice of elements.
+//   - key: the function that returns the key of an element.
+//
+// Returns:
+//   - map[K][]T: the elements of each key, in their order in S. Nil if S is
+//     empty or key is nil.
+//
+// Example:
+//
+//	GroupBy([]string{"go", "c", "rust", "js"}, func(s string) int {
+//		return len(s)
+//	})
+//	// map[1:[c] 2:[go js] 4:[rust]]
+func GroupBy[T any, K comparable](S []T, key func(T) K) map[K][]T {
+	if len(S) == 0 || key == nil {
+		return nil
+	}
+
+	groups := make(map[K][]T)
+
+	for _, e := range S {
+		k := key(e)
+		groups[k] = append(groups[k], e)
+	}
+
+	return groups
+}
+
+// OrderedGroupBy is the same as GroupBy but returns the groups sorted by key.
+//
+// Parameters:
+//   - S: slice of elements.
+//   - key: the function that returns the key of an element.
+//
+// Returns:
+//   - *maps.OrderedMap[K, []T]: the elements of each key, in their order in S.
+//     Never nil; empty if S is empty or key is nil.
+func OrderedGroupBy[T any, K cmp.Ordered](S []T, key func(T) K) *maps.OrderedMap[K, []T] {
+	om := maps.NewOrderedMap[K, []T]()
+
+	for k, group := range GroupBy(S, key) {
+		om.AddUnsorted(k, group)
+	}
+
+	om.Reorder()
+
+	return om
+}
+
+// PartitionN splits a slice into several parts according to a classifier.
+// It generalizes the success/failure split of SFSeparate to any number of
+// parts.
+//
+// Parameters:
+//   - S: slice of elements.
+//   - n: the number of parts.
+//   - classify: the function that returns the index of the part of an element.
+//
+// Returns:
+//   - [][]T: the n parts, whose elements are in their order in S. Nil if n <= 0
+//     or classify is nil.
+//
+// Example:
+//
+//	PartitionN([]int{5, -1, 0, 3, -7}, 3, func(x int) int {
+//		return cmp.Compare(x, 0) + 1
+//	})
+//	// [[-1 -7] [0] [5 3]]
+//
+// Elements whose index is not in [0, n) are dropped.
+func PartitionN[T any](S []T, n int, classify func(T) int) [][]T {
+	if n <= 0 || classify == nil {
+		return nil
+	}
+
+	parts := make([][]T, n)
+
+	for _, e := range S {
+		idx := classify(e)
+
+		if idx >= 0 && idx < n {
+			parts[idx] = append(parts[idx], e)
+		}
+	}
+
+	return parts
+}
@@ -0,0 +1,29 @@
+package slices
+
+import (
+	"cmp"
+	"fmt"
+	"testing"
+)
+
+func TestGroupBy(t *testing.T) {
+	S := []string{"go", "c", "rust", "js"}
+
+	groups := GroupBy(S, func(s string) int { return len(s) })
+	if got := fmt.Sprint(groups); got != "map[1:[c] 2:[go js] 4:[rust]]" {
+		t.Errorf("expected map[1:[c] 2:[go js] 4:[rust]], got %s", got)
+	}
+
+	om := OrderedGroupBy(S, func(s string) int { return len(s) })
+	if got := fmt.Sprint(om.Keys(), om.Values()); got != "[1 2 4] [[c] [go js] [rust]]" {
+		t.Errorf("expected [1 2 4] [[c] [go js] [rust]], got %s", got)
+	}
+
+	parts := PartitionN([]int{5, -1, 0, 3, -7}, 3, func(x int) int {
+		return cmp.Compare(x, 0) + 1
+	})
+
+	if got := fmt.Sprint(parts); got != "[[-1 -7] [0] [5 3]]" {
+		t.Errorf("expected [[-1 -7] [0] [5 3]], got %s", got)
+	}
+}
@@ -0,0 +1,165 @@
+package slices
+
+// MultisetDifference removes from a slice one occurrence of an element per
+// occurrence of it in another slice.
+//
+// Parameters:
+//   - a: slice of elements.
+//   - b: slice of the elements to remove.
+//
+// Returns:
+//   - []T: the elements of a that remain, in their order in a. Nil if none.
+//
+// Example:
+//
+//	MultisetDifference([]int{1, 2, 2, 3, 2}, []int{2, 2}) // [1 3 2]
+//
+// Unlike a set difference, duplicates are significant: an element that occurs
+// more often in a than in b is kept as many times as it is in excess. The
+// earliest occurrences are the ones removed, and a is not modified.
+func MultisetDifference[T comparable](a, b []T) []T {
+	counts := count_elems(b)
+
+	var diff []T
+
+	for _, e := range a {
+		if counts[e] > 0 {
+			counts[e]--
+		} else {
+			diff = append(diff, e)
+		}
+	}
+
+	return diff
+}
+
+// MultisetIntersect keeps, from a slice, the occurrences of the elements that
+// also occur in another slice, up to the number of times they occur in it.
+//
+// Parameters:
+//   - a: slice of elements.
+//   - b: slice of elements.
+//
+// Returns:
+//   - []T: the elements of a that are kept, in their order in a. Nil if none.
+//
+// Example:
+//
+//	MultisetIntersect([]int{1, 2, 2, 3, 2}, []int{2, 3, 2, 4}) // [2 2 3]
+//
+// The earliest occurrences are the ones kept, and a is not modified. The
+// result has the same elements, counted with multiplicity, whichever order
+// the slices are given in.
+func MultisetIntersect[T comparable](a, b []T) []T {
+	counts := count_elems(b)
+
+	var inter []T
+
+	for _, e := range a {
+		if counts[e] > 0 {
+			counts[e]--
+			inter = append(inter, e)
+		}
+	}
+
+	return inter
+}
+
+// MultisetDifferenceFunc is the same as MultisetDifference but uses the given
+// function to compare the elements.
+//
+// Parameters:
+//   - a: slice of elements.
+//   - b: slice of the elements to remove.
+//   - eq: the equality function.
+//
+// Returns:
+//   - []T: the elements of a that remain, in their order in a. Nil if none.
+//
+// If eq is nil, a is returned as is.
+func MultisetDifferenceFunc[T any](a, b []T, eq EqualsFunc[T]) []T {
+	if eq == nil {
+		return a
+	}
+
+	used := make([]bool, len(b))
+
+	var diff []T
+
+	for _, e := range a {
+		if !take_match(b, used, e, eq) {
+			diff = append(diff, e)
+		}
+	}
+
+	return diff
+}
+
+// MultisetIntersectFunc is the same as MultisetIntersect but uses the given
+// function to compare the elements.
+//
+// Parameters:
+//   - a: slice of elements.
+//   - b: slice of elements.
+//   - eq: the equality function.
+//
+// Returns:
+//   - []T: the elements of a that are kept, in their order in a. Nil if none.
+//
+// If eq is nil, nil is returned.
+func MultisetIntersectFunc[T any](a, b []T, eq EqualsFunc[T]) []T {
+	if eq == nil {
+		return nil
+	}
+
+	used := make([]bool, len(b))
+
+	var inter []T
+
+	for _, e := range a {
+		if take_match(b, used, e, eq) {
+			inter = append(inter, e)
+		}
+	}
+
+	return inter
+}
+
+// count_elems counts the occurrences of each element of a slice.
+//
+// Parameters:
+//   - S: slice of elements.
+//
+// Returns:
+//   - map[T]int: the number of occurrences of each element. Never nil.
+func count_elems[T comparable](S []T) map[T]int {
+	counts := make(map[T]int, len(S))
+
+	for _, e := range S {
+		counts[e]++
+	}
+
+	return counts
+}
+
+// take_match marks as used the first unused element of a slice that is equal
+// to the given one.
+//
+// Parameters:
+//   - S: slice of elements.
+//   - used: whether each element of S is used.
+//   - elem: the element to match.
+//   - eq: the equality function.
+//
+// Returns:
+//   - bool: true if an element was marked, false otherwise.
+func take_match[T any](S []T, used []bool, elem T, eq EqualsFunc[T]) bool {
+	for i, e := range S {
+		if !used[i] && eq(e, elem) {
+			used[i] = true
+			return true
+		}
+	}
+
+	return false
+}
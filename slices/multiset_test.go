@@ -0,0 +1,34 @@
+package slices
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestMultiset(t *testing.T) {
+	a := []int{1, 2, 2, 3, 2}
+
+	diff := MultisetDifference(a, []int{2, 2, 4})
+	if !slices.Equal(diff, []int{1, 3, 2}) {
+		t.Errorf("expected [1 3 2], got %v", diff)
+	}
+
+	inter := MultisetIntersect(a, []int{2, 3, 2, 4})
+	if !slices.Equal(inter, []int{2, 2, 3}) {
+		t.Errorf("expected [2 2 3], got %v", inter)
+	}
+
+	eq := func(x, y int) bool { return x == y }
+
+	if got := MultisetDifferenceFunc(a, []int{2, 2, 4}, eq); !slices.Equal(got, diff) {
+		t.Errorf("expected %v, got %v", diff, got)
+	}
+
+	if got := MultisetIntersectFunc(a, []int{2, 3, 2, 4}, eq); !slices.Equal(got, inter) {
+		t.Errorf("expected %v, got %v", inter, got)
+	}
+
+	if MultisetDifference(a, a) != nil || MultisetIntersect(a, nil) != nil {
+		t.Errorf("expected nil results when nothing remains")
+	}
+}
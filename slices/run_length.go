@@ -0,0 +1,112 @@
+package slices
+
+import (
+	gcslc "github.com/PlayerR9/go-commons/slices"
+)
+
+// Run is a maximal sequence of consecutive equal elements.
+type Run[T any] struct {
+	// Value is the element that is repeated.
+	Value T
+
+	// Count is the number of repetitions. Always positive in the results of
+	// the encoding functions.
+	Count int
+}
+
+// RunLengthEncode groups the consecutive equal elements of a slice into runs.
+//
+// Parameters:
+//   - S: slice of elements.
+//
+// Returns:
+//   - []Run[T]: the runs, in order. Nil if S is empty.
+//
+// Example:
+//
+//	RunLengthEncode([]rune("aaab")) // [{'a' 3} {'b' 1}]
+func RunLengthEncode[T comparable](S []T) []Run[T] {
+	return RunLengthEncodeFunc(S, func(a, b T) bool {
+		return a == b
+	})
+}
+
+// RunLengthEncodeEquals is the same as RunLengthEncode but uses the Equals
+// method of the elements to compare them.
+//
+// Parameters:
+//   - S: slice of elements.
+//
+// Returns:
+//   - []Run[T]: the runs, in order. Nil if S is empty.
+func RunLengthEncodeEquals[T gcslc.Equaler](S []T) []Run[T] {
+	return RunLengthEncodeFunc(S, func(a, b T) bool {
+		return a.Equals(b)
+	})
+}
+
+// RunLengthEncodeFunc is the same as RunLengthEncode but uses the given
+// function to compare the elements.
+//
+// Parameters:
+//   - S: slice of elements.
+//   - eq: the equality function.
+//
+// Returns:
+//   - []Run[T]: the runs, in order. Nil if S is empty.
+//
+// Behaviors:
+//   - The value of a run is the first element of the run.
+//   - If eq is nil, every element is its own run.
+func RunLengthEncodeFunc[T any](S []T, eq EqualsFunc[T]) []Run[T] {
+	if len(S) == 0 {
+		return nil
+	}
+
+	runs := []Run[T]{{Value: S[0], Count: 1}}
+
+	for _, elem := range S[1:] {
+		last := &runs[len(runs)-1]
+
+		if eq != nil && eq(last.Value, elem) {
+			last.Count++
+		} else {
+			runs = append(runs, Run[T]{Value: elem, Count: 1})
+		}
+	}
+
+	return runs
+}
+
+// RunLengthDecode expands runs back into a slice.
+//
+// Parameters:
+//   - runs: the runs to expand.
+//
+// Returns:
+//   - []T: the elements. Nil if the runs contain no elements.
+//
+// Runs whose count is not positive are ignored.
+func RunLengthDecode[T any](runs []Run[T]) []T {
+	var size int
+
+	for _, run := range runs {
+		if run.Count > 0 {
+			size += run.Count
+		}
+	}
+
+	if size == 0 {
+		return nil
+	}
+
+	S := make([]T, 0, size)
+
+	for _, run := range runs {
+		for i := 0; i < run.Count; i++ {
+			S = append(S, run.Value)
+		}
+	}
+
+	return S
+}
@@ -0,0 +1,26 @@
+package slices
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestRunLength(t *testing.T) {
+	S := []rune("aaabccdddd")
+
+	runs := RunLengthEncode(S)
+
+	expected := []Run[rune]{{'a', 3}, {'b', 1}, {'c', 2}, {'d', 4}}
+	if !slices.Equal(runs, expected) {
+		t.Fatalf("expected %v, got %v", expected, runs)
+	}
+
+	decoded := RunLengthDecode(runs)
+	if !slices.Equal(decoded, S) {
+		t.Errorf("expected %q, got %q", string(S), string(decoded))
+	}
+
+	if RunLengthEncode([]int{}) != nil || RunLengthDecode([]Run[int]{{1, 0}}) != nil {
+		t.Errorf("expected nil results for empty inputs")
+	}
+}
@@ -0,0 +1,112 @@
+package slices
+
+import (
+	"errors"
+	"math/rand"
+	"slices"
+
+	gcers "github.com/PlayerR9/go-commons/errors"
+	luc "github.com/PlayerR9/lib_units/common"
+)
+
+// Sample returns n elements of the slice chosen at random, in the order in
+// which they appear in the slice.
+//
+// Parameters:
+//   - S: slice of elements.
+//   - n: the number of elements to choose.
+//   - seed: the seed of the random generator.
+//
+// Returns:
+//   - []T: the chosen elements. Nil if n <= 0 or S is empty.
+//
+// Behaviors:
+//   - The same seed always yields the same sample for the same input.
+//   - If n >= len(S), a copy of S is returned.
+func Sample[T any](S []T, n int, seed int64) []T {
+	if n <= 0 || len(S) == 0 {
+		return nil
+	}
+
+	if n >= len(S) {
+		return slices.Clone(S)
+	}
+
+	r := rand.New(rand.NewSource(seed))
+
+	indices := make([]int, len(S))
+	for i := range indices {
+		indices[i] = i
+	}
+
+	// Partial Fisher-Yates shuffle.
+	for i := 0; i < n; i++ {
+		j := i + r.Intn(len(indices)-i)
+		indices[i], indices[j] = indices[j], indices[i]
+	}
+
+	chosen := indices[:n]
+	slices.Sort(chosen)
+
+	sample := make([]T, 0, n)
+
+	for _, idx := range chosen {
+		sample = append(sample, S[idx])
+	}
+
+	return sample
+}
+
+// ReservoirSample returns n elements of the iterator chosen at random without
+// holding more than n elements in memory.
+//
+// Parameters:
+//   - it: the iterator to sample from.
+//   - n: the number of elements to choose.
+//   - seed: the seed of the random generator.
+//
+// Returns:
+//   - []T: the chosen elements. Nil if n <= 0.
+//   - error: an error if the iterator failed.
+//
+// Errors:
+//   - *errors.ErrInvalidParameter: If the iterator is nil.
+//   - any error returned by the iterator other than *common.ErrExhaustedIter. In
+//     that case, the sample collected so far is returned.
+//
+// Behaviors:
+//   - The same seed always yields the same sample for the same input.
+//   - If the iterator has at most n elements, all of them are returned.
+//   - The iterator is consumed until it is exhausted.
+func ReservoirSample[T any](it luc.Iterater[T], n int, seed int64) ([]T, error) {
+	if it == nil {
+		return nil, gcers.NewErrNilParameter("it")
+	} else if n <= 0 {
+		return nil, nil
+	}
+
+	r := rand.New(rand.NewSource(seed))
+
+	reservoir := make([]T, 0, n)
+
+	for count := 0; ; count++ {
+		elem, err := it.Consume()
+		if errors.Is(err, luc.ExhaustedIter) {
+			break
+		} else if err != nil {
+			return reservoir, err
+		}
+
+		if count < n {
+			reservoir = append(reservoir, elem)
+			continue
+		}
+
+		j := r.Intn(count + 1)
+		if j < n {
+			reservoir[j] = elem
+		}
+	}
+
+	return reservoir, nil
+}
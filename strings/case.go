@@ -0,0 +1,328 @@
+package strings
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+var (
+	// default_acronyms are the acronyms known by ToCamel and ToPascal unless
+	// WithoutAcronyms is used. They are the common initialisms of Go code.
+	default_acronyms []string
+)
+
+func init() {
+	default_acronyms = []string{
+		"ACL", "API", "ASCII", "CPU", "CSS", "DNS", "EOF", "GUID", "HTML",
+		"HTTP", "HTTPS", "ID", "IP", "JSON", "LHS", "QPS", "RAM", "RHS", "RPC",
+		"SLA", "SMTP", "SQL", "SSH", "TCP", "TLS", "TTL", "UDP", "UI", "UID",
+		"UUID", "URI", "URL", "UTF8", "VM", "XML", "XMPP", "XSRF", "XSS",
+	}
+}
+
+// case_config is the configuration of the case conversions.
+type case_config struct {
+	// acronyms are the words written in upper case, in upper case.
+	acronyms map[string]bool
+}
+
+// CaseOpt is an option of the case conversions, such as ToSnake and ToPascal.
+//
+// Parameters:
+//   - cfg: The configuration to modify.
+type CaseOpt func(cfg *case_config)
+
+// WithAcronyms adds words to the acronyms, which ToCamel and ToPascal write in
+// upper case and whose plurals, such as "IDs", are single words.
+//
+// Parameters:
+//   - words: The acronyms, in any case.
+//
+// Returns:
+//   - CaseOpt: The option.
+func WithAcronyms(words ...string) CaseOpt {
+	return func(cfg *case_config) {
+		for _, word := range words {
+			cfg.acronyms[strings.ToUpper(word)] = true
+		}
+	}
+}
+
+// WithoutAcronyms forgets the acronyms known so far, including the default
+// ones such as "ID", "URL" and "HTTP".
+//
+// Returns:
+//   - CaseOpt: The option.
+func WithoutAcronyms() CaseOpt {
+	return func(cfg *case_config) {
+		clear(cfg.acronyms)
+	}
+}
+
+// new_case_config creates the configuration of a set of options.
+//
+// Parameters:
+//   - opts: The options. Nil options are ignored.
+//
+// Returns:
+//   - case_config: The configuration.
+func new_case_config(opts []CaseOpt) case_config {
+	cfg := case_config{
+		acronyms: make(map[string]bool, len(default_acronyms)),
+	}
+
+	for _, word := range default_acronyms {
+		cfg.acronyms[word] = true
+	}
+
+	for _, opt := range opts {
+		if opt != nil {
+			opt(&cfg)
+		}
+	}
+
+	return cfg
+}
+
+// case_word is a word of an identifier, as converted by the functions of this
+// file.
+type case_word struct {
+	// text is the word, with its original case.
+	text string
+
+	// acronym is the length, in bytes, of the acronym the word starts with.
+	// Zero if it does not start with one.
+	acronym int
+}
+
+// title capitalizes the word, or writes its acronym in upper case.
+//
+// Returns:
+//   - string: The capitalized word.
+func (cw case_word) title() string {
+	if cw.acronym > 0 {
+		return strings.ToUpper(cw.text[:cw.acronym]) + strings.ToLower(cw.text[cw.acronym:])
+	}
+
+	first, size := utf8.DecodeRuneInString(cw.text)
+
+	return string(unicode.ToTitle(first)) + strings.ToLower(cw.text[size:])
+}
+
+// acronym_of returns the length of the acronym a word is, either as it is or
+// in the plural; such as "URL", "url" or "URLs".
+//
+// Parameters:
+//   - word: The word.
+//
+// Returns:
+//   - int: The length of the acronym, in bytes. Zero if the word is not one.
+func (cfg case_config) acronym_of(word string) int {
+	if cfg.acronyms[strings.ToUpper(word)] {
+		return len(word)
+	}
+
+	stem, ok := strings.CutSuffix(word, "s")
+	if ok && stem != "" && cfg.acronyms[strings.ToUpper(stem)] {
+		return len(stem)
+	}
+
+	return 0
+}
+
+// case_words splits an identifier into the words of its conversions.
+//
+// Parameters:
+//   - s: The identifier.
+//
+// Returns:
+//   - []case_word: The words. Nil if there are none.
+//
+// Unlike in SplitIdentifier, the plural of an acronym is one word, so that
+// "userIDs" has the words "user" and "IDs"; and a run of digits is part of the
+// word before it, so that "utf8Reader" has the words "utf8" and "Reader".
+// Acronyms are looked up before digits are merged; thus, "HTTP2Server" has the
+// words "HTTP2", which starts with the acronym "HTTP", and "Server".
+func (cfg case_config) case_words(s string) []case_word {
+	parts := SplitIdentifier(s)
+
+	var words []case_word
+
+	for i := 0; i < len(parts); i++ {
+		part := parts[i]
+
+		first, _ := utf8.DecodeRuneInString(part)
+
+		if len(words) > 0 && unicode.IsDigit(first) {
+			last := &words[len(words)-1]
+
+			last.text += part
+
+			if cfg.acronyms[strings.ToUpper(last.text)] {
+				last.acronym = len(last.text)
+			}
+
+			continue
+		}
+
+		// SplitIdentifier splits "IDs" into "I" and "Ds".
+		if i+1 < len(parts) && strings.ToUpper(part) == part {
+			next, size := utf8.DecodeRuneInString(parts[i+1])
+
+			if unicode.IsUpper(next) && parts[i+1][size:] == "s" && cfg.acronyms[part+string(next)] {
+				words = append(words, case_word{text: part + parts[i+1], acronym: len(part) + size})
+				i++
+
+				continue
+			}
+		}
+
+		words = append(words, case_word{text: part, acronym: cfg.acronym_of(part)})
+	}
+
+	return words
+}
+
+// join_words converts the case of each word of an identifier and joins them.
+//
+// Parameters:
+//   - s: The identifier.
+//   - sep: The separator between the words.
+//   - upper: Whether the words are in upper case instead of lower case.
+//   - opts: The options.
+//
+// Returns:
+//   - string: The converted identifier.
+func join_words(s, sep string, upper bool, opts []CaseOpt) string {
+	cfg := new_case_config(opts)
+
+	words := cfg.case_words(s)
+
+	texts := make([]string, 0, len(words))
+
+	for _, word := range words {
+		if upper {
+			texts = append(texts, strings.ToUpper(word.text))
+		} else {
+			texts = append(texts, strings.ToLower(word.text))
+		}
+	}
+
+	return strings.Join(texts, sep)
+}
+
+// ToSnake converts an identifier to snake_case.
+//
+// Parameters:
+//   - s: The identifier, split into words as SplitIdentifier does.
+//   - opts: The options.
+//
+// Returns:
+//   - string: The converted identifier.
+//
+// Example:
+//
+//	ToSnake("parseHTTPResponse") // "parse_http_response"
+//	ToSnake("userIDs") // "user_ids"
+//
+// The plurals of acronyms are words of their own; see ToPascal.
+func ToSnake(s string, opts ...CaseOpt) string {
+	return join_words(s, "_", false, opts)
+}
+
+// ToScreamingSnake converts an identifier to SCREAMING_SNAKE_CASE.
+//
+// Parameters:
+//   - s: The identifier, split into words as SplitIdentifier does.
+//   - opts: The options.
+//
+// Returns:
+//   - string: The converted identifier.
+//
+// Example:
+//
+//	ToScreamingSnake("maxRetryCount") // "MAX_RETRY_COUNT"
+//
+// As in ToSnake, except that the words are in upper case.
+func ToScreamingSnake(s string, opts ...CaseOpt) string {
+	return join_words(s, "_", true, opts)
+}
+
+// ToKebab converts an identifier to kebab-case.
+//
+// Parameters:
+//   - s: The identifier, split into words as SplitIdentifier does.
+//   - opts: The options.
+//
+// Returns:
+//   - string: The converted identifier.
+//
+// Example:
+//
+//	ToKebab("UserID") // "user-id"
+//
+// As in ToSnake, except that the words are separated by hyphens.
+func ToKebab(s string, opts ...CaseOpt) string {
+	return join_words(s, "-", false, opts)
+}
+
+// ToPascal converts an identifier to PascalCase.
+//
+// Parameters:
+//   - s: The identifier, split into words as SplitIdentifier does.
+//   - opts: The options.
+//
+// Returns:
+//   - string: The converted identifier.
+//
+// Example:
+//
+//	ToPascal("user_id_url") // "UserIDURL"
+//	ToPascal("user_id", WithoutAcronyms()) // "UserId"
+//
+// Acronyms are written in upper case, even when followed by digits or in the
+// plural such as "IDs", and the other words are capitalized.
+func ToPascal(s string, opts ...CaseOpt) string {
+	cfg := new_case_config(opts)
+
+	var builder strings.Builder
+
+	for _, word := range cfg.case_words(s) {
+		builder.WriteString(word.title())
+	}
+
+	return builder.String()
+}
+
+// ToCamel converts an identifier to camelCase.
+//
+// Parameters:
+//   - s: The identifier, split into words as SplitIdentifier does.
+//   - opts: The options.
+//
+// Returns:
+//   - string: The converted identifier.
+//
+// Example:
+//
+//	ToCamel("user_id") // "userID"
+//	ToCamel("URLPath") // "urlPath"
+//
+// As in ToPascal, except that the first word is in lower case, even if it is
+// an acronym.
+func ToCamel(s string, opts ...CaseOpt) string {
+	cfg := new_case_config(opts)
+
+	var builder strings.Builder
+
+	for i, word := range cfg.case_words(s) {
+		if i == 0 {
+			builder.WriteString(strings.ToLower(word.text))
+		} else {
+			builder.WriteString(word.title())
+		}
+	}
+
+	return builder.String()
+}
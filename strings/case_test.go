@@ -0,0 +1,63 @@
+package strings
+
+import "testing"
+
+func TestCaseConversions(t *testing.T) {
+	tests := []struct {
+		input     string
+		snake     string
+		screaming string
+		kebab     string
+		camel     string
+		pascal    string
+	}{
+		{"parseHTTPResponse", "parse_http_response", "PARSE_HTTP_RESPONSE", "parse-http-response", "parseHTTPResponse", "ParseHTTPResponse"},
+		{"userIDs", "user_ids", "USER_IDS", "user-ids", "userIDs", "UserIDs"},
+		{"user_ids", "user_ids", "USER_IDS", "user-ids", "userIDs", "UserIDs"},
+		{"listURLs", "list_urls", "LIST_URLS", "list-urls", "listURLs", "ListURLs"},
+		{"HTTP2Server", "http2_server", "HTTP2_SERVER", "http2-server", "http2Server", "HTTP2Server"},
+		{"utf8Reader", "utf8_reader", "UTF8_READER", "utf8-reader", "utf8Reader", "UTF8Reader"},
+		{"user_id_url", "user_id_url", "USER_ID_URL", "user-id-url", "userIDURL", "UserIDURL"},
+		{"URLPath", "url_path", "URL_PATH", "url-path", "urlPath", "URLPath"},
+		{"max-retry-count", "max_retry_count", "MAX_RETRY_COUNT", "max-retry-count", "maxRetryCount", "MaxRetryCount"},
+		{"", "", "", "", "", ""},
+	}
+
+	for _, test := range tests {
+		t.Run(test.input, func(t *testing.T) {
+			got := map[string][2]string{
+				"ToSnake":          {ToSnake(test.input), test.snake},
+				"ToScreamingSnake": {ToScreamingSnake(test.input), test.screaming},
+				"ToKebab":          {ToKebab(test.input), test.kebab},
+				"ToCamel":          {ToCamel(test.input), test.camel},
+				"ToPascal":         {ToPascal(test.input), test.pascal},
+			}
+
+			for name, pair := range got {
+				if pair[0] != pair[1] {
+					t.Errorf("%s(%q) = %q, want %q", name, test.input, pair[0], pair[1])
+				}
+			}
+		})
+	}
+}
+
+func TestCaseOptions(t *testing.T) {
+	tests := []struct {
+		name string
+		got  string
+		want string
+	}{
+		{"pascal without acronyms", ToPascal("user_id", WithoutAcronyms()), "UserId"},
+		{"snake without acronyms", ToSnake("userIDs", WithoutAcronyms()), "user_i_ds"},
+		{"pascal with acronym", ToPascal("grpc_client", WithAcronyms("grpc")), "GRPCClient"},
+		{"snake with acronym plural", ToSnake("allGPUs", WithAcronyms("GPU")), "all_gpus"},
+		{"camel with acronym", ToCamel("new_grpc_client", WithAcronyms("GRPC")), "newGRPCClient"},
+	}
+
+	for _, test := range tests {
+		if test.got != test.want {
+			t.Errorf("%s: got %q, want %q", test.name, test.got, test.want)
+		}
+	}
+}
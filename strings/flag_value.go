@@ -0,0 +1,124 @@
+package strings
+
+import (
+	"errors"
+	"strings"
+
+	gcint "github.com/PlayerR9/go-commons/ints"
+)
+
+// flag_escapes are the characters escaped by EncodeFlagValue, with their
+// escape sequences.
+var flag_escapes map[byte]string
+
+func init() {
+	flag_escapes = map[byte]string{
+		'%':  "%25",
+		' ':  "%20",
+		',':  "%2C",
+		'/':  "%2F",
+		'\\': "%5C",
+	}
+}
+
+// EncodeFlagValue escapes the characters that command-line flag values use as
+// delimiters, so that a value containing them survives being joined with
+// other values and split again.
+//
+// Parameters:
+//   - s: The value to encode.
+//
+// Returns:
+//   - string: The encoded value. It contains no comma, slash, backslash or
+//     space.
+//
+// Example:
+//
+//	EncodeFlagValue("map[string, int]") // "map[string%2C%20int]"
+//
+// The escape sequences are those of URL percent-encoding: "%" followed by the
+// two hexadecimal digits of the byte. Only "%", " ", ",", "/" and "\\" are
+// escaped, so that the encoded value stays readable. DecodeFlagValue reverses
+// the encoding.
+func EncodeFlagValue(s string) string {
+	var builder strings.Builder
+
+	builder.Grow(len(s))
+
+	for i := 0; i < len(s); i++ {
+		esc, ok := flag_escapes[s[i]]
+		if ok {
+			builder.WriteString(esc)
+		} else {
+			builder.WriteByte(s[i])
+		}
+	}
+
+	return builder.String()
+}
+
+// DecodeFlagValue reverses EncodeFlagValue.
+//
+// Parameters:
+//   - s: The encoded value.
+//
+// Returns:
+//   - string: The decoded value.
+//   - error: An error if s is not a valid encoded value.
+//
+// Errors:
+//   - *ints.ErrAt: If a "%" does not start one of the escape sequences of
+//     EncodeFlagValue. Its index is the 1-based position of the "%" byte.
+//
+// Hexadecimal digits are accepted in either case.
+func DecodeFlagValue(s string) (string, error) {
+	if strings.IndexByte(s, '%') == -1 {
+		return s, nil
+	}
+
+	var builder strings.Builder
+
+	builder.Grow(len(s))
+
+	for i := 0; i < len(s); i++ {
+		if s[i] != '%' {
+			builder.WriteByte(s[i])
+			continue
+		}
+
+		char, ok := decode_flag_escape(s[i:])
+		if !ok {
+			return "", gcint.NewErrAt(i+1, "byte", errors.New("invalid escape sequence"))
+		}
+
+		builder.WriteByte(char)
+		i += 2
+	}
+
+	return builder.String(), nil
+}
+
+// decode_flag_escape decodes the escape sequence at the start of a string.
+//
+// Parameters:
+//   - s: The string, which starts with "%".
+//
+// Returns:
+//   - byte: The escaped character.
+//   - bool: True if s starts with an escape sequence of EncodeFlagValue, false
+//     otherwise.
+func decode_flag_escape(s string) (byte, bool) {
+	if len(s) < 3 {
+		return 0, false
+	}
+
+	seq := strings.ToUpper(s[:3])
+
+	for char, esc := range flag_escapes {
+		if esc == seq {
+			return char, true
+		}
+	}
+
+	return 0, false
+}
@@ -0,0 +1,90 @@
+package strings
+
+import (
+	"strings"
+
+	gcint "github.com/PlayerR9/go-commons/ints"
+)
+
+// Pluralize chooses between the singular and plural forms of a word.
+//
+// Parameters:
+//   - count: The number of things the word refers to.
+//   - singular: The singular form, such as "file".
+//   - plural: The plural form, such as "files". If empty, it is the singular
+//     form followed by "s".
+//
+// Returns:
+//   - string: The singular form if count is 1 or -1, the plural form
+//     otherwise.
+//
+// Example:
+//
+//	fmt.Sprintf("%d %s", n, Pluralize(n, "entry", "entries")) // "0 entries"
+func Pluralize(count int, singular, plural string) string {
+	if count == 1 || count == -1 {
+		return singular
+	}
+
+	if plural == "" {
+		return singular + "s"
+	}
+
+	return plural
+}
+
+// Ordinal formats a number as an English ordinal.
+//
+// Parameters:
+//   - n: The number.
+//
+// Returns:
+//   - string: The ordinal, such as "1st", "2nd", "11th" or "-3rd".
+//
+// This is the same as ints.GetOrdinalSuffix of go-commons, which the error
+// messages of that module use.
+func Ordinal(n int) string {
+	return gcint.GetOrdinalSuffix(n)
+}
+
+// HumanizeList joins items as an English enumeration.
+//
+// Parameters:
+//   - items: The items.
+//   - conj: The conjunction before the last item, such as "and" or "or".
+//   - oxford: Whether a comma precedes the conjunction when there are more than
+//     two items.
+//
+// Returns:
+//   - string: The enumeration. Empty if there are no items.
+//
+// Example:
+//
+//	HumanizeList([]string{"a", "b", "c"}, "or", false) // "a, b or c"
+//	HumanizeList([]string{"a", "b", "c"}, "and", true) // "a, b, and c"
+//	HumanizeList([]string{"a", "b"}, "and", true)      // "a and b"
+func HumanizeList(items []string, conj string, oxford bool) string {
+	switch len(items) {
+	case 0:
+		return ""
+	case 1:
+		return items[0]
+	}
+
+	last := len(items) - 1
+
+	var builder strings.Builder
+
+	builder.WriteString(strings.Join(items[:last], ", "))
+
+	if oxford && last > 1 {
+		builder.WriteRune(',')
+	}
+
+	builder.WriteRune(' ')
+	builder.WriteString(conj)
+	builder.WriteRune(' ')
+	builder.WriteString(items[last])
+
+	return builder.String()
+}
@@ -0,0 +1,187 @@
+package strings
+
+import (
+	"go/token"
+	"strings"
+	"unicode"
+)
+
+// IsGoIdentifier checks whether the string is a valid Go identifier according
+// to the Go specification; that is, a letter or underscore followed by letters,
+// underscores or Unicode digits, that is not a keyword.
+//
+// Parameters:
+//   - s: The string to check.
+//
+// Returns:
+//   - bool: True if the string is a valid Go identifier, false otherwise.
+func IsGoIdentifier(s string) bool {
+	return token.IsIdentifier(s)
+}
+
+// IsExported checks whether the string is an exported Go identifier; that is,
+// a valid identifier whose first character is an upper-case letter.
+//
+// Parameters:
+//   - s: The string to check.
+//
+// Returns:
+//   - bool: True if the string is an exported Go identifier, false otherwise.
+func IsExported(s string) bool {
+	return token.IsIdentifier(s) && token.IsExported(s)
+}
+
+// SanitizeIdentifier turns the string into a valid Go identifier.
+//
+// Parameters:
+//   - s: The string to sanitize.
+//
+// Returns:
+//   - string: The sanitized identifier. Never empty.
+//
+// Behaviors:
+//   - Characters that are neither letters, digits nor underscores are replaced
+//     with underscores.
+//   - If the string starts with a digit, it is prefixed with an underscore.
+//   - If the string is a keyword, it is suffixed with an underscore.
+//   - If the string is empty, "_" is returned.
+func SanitizeIdentifier(s string) string {
+	if s == "" {
+		return "_"
+	}
+
+	var builder strings.Builder
+
+	for i, r := range s {
+		if i == 0 && unicode.IsDigit(r) {
+			builder.WriteRune('_')
+		}
+
+		if r == '_' || unicode.IsLetter(r) || unicode.IsDigit(r) {
+			builder.WriteRune(r)
+		} else {
+			builder.WriteRune('_')
+		}
+	}
+
+	str := builder.String()
+
+	if token.IsKeyword(str) {
+		str += "_"
+	}
+
+	return str
+}
+
+// rune_class is the class of a character of an identifier, as seen by
+// SplitIdentifier.
+type rune_class int
+
+const (
+	// class_sep is a separator, such as an underscore or a space.
+	class_sep rune_class = iota
+
+	// class_upper is an upper-case or title-case letter.
+	class_upper
+
+	// class_lower is any other letter.
+	class_lower
+
+	// class_digit is a digit.
+	class_digit
+)
+
+// class_of returns the class of a character.
+//
+// Parameters:
+//   - r: The character.
+//
+// Returns:
+//   - rune_class: The class.
+func class_of(r rune) rune_class {
+	switch {
+	case unicode.IsUpper(r), unicode.IsTitle(r):
+		return class_upper
+	case unicode.IsLetter(r):
+		return class_lower
+	case unicode.IsDigit(r):
+		return class_digit
+	default:
+		return class_sep
+	}
+}
+
+// SplitIdentifier splits an identifier into its words.
+//
+// Parameters:
+//   - s: The identifier. It may use any mix of camelCase, PascalCase,
+//     snake_case and kebab-case.
+//
+// Returns:
+//   - []string: The words, in order and with their original case. Nil if
+//     there are none.
+//
+// Example:
+//
+//	SplitIdentifier("parseHTTPResponse2XML")
+//	// [parse HTTP Response 2 XML]
+//
+// Behaviors:
+//   - Characters that are neither letters nor digits separate words and are
+//     dropped.
+//   - A word starts at an upper-case letter that follows a lower-case one, and
+//     at the last letter of a run of upper-case letters that is followed by a
+//     lower-case one; thus, acronyms are kept whole.
+//   - Runs of digits are words of their own.
+func SplitIdentifier(s string) []string {
+	chars := []rune(s)
+
+	var words []string
+	start := -1
+
+	for i, r := range chars {
+		class := class_of(r)
+
+		if class == class_sep {
+			if start >= 0 {
+				words = append(words, string(chars[start:i]))
+				start = -1
+			}
+
+			continue
+		}
+
+		if start < 0 {
+			start = i
+			continue
+		}
+
+		prev := class_of(chars[i-1])
+
+		var is_boundary bool
+
+		switch {
+		case (prev == class_digit) != (class == class_digit):
+			is_boundary = true
+		case prev == class_lower && class == class_upper:
+			is_boundary = true
+		case prev == class_upper && class == class_lower:
+			// "HTTPResponse": the word starts at the 'R', not at the 'e'.
+			if i-1 > start {
+				words = append(words, string(chars[start:i-1]))
+				start = i - 1
+			}
+		}
+
+		if is_boundary {
+			words = append(words, string(chars[start:i]))
+			start = i
+		}
+	}
+
+	if start >= 0 {
+		words = append(words, string(chars[start:]))
+	}
+
+	return words
+}
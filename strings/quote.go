@@ -0,0 +1,127 @@
+package strings
+
+import (
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// QuoteGoString quotes the string as an interpreted Go string literal.
+//
+// Parameters:
+//   - s: The string to quote.
+//
+// Returns:
+//   - string: The double-quoted literal.
+func QuoteGoString(s string) string {
+	return strconv.Quote(s)
+}
+
+// can_be_raw checks whether the string can be written as a raw Go string
+// literal without changing its value or hiding characters.
+//
+// Parameters:
+//   - s: The string to check.
+//
+// Returns:
+//   - bool: True if the string can be a raw literal, false otherwise.
+func can_be_raw(s string) bool {
+	if !utf8.ValidString(s) {
+		return false
+	}
+
+	for _, r := range s {
+		switch {
+		case r == '`', r == '\r', r == '\uFEFF':
+			return false
+		case r == '\n', r == '\t':
+			// Allowed.
+		case !unicode.IsPrint(r):
+			return false
+		}
+	}
+
+	return true
+}
+
+// QuoteGoRawString quotes the string as a raw Go string literal (between
+// backticks) when it is safe to do so and as an interpreted one otherwise.
+//
+// Parameters:
+//   - s: The string to quote.
+//
+// Returns:
+//   - string: The quoted literal.
+//
+// A raw literal is not used if the string contains backticks, carriage returns
+// (which are discarded from raw literals), byte order marks, non-printable
+// characters other than newlines and tabs, or invalid UTF-8.
+func QuoteGoRawString(s string) string {
+	if !can_be_raw(s) {
+		return strconv.Quote(s)
+	}
+
+	var builder strings.Builder
+
+	builder.WriteRune('`')
+	builder.WriteString(s)
+	builder.WriteRune('`')
+
+	return builder.String()
+}
+
+// QuoteRuneLiteral quotes the character as a Go rune literal.
+//
+// Parameters:
+//   - r: The character to quote.
+//
+// Returns:
+//   - string: The single-quoted literal.
+func QuoteRuneLiteral(r rune) string {
+	return strconv.QuoteRune(r)
+}
+
+// EscapeForComment makes the string safe to embed in a Go comment of either
+// form ("//" or "/* */") on a single line.
+//
+// Parameters:
+//   - s: The string to escape.
+//
+// Returns:
+//   - string: The escaped string.
+//
+// Behaviors:
+//   - Line breaks are written as the escape sequences "\n" and "\r".
+//   - "*/" is written as "*\/" so that block comments are not closed early.
+//   - Other non-printable characters and invalid UTF-8 bytes are written as
+//     Go escape sequences.
+func EscapeForComment(s string) string {
+	var builder strings.Builder
+
+	for i := 0; i < len(s); {
+		r, size := utf8.DecodeRuneInString(s[i:])
+
+		switch {
+		case r == utf8.RuneError && size == 1:
+			builder.WriteString(`\x`)
+			builder.WriteString(strconv.FormatUint(uint64(s[i])>>4, 16))
+			builder.WriteString(strconv.FormatUint(uint64(s[i])&0xF, 16))
+		case r == '\n':
+			builder.WriteString(`\n`)
+		case r == '\r':
+			builder.WriteString(`\r`)
+		case r == '*' && strings.HasPrefix(s[i+size:], "/"):
+			builder.WriteString(`*\`)
+		case r == '\t' || unicode.IsPrint(r):
+			builder.WriteRune(r)
+		default:
+			quoted := strconv.QuoteRune(r)
+			builder.WriteString(quoted[1 : len(quoted)-1])
+		}
+
+		i += size
+	}
+
+	return builder.String()
+}
@@ -0,0 +1,133 @@
+package strings
+
+import (
+	"strings"
+)
+
+// Segment is a piece of a string produced by SplitKeep.
+type Segment struct {
+	// Text is the text of the segment.
+	Text string
+
+	// IsSep is true if the segment is a separator.
+	IsSep bool
+
+	// Start is the byte offset of the segment in the original string.
+	Start int
+}
+
+// End returns the byte offset right after the segment in the original string.
+//
+// Returns:
+//   - int: The end offset.
+func (s Segment) End() int {
+	return s.Start + len(s.Text)
+}
+
+// next_sep is a helper function that finds the first separator in s.
+//
+// Parameters:
+//   - s: The string to search.
+//   - seps: The separators.
+//
+// Returns:
+//   - int: The byte offset of the separator. -1 if none is found.
+//   - int: The length of the separator. 0 if none is found.
+//
+// When several separators start at the same offset, the longest one wins.
+func next_sep(s string, seps []string) (int, int) {
+	pos, size := -1, 0
+
+	for _, sep := range seps {
+		if sep == "" {
+			continue
+		}
+
+		idx := strings.Index(s, sep)
+		if idx == -1 {
+			continue
+		}
+
+		if pos == -1 || idx < pos || (idx == pos && len(sep) > size) {
+			pos, size = idx, len(sep)
+		}
+	}
+
+	return pos, size
+}
+
+// SplitKeep splits the string around the separators while keeping the
+// separators as segments of their own.
+//
+// Parameters:
+//   - s: The string to split.
+//   - seps: The separators. Empty separators are ignored.
+//
+// Returns:
+//   - []Segment: The segments in order. Nil if s is empty.
+//
+// Behaviors:
+//   - Concatenating the Text of all the segments yields s back.
+//   - No empty text segment is produced; two adjacent separators are two
+//     consecutive separator segments.
+//   - When several separators match at the same offset, the longest one is
+//     used.
+func SplitKeep(s string, seps []string) []Segment {
+	if s == "" {
+		return nil
+	}
+
+	var segments []Segment
+
+	offset := 0
+
+	for offset < len(s) {
+		pos, size := next_sep(s[offset:], seps)
+		if pos == -1 {
+			break
+		}
+
+		if pos > 0 {
+			segments = append(segments, Segment{
+				Text:  s[offset : offset+pos],
+				Start: offset,
+			})
+		}
+
+		segments = append(segments, Segment{
+			Text:  s[offset+pos : offset+pos+size],
+			IsSep: true,
+			Start: offset + pos,
+		})
+
+		offset += pos + size
+	}
+
+	if offset < len(s) {
+		segments = append(segments, Segment{
+			Text:  s[offset:],
+			Start: offset,
+		})
+	}
+
+	return segments
+}
+
+// JoinSegments concatenates the text of the segments.
+//
+// Parameters:
+//   - segments: The segments to join.
+//
+// Returns:
+//   - string: The joined string.
+//
+// Joining the unmodified result of SplitKeep yields the original string.
+func JoinSegments(segments []Segment) string {
+	var builder strings.Builder
+
+	for _, seg := range segments {
+		builder.WriteString(seg.Text)
+	}
+
+	return builder.String()
+}
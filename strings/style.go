@@ -0,0 +1,209 @@
+package strings
+
+import (
+	"io"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Color is an ANSI terminal color.
+type Color int
+
+const (
+	// Black is the black color.
+	Black Color = iota
+
+	// Red is the red color.
+	Red
+
+	// Green is the green color.
+	Green
+
+	// Yellow is the yellow color.
+	Yellow
+
+	// Blue is the blue color.
+	Blue
+
+	// Magenta is the magenta color.
+	Magenta
+
+	// Cyan is the cyan color.
+	Cyan
+
+	// White is the white color.
+	White
+)
+
+// style is the set of SGR parameters to apply.
+type style struct {
+	// codes is the list of SGR parameters.
+	codes []string
+}
+
+// StyleOpt is an option of Style.
+//
+// Parameters:
+//   - s: The style to modify.
+type StyleOpt func(s *style)
+
+// WithColor sets the foreground color.
+//
+// Parameters:
+//   - c: The color.
+//
+// Returns:
+//   - StyleOpt: The option.
+func WithColor(c Color) StyleOpt {
+	return func(s *style) {
+		s.codes = append(s.codes, strconv.Itoa(30+int(c)))
+	}
+}
+
+// WithBackground sets the background color.
+//
+// Parameters:
+//   - c: The color.
+//
+// Returns:
+//   - StyleOpt: The option.
+func WithBackground(c Color) StyleOpt {
+	return func(s *style) {
+		s.codes = append(s.codes, strconv.Itoa(40+int(c)))
+	}
+}
+
+// WithBold makes the text bold.
+//
+// Returns:
+//   - StyleOpt: The option.
+func WithBold() StyleOpt {
+	return func(s *style) {
+		s.codes = append(s.codes, "1")
+	}
+}
+
+// WithFaint makes the text faint.
+//
+// Returns:
+//   - StyleOpt: The option.
+func WithFaint() StyleOpt {
+	return func(s *style) {
+		s.codes = append(s.codes, "2")
+	}
+}
+
+// WithItalic makes the text italic.
+//
+// Returns:
+//   - StyleOpt: The option.
+func WithItalic() StyleOpt {
+	return func(s *style) {
+		s.codes = append(s.codes, "3")
+	}
+}
+
+// WithUnderline underlines the text.
+//
+// Returns:
+//   - StyleOpt: The option.
+func WithUnderline() StyleOpt {
+	return func(s *style) {
+		s.codes = append(s.codes, "4")
+	}
+}
+
+// Style wraps the string in ANSI escape sequences according to the options.
+//
+// Parameters:
+//   - s: The string to style.
+//   - opts: The style options.
+//
+// Returns:
+//   - string: The styled string. If no option is given, s is returned as is.
+func Style(s string, opts ...StyleOpt) string {
+	var st style
+
+	for _, opt := range opts {
+		if opt != nil {
+			opt(&st)
+		}
+	}
+
+	if len(st.codes) == 0 {
+		return s
+	}
+
+	var builder strings.Builder
+
+	builder.WriteString("\x1b[")
+	builder.WriteString(strings.Join(st.codes, ";"))
+	builder.WriteRune('m')
+	builder.WriteString(s)
+	builder.WriteString("\x1b[0m")
+
+	return builder.String()
+}
+
+var (
+	// ansi_regex matches ANSI CSI escape sequences.
+	ansi_regex *regexp.Regexp
+)
+
+func init() {
+	ansi_regex = regexp.MustCompile("\x1b\\[[0-?]*[ -/]*[@-~]")
+}
+
+// StripStyle removes every ANSI escape sequence from the string.
+//
+// Parameters:
+//   - s: The string to strip.
+//
+// Returns:
+//   - string: The string without escape sequences.
+func StripStyle(s string) string {
+	return ansi_regex.ReplaceAllString(s, "")
+}
+
+// SupportsColor checks whether ANSI colors should be written to the writer.
+//
+// Parameters:
+//   - w: The writer to check.
+//
+// Returns:
+//   - bool: True if colors are supported, false otherwise.
+//
+// Behaviors:
+//   - If the NO_COLOR environment variable is set, false is returned.
+//   - If the FORCE_COLOR environment variable is set, true is returned.
+//   - If TERM is "dumb", false is returned.
+//   - Otherwise, true is returned only if w is a terminal.
+func SupportsColor(w io.Writer) bool {
+	_, ok := os.LookupEnv("NO_COLOR")
+	if ok {
+		return false
+	}
+
+	_, ok = os.LookupEnv("FORCE_COLOR")
+	if ok {
+		return true
+	}
+
+	if os.Getenv("TERM") == "dumb" {
+		return false
+	}
+
+	f, ok := w.(*os.File)
+	if !ok || f == nil {
+		return false
+	}
+
+	info, err := f.Stat()
+	if err != nil {
+		return false
+	}
+
+	return info.Mode()&os.ModeCharDevice != 0
+}
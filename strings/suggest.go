@@ -0,0 +1,39 @@
+package strings
+
+import (
+	"strings"
+
+	luc "github.com/PlayerR9/lib_units/common"
+)
+
+// FormatSuggestions formats the candidates closest to a mistyped input as a
+// "did you mean" sentence.
+//
+// Parameters:
+//   - input: The mistyped input, such as a flag or type name.
+//   - candidates: The accepted values.
+//   - max: The maximum number of suggestions. Values below 1 mean no limit.
+//
+// Returns:
+//   - string: The sentence. Empty if no candidate is close enough.
+//
+// Example:
+//
+//	FormatSuggestions("stauts", []string{"status", "stats", "start"}, 3)
+//	// "did you mean: stats, status or start?"
+//
+// The candidates are ranked with common.ClosestCandidatesN.
+func FormatSuggestions(input string, candidates []string, max int) string {
+	suggestions := luc.ClosestCandidatesN(input, candidates, max)
+	if len(suggestions) == 0 {
+		return ""
+	}
+
+	var builder strings.Builder
+
+	builder.WriteString("did you mean: ")
+	builder.WriteString(HumanizeList(suggestions, "or", false))
+	builder.WriteRune('?')
+
+	return builder.String()
+}
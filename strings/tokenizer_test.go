@@ -0,0 +1,74 @@
+package strings
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+
+	gcint "github.com/PlayerR9/go-commons/ints"
+	gcstr "github.com/PlayerR9/go-commons/strings"
+)
+
+func TestTokenizer(t *testing.T) {
+	tk, err := NewTokenizer(
+		Delimiters{Open: "{", Close: "}"},
+		Delimiters{Open: "#", Close: "\n"},
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	tokens := []string{"{", "a", "#", "b", "\n", "}", "#", "c"}
+
+	regions, err := tk.Regions(tokens)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := []Region{{Pair: 0, Start: 1, End: 6}, {Pair: 1, Start: 7, End: 8}}
+	if !slices.Equal(regions, expected) {
+		t.Errorf("expected %v, got %v", expected, regions)
+	}
+
+	_, err = tk.Regions([]string{"{", "#", "a", "}"})
+
+	var at *gcint.ErrAt
+
+	var not_found *gcstr.ErrTokenNotFound
+
+	if !errors.As(err, &at) || at.Idx != 2 || !errors.As(err, &not_found) {
+		t.Errorf("expected the comment to be left open at token 2, got %v", err)
+	}
+}
+
+func TestTokenizerClosingPrecedence(t *testing.T) {
+	// ")" both closes the first pair and opens the second one.
+	tk, err := NewTokenizer(
+		Delimiters{Open: "(", Close: ")"},
+		Delimiters{Open: ")", Close: "]"},
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	regions, err := tk.Regions(strings.Fields("( a ) b ]"))
+
+	expected := []Region{{Pair: 0, Start: 1, End: 3}}
+	if !slices.Equal(regions, expected) {
+		t.Errorf("expected %v, got %v", expected, regions)
+	}
+
+	var at *gcint.ErrAt
+
+	var never *gcstr.ErrNeverOpened
+
+	if !errors.As(err, &at) || at.Idx != 5 || !errors.As(err, &never) {
+		t.Errorf("expected \"]\" to be never opened at token 5, got %v", err)
+	}
+
+	_, err = NewTokenizer()
+	if err == nil {
+		t.Errorf("expected an error for no delimiters")
+	}
+}